@@ -6,6 +6,7 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/xw1nchester/kushfinds-backend/internal/app"
 	"github.com/xw1nchester/kushfinds-backend/internal/config"
@@ -15,6 +16,11 @@ import (
 	_ "github.com/xw1nchester/kushfinds-backend/docs"
 )
 
+const (
+	envLocal = "local"
+	envDev   = "dev"
+)
+
 //	@title			Kushfinds API
 //	@version		1.0
 //	@description	API Server for Kushfinds application
@@ -28,9 +34,7 @@ import (
 func main() {
 	cfg := config.MustLoad()
 
-	config := zap.NewDevelopmentConfig()
-	config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-	log, _ := config.Build()
+	log := setupLogger(cfg.Env)
 
 	app := app.New(log, *cfg)
 
@@ -44,10 +48,37 @@ func main() {
 	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
 	<-quit
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second+cfg.Health.DrainDelay)
 	defer cancel()
 
 	app.Shutdown(ctx)
 
 	log.Info("server shutting down")
 }
+
+// setupLogger keeps the colored console output for local runs and switches
+// to JSON for deployed environments, with SQL debug logs on dev only.
+// Any other env value, prod included, gets the production config.
+func setupLogger(env string) *zap.Logger {
+	var config zap.Config
+
+	switch env {
+	case envLocal:
+		config = zap.NewDevelopmentConfig()
+		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	case envDev:
+		config = zap.NewProductionConfig()
+		config.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+		config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	default:
+		config = zap.NewProductionConfig()
+		config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	}
+
+	log, err := config.Build()
+	if err != nil {
+		panic("failed to build logger: " + err.Error())
+	}
+
+	return log
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/config"
+	"github.com/xw1nchester/kushfinds-backend/internal/seeder"
+	pgclient "github.com/xw1nchester/kushfinds-backend/pkg/client/postgresql"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func main() {
+	var profile, fixturesPath string
+
+	flag.StringVar(&profile, "profile", seeder.ProfileReference, "fixtures profile: reference or demo")
+	flag.StringVar(&fixturesPath, "fixtures-path", "fixtures", "path to fixtures")
+
+	// parses the flags above together with --config
+	cfg := config.MustLoad()
+
+	logConfig := zap.NewDevelopmentConfig()
+	logConfig.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	log, _ := logConfig.Build()
+
+	ctx := context.Background()
+
+	pgClient, err := pgclient.New(ctx, pgclient.Config{
+		Username: cfg.PostgreSQL.Username,
+		Password: cfg.PostgreSQL.Password,
+		Host:     cfg.PostgreSQL.Host,
+		Port:     cfg.PostgreSQL.Port,
+		Database: cfg.PostgreSQL.Database,
+	})
+	if err != nil {
+		log.Fatal("failed to connect to database", zap.Error(err))
+	}
+	defer pgClient.Close()
+
+	if err := seeder.New(pgClient, log).Run(ctx, fixturesPath, profile); err != nil {
+		log.Fatal("failed to seed database", zap.Error(err))
+	}
+
+	log.Info("fixtures have been successfully applied", zap.String("profile", profile))
+}
@@ -32,7 +32,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.AdminBusinessProfileRequest"
+                            "$ref": "#/definitions/handler.BusinessProfileRequest"
                         }
                     }
                 ],
@@ -1310,47 +1310,6 @@ const docTemplate = `{
                 }
             }
         },
-        "handler.AdminBusinessProfileRequest": {
-            "type": "object",
-            "required": [
-                "businessIndustryId",
-                "businessName",
-                "countryId",
-                "email",
-                "isVerified",
-                "phoneNumber",
-                "regionId",
-                "stateId"
-            ],
-            "properties": {
-                "businessIndustryId": {
-                    "type": "integer"
-                },
-                "businessName": {
-                    "type": "string",
-                    "maxLength": 30,
-                    "minLength": 3
-                },
-                "countryId": {
-                    "type": "integer"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "isVerified": {
-                    "type": "boolean"
-                },
-                "phoneNumber": {
-                    "type": "string"
-                },
-                "regionId": {
-                    "type": "integer"
-                },
-                "stateId": {
-                    "type": "integer"
-                }
-            }
-        },
         "handler.BrandRequest": {
             "type": "object",
             "required": [
@@ -9,8 +9,13 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
+	"github.com/riandyrn/otelchi"
 	"github.com/swaggo/http-swagger/v2"
 	_ "github.com/xw1nchester/kushfinds-backend/docs"
+	"github.com/xw1nchester/kushfinds-backend/internal/audit"
+	auditdb "github.com/xw1nchester/kushfinds-backend/internal/audit/db"
+	audithandler "github.com/xw1nchester/kushfinds-backend/internal/audit/handler"
+	auditservice "github.com/xw1nchester/kushfinds-backend/internal/audit/service"
 	"github.com/xw1nchester/kushfinds-backend/internal/auth"
 	authdb "github.com/xw1nchester/kushfinds-backend/internal/auth/db"
 	authhandler "github.com/xw1nchester/kushfinds-backend/internal/auth/handler"
@@ -21,6 +26,12 @@ import (
 	codedb "github.com/xw1nchester/kushfinds-backend/internal/code/db"
 	codeservice "github.com/xw1nchester/kushfinds-backend/internal/code/service"
 	"github.com/xw1nchester/kushfinds-backend/internal/config"
+	"github.com/xw1nchester/kushfinds-backend/internal/health"
+	healthhandler "github.com/xw1nchester/kushfinds-backend/internal/health/handler"
+	"github.com/xw1nchester/kushfinds-backend/internal/i18n"
+	"github.com/xw1nchester/kushfinds-backend/internal/idempotency"
+	idempotencydb "github.com/xw1nchester/kushfinds-backend/internal/idempotency/db"
+	idempotencymiddleware "github.com/xw1nchester/kushfinds-backend/internal/idempotency/middleware"
 	countrydb "github.com/xw1nchester/kushfinds-backend/internal/location/country/db"
 	countryhandler "github.com/xw1nchester/kushfinds-backend/internal/location/country/handler"
 	countryservice "github.com/xw1nchester/kushfinds-backend/internal/location/country/service"
@@ -29,12 +40,31 @@ import (
 	statedb "github.com/xw1nchester/kushfinds-backend/internal/location/state/db"
 	statehandler "github.com/xw1nchester/kushfinds-backend/internal/location/state/handler"
 	stateservice "github.com/xw1nchester/kushfinds-backend/internal/location/state/service"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
 	branddb "github.com/xw1nchester/kushfinds-backend/internal/market/brand/db"
 	brandhandler "github.com/xw1nchester/kushfinds-backend/internal/market/brand/handler"
 	brandservice "github.com/xw1nchester/kushfinds-backend/internal/market/brand/service"
+	favoritedb "github.com/xw1nchester/kushfinds-backend/internal/market/favorite/db"
+	favoritehandler "github.com/xw1nchester/kushfinds-backend/internal/market/favorite/handler"
+	favoriteservice "github.com/xw1nchester/kushfinds-backend/internal/market/favorite/service"
 	industrydb "github.com/xw1nchester/kushfinds-backend/internal/market/industry/db"
 	industryhandler "github.com/xw1nchester/kushfinds-backend/internal/market/industry/handler"
 	industryservice "github.com/xw1nchester/kushfinds-backend/internal/market/industry/service"
+	menudb "github.com/xw1nchester/kushfinds-backend/internal/market/menu/db"
+	menuhandler "github.com/xw1nchester/kushfinds-backend/internal/market/menu/handler"
+	menuservice "github.com/xw1nchester/kushfinds-backend/internal/market/menu/service"
+	orderdb "github.com/xw1nchester/kushfinds-backend/internal/market/order/db"
+	orderhandler "github.com/xw1nchester/kushfinds-backend/internal/market/order/handler"
+	orderservice "github.com/xw1nchester/kushfinds-backend/internal/market/order/service"
+	productdb "github.com/xw1nchester/kushfinds-backend/internal/market/product/db"
+	producthandler "github.com/xw1nchester/kushfinds-backend/internal/market/product/handler"
+	productservice "github.com/xw1nchester/kushfinds-backend/internal/market/product/service"
+	promotiondb "github.com/xw1nchester/kushfinds-backend/internal/market/promotion/db"
+	promotionhandler "github.com/xw1nchester/kushfinds-backend/internal/market/promotion/handler"
+	promotionservice "github.com/xw1nchester/kushfinds-backend/internal/market/promotion/service"
+	reviewdb "github.com/xw1nchester/kushfinds-backend/internal/market/review/db"
+	reviewhandler "github.com/xw1nchester/kushfinds-backend/internal/market/review/handler"
+	reviewservice "github.com/xw1nchester/kushfinds-backend/internal/market/review/service"
 	marketsectiondb "github.com/xw1nchester/kushfinds-backend/internal/market/section/db"
 	marketsectionhandler "github.com/xw1nchester/kushfinds-backend/internal/market/section/handler"
 	marketsectionservice "github.com/xw1nchester/kushfinds-backend/internal/market/section/service"
@@ -44,8 +74,18 @@ import (
 	storedb "github.com/xw1nchester/kushfinds-backend/internal/market/store/db"
 	storehandler "github.com/xw1nchester/kushfinds-backend/internal/market/store/handler"
 	storeservice "github.com/xw1nchester/kushfinds-backend/internal/market/store/service"
+	"github.com/xw1nchester/kushfinds-backend/internal/metrics"
+	referencedb "github.com/xw1nchester/kushfinds-backend/internal/reference/db"
+	referencehandler "github.com/xw1nchester/kushfinds-backend/internal/reference/handler"
+	referenceservice "github.com/xw1nchester/kushfinds-backend/internal/reference/service"
+	teamdb "github.com/xw1nchester/kushfinds-backend/internal/team/db"
+	teamhandler "github.com/xw1nchester/kushfinds-backend/internal/team/handler"
+	teamservice "github.com/xw1nchester/kushfinds-backend/internal/team/service"
+	"github.com/xw1nchester/kushfinds-backend/internal/tracing"
 	uploadhandler "github.com/xw1nchester/kushfinds-backend/internal/upload/handler"
 	uploadservice "github.com/xw1nchester/kushfinds-backend/internal/upload/service"
+	"github.com/xw1nchester/kushfinds-backend/internal/user/age"
+	agemiddleware "github.com/xw1nchester/kushfinds-backend/internal/user/age/middleware"
 	userdb "github.com/xw1nchester/kushfinds-backend/internal/user/db"
 	userhandler "github.com/xw1nchester/kushfinds-backend/internal/user/handler"
 	userservice "github.com/xw1nchester/kushfinds-backend/internal/user/service"
@@ -56,10 +96,31 @@ import (
 )
 
 type App struct {
-	HTTPServer *http.Server
+	HTTPServer  *http.Server
+	AdminServer *http.Server
+
+	health          *health.Checker
+	drainDelay      time.Duration
+	shutdownTracing func(context.Context) error
+
+	// workers run in the background until shutdown
+	workers     []func(context.Context)
+	workersCtx  context.Context
+	stopWorkers context.CancelFunc
 }
 
 func New(log *zap.Logger, cfg config.Config) *App {
+	shutdownTracing, err := tracing.Setup(context.TODO(), tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		ServiceName: cfg.Tracing.ServiceName,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
 	// TODO: возможно стоит вынести в main.go, чтобы не создавать доп инстанс в тестах
 	pgClient, err := pgclient.New(
 		context.TODO(),
@@ -85,21 +146,45 @@ func New(log *zap.Logger, cfg config.Config) *App {
 		log.Fatal(err.Error())
 	}
 
+	ageProvider, err := age.NewProvider(cfg.Age.Provider, cfg.Env)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	metricsRegistry := metrics.NewRegistry(pgClient)
+
 	router := chi.NewRouter()
 
 	router.Use(
-		LoggingMiddleware(log),
+		middleware.RequestID,
+		otelchi.Middleware(cfg.Tracing.ServiceName, otelchi.WithChiRoutes(router)),
+		metricsRegistry.Middleware,
+		audit.Middleware,
+		i18n.Middleware,
+		logging.Middleware(log),
 		cors.Handler(cors.Options{
 			AllowedOrigins:   cfg.HTTPServer.AllowedOrigins,
 			AllowCredentials: cfg.HTTPServer.AllowCredentials,
 			AllowedMethods:   cfg.HTTPServer.AllowedMethods,
 			AllowedHeaders:   cfg.HTTPServer.AllowedHeaders,
+			ExposedHeaders:   cfg.HTTPServer.ExposedHeaders,
 		}),
 		middleware.Recoverer,
 	)
 
 	router.Get("/swagger/*", httpSwagger.Handler())
 
+	healthChecker := health.New(cfg.Health.Timeout)
+	healthChecker.Add("postgresql", health.PostgreSQL(pgClient))
+	healthChecker.Add("minio", health.Minio(minioClient))
+	healthChecker.Add("migrations", health.Migrations(pgClient, cfg.Health.MigrationsPath))
+	healthChecker.Add("smtp", health.SMTP(cfg.SMTP.Host, cfg.SMTP.Port))
+
+	healthHandler := healthhandler.New(healthChecker, log)
+	healthHandler.Register(router)
+
+	var workers []func(context.Context)
+
 	router.Route("/api", func(r chi.Router) {
 		r.Get("/ping", PingHandler)
 
@@ -124,12 +209,25 @@ func New(log *zap.Logger, cfg config.Config) *App {
 
 		countryService := countryservice.New(countryRepository, stateService, log)
 
+		mailManager := auth.NewMailManager(cfg.SMTP, metricsRegistry)
+
+		txManager := pgtx.New(pgClient)
+
+		auditRepository := auditdb.New(pgClient, log)
+
+		auditRecorder := auditservice.NewRecorder(auditRepository, log)
+
 		userService := userservice.New(
 			userRepository,
 			industryService,
 			countryService,
 			stateService,
 			regionService,
+			ageProvider,
+			cfg.Age.DefaultMinimumAge,
+			mailManager,
+			txManager,
+			auditRecorder,
 			log,
 		)
 
@@ -139,12 +237,8 @@ func New(log *zap.Logger, cfg config.Config) *App {
 
 		tokenManager := jwtauth.NewManager(cfg.JWT)
 
-		mailManager := auth.NewMailManager(cfg.SMTP)
-
 		passwordManager := password.New(log)
 
-		txManager := pgtx.New(pgClient)
-
 		authService := authservice.New(
 			authRepository,
 			userService,
@@ -153,11 +247,32 @@ func New(log *zap.Logger, cfg config.Config) *App {
 			mailManager,
 			passwordManager,
 			txManager,
+			auditRecorder,
+			metricsRegistry,
 			log,
 		)
 
 		authMiddleware := jwtmiddleware.NewMiddleware(log, tokenManager)
 
+		ageMiddleware := agemiddleware.NewMiddleware(log, userService)
+
+		idempotencyRepository := idempotencydb.New(pgClient, log)
+
+		idempotencyMiddleware := idempotencymiddleware.NewMiddleware(
+			log,
+			idempotencyRepository,
+			cfg.HTTPServer.Idempotency.TTL,
+			cfg.HTTPServer.Idempotency.Lease,
+		)
+
+		idempotencyPurger := idempotency.NewPurger(
+			idempotencyRepository,
+			cfg.HTTPServer.Idempotency.PurgeInterval,
+			log,
+		)
+
+		workers = append(workers, idempotencyPurger.Run)
+
 		marketSectionRepository := marketsectiondb.New(pgClient, log)
 
 		marketSectionService := marketsectionservice.New(marketSectionRepository, log)
@@ -166,29 +281,116 @@ func New(log *zap.Logger, cfg config.Config) *App {
 
 		socialService := socialservice.New(socialRepository, log)
 
+		teamRepository := teamdb.New(pgClient, log)
+
+		teamService := teamservice.New(
+			teamRepository,
+			userService,
+			mailManager,
+			txManager,
+			auditRecorder,
+			log,
+		)
+
 		brandRepository := branddb.New(pgClient, log)
 
 		brandService := brandservice.New(
 			brandRepository,
 			userService,
+			teamService,
 			countryService,
 			stateService,
 			marketSectionService,
 			socialService,
+			txManager,
+			auditRecorder,
 			log,
 		)
 
+		favoriteRepository := favoritedb.New(pgClient, log)
+
+		followerNotifier := favoriteservice.NewNotifier(favoriteRepository, mailManager, log)
+
 		storeRepository := storedb.New(pgClient, log)
 
 		storeService := storeservice.New(
 			storeRepository,
 			userService,
 			brandService,
+			teamService,
 			regionService,
 			socialService,
+			followerNotifier,
+			txManager,
+			auditRecorder,
+			log,
+		)
+
+		licenseReminder := storeservice.NewLicenseReminder(
+			storeRepository,
+			mailManager,
+			cfg.Licenses.ReminderInterval,
+			cfg.Licenses.ReminderLeadTime,
 			log,
 		)
 
+		workers = append(workers, licenseReminder.Run)
+
+		productRepository := productdb.New(pgClient, log)
+
+		productService := productservice.New(
+			productRepository,
+			brandService,
+			marketSectionService,
+			followerNotifier,
+			txManager,
+			auditRecorder,
+			log,
+		)
+
+		menuRepository := menudb.New(pgClient, log)
+
+		menuService := menuservice.New(menuRepository, storeService, txManager, log)
+
+		promotionRepository := promotiondb.New(pgClient, log)
+
+		promotionService := promotionservice.New(promotionRepository, storeService, txManager, log)
+
+		orderRepository := orderdb.New(pgClient, log)
+
+		orderService := orderservice.New(
+			orderRepository,
+			storeService,
+			menuService,
+			promotionService,
+			txManager,
+			log,
+		)
+
+		reviewRepository := reviewdb.New(pgClient, log)
+
+		reviewService := reviewservice.New(
+			reviewRepository,
+			storeService,
+			userService,
+			txManager,
+			auditRecorder,
+			log,
+		)
+
+		favoriteService := favoriteservice.New(
+			favoriteRepository,
+			storeService,
+			brandService,
+			log,
+		)
+
+		referenceRepository := referencedb.New(pgClient, log)
+
+		referenceService := referenceservice.New(referenceRepository, userService, log)
+
+		auditService := auditservice.New(auditRepository, userService, log)
+
 		authHandler := authhandler.New(authService, authMiddleware, log)
 
 		log.Info("register auth handlers")
@@ -219,7 +421,7 @@ func New(log *zap.Logger, cfg config.Config) *App {
 
 		industryHandler.Register(r)
 
-		uploadService := uploadservice.New(minioClient, log)
+		uploadService := uploadservice.New(minioClient, metricsRegistry, log)
 
 		uploadHandler := uploadhandler.New(uploadService, authMiddleware, log)
 
@@ -233,9 +435,21 @@ func New(log *zap.Logger, cfg config.Config) *App {
 
 		marketSectionHandler.Register(r)
 
+		teamHandler := teamhandler.New(
+			teamService,
+			authMiddleware,
+			cfg.HTTPServer.StaticURL,
+			log,
+		)
+
+		log.Info("register team handlers")
+
+		teamHandler.Register(r)
+
 		brandHandler := brandhandler.New(
 			brandService,
 			authMiddleware,
+			idempotencyMiddleware,
 			cfg.HTTPServer.StaticURL,
 			log,
 		)
@@ -247,6 +461,7 @@ func New(log *zap.Logger, cfg config.Config) *App {
 		storeHandler := storehandler.New(
 			storeService,
 			authMiddleware,
+			idempotencyMiddleware,
 			cfg.HTTPServer.StaticURL,
 			log,
 		)
@@ -255,6 +470,96 @@ func New(log *zap.Logger, cfg config.Config) *App {
 
 		storeHandler.Register(r)
 
+		productHandler := producthandler.New(
+			productService,
+			authMiddleware,
+			ageMiddleware,
+			cfg.HTTPServer.StaticURL,
+			log,
+		)
+
+		log.Info("register product handlers")
+
+		productHandler.Register(r)
+
+		menuHandler := menuhandler.New(
+			menuService,
+			authMiddleware,
+			ageMiddleware,
+			cfg.HTTPServer.StaticURL,
+			log,
+		)
+
+		log.Info("register menu handlers")
+
+		menuHandler.Register(r)
+
+		promotionHandler := promotionhandler.New(
+			promotionService,
+			authMiddleware,
+			ageMiddleware,
+			cfg.HTTPServer.StaticURL,
+			log,
+		)
+
+		log.Info("register promotion handlers")
+
+		promotionHandler.Register(r)
+
+		orderHandler := orderhandler.New(
+			orderService,
+			authMiddleware,
+			ageMiddleware,
+			cfg.HTTPServer.StaticURL,
+			log,
+		)
+
+		log.Info("register order handlers")
+
+		orderHandler.Register(r)
+
+		reviewHandler := reviewhandler.New(
+			reviewService,
+			authMiddleware,
+			cfg.HTTPServer.StaticURL,
+			log,
+		)
+
+		log.Info("register review handlers")
+
+		reviewHandler.Register(r)
+
+		favoriteHandler := favoritehandler.New(
+			favoriteService,
+			authMiddleware,
+			cfg.HTTPServer.StaticURL,
+			log,
+		)
+
+		log.Info("register favorite handlers")
+
+		favoriteHandler.Register(r)
+
+		referenceHandler := referencehandler.New(
+			referenceService,
+			authMiddleware,
+			log,
+		)
+
+		log.Info("register reference handlers")
+
+		referenceHandler.Register(r)
+
+		auditHandler := audithandler.New(
+			auditService,
+			authMiddleware,
+			log,
+		)
+
+		log.Info("register audit handlers")
+
+		auditHandler.Register(r)
+
 		socialHandler := socialhandler.New(
 			socialService,
 			log,
@@ -273,36 +578,75 @@ func New(log *zap.Logger, cfg config.Config) *App {
 		IdleTimeout:  cfg.HTTPServer.IdleTimeout,
 	}
 
-	return &App{
-		HTTPServer: srv,
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+
+	app := &App{
+		HTTPServer:      srv,
+		health:          healthChecker,
+		drainDelay:      cfg.Health.DrainDelay,
+		shutdownTracing: shutdownTracing,
+		workers:         workers,
+		workersCtx:      workersCtx,
+		stopWorkers:     stopWorkers,
 	}
+
+	if cfg.HTTPServer.AdminAddress != "" {
+		adminRouter := chi.NewRouter()
+		adminRouter.Use(middleware.Recoverer)
+		adminRouter.Handle("/metrics", metricsRegistry.Handler())
+
+		app.AdminServer = &http.Server{
+			Addr:         cfg.HTTPServer.AdminAddress,
+			Handler:      adminRouter,
+			ReadTimeout:  cfg.HTTPServer.Timeout,
+			WriteTimeout: cfg.HTTPServer.Timeout,
+			IdleTimeout:  cfg.HTTPServer.IdleTimeout,
+		}
+	}
+
+	return app
 }
 
 func (a *App) MustRun() {
+	for _, run := range a.workers {
+		go run(a.workersCtx)
+	}
+
+	if a.AdminServer != nil {
+		go func() {
+			if err := a.AdminServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				panic("failed to start admin server")
+			}
+		}()
+	}
+
 	if err := a.HTTPServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		panic("failed to start server")
 	}
 }
 
+// Shutdown fails readiness first and waits for the drain delay,
+// so load balancers stop sending traffic before the listener closes.
 func (a *App) Shutdown(ctx context.Context) error {
-	return a.HTTPServer.Shutdown(ctx)
-}
+	a.health.SetShuttingDown()
+	a.stopWorkers()
 
-func LoggingMiddleware(logger *zap.Logger) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			start := time.Now()
+	select {
+	case <-time.After(a.drainDelay):
+	case <-ctx.Done():
+	}
+
+	err := a.HTTPServer.Shutdown(ctx)
 
-			next.ServeHTTP(w, r)
+	if a.AdminServer != nil {
+		err = errors.Join(err, a.AdminServer.Shutdown(ctx))
+	}
 
-			logger.Info("request",
-				zap.String("method", r.Method),
-				zap.String("path", r.URL.Path),
-				zap.String("remote", r.RemoteAddr),
-				zap.Duration("duration", time.Since(start)),
-			)
-		})
+	if a.shutdownTracing != nil {
+		err = errors.Join(err, a.shutdownTracing(ctx))
 	}
+
+	return err
 }
 
 // @Tags		other
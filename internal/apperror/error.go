@@ -3,32 +3,98 @@ package apperror
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/xw1nchester/kushfinds-backend/internal/i18n"
 )
 
 var (
-	ErrNotFound     = NewAppError("not found")
-	ErrUnauthorized = NewAppError("unauthorized")
-	ErrForbidden    = NewAppError("forbidden")
-	ErrDecodeBody   = NewAppError("failed to decode request body")
+	ErrNotFound           = New(http.StatusNotFound, "not_found", "not found")
+	ErrUnauthorized       = New(http.StatusUnauthorized, "unauthorized", "unauthorized")
+	ErrForbidden          = New(http.StatusForbidden, "forbidden", "forbidden")
+	ErrPreconditionFailed = New(http.StatusPreconditionFailed, "precondition_failed", "the resource has been modified, fetch it again and retry")
+	ErrDecodeBody         = NewAppError("request.invalid_body", "failed to decode request body")
 )
 
+const (
+	codeInvalidField     = "request.invalid_field"
+	codeValidationFailed = "request.validation_failed"
+	codeInternal         = "internal"
+)
+
+// AppError is returned to clients as is. Code is stable and meant for
+// programmatic handling, Message is human-readable and may change.
+// Fields holds per-field problems keyed by JSON field name.
+// Message and Fields are translated by the i18n catalog entry of Code
+// when the error is written, the English text given here is the fallback.
 type AppError struct {
-	Message string `json:"message"`
+	Code      string            `json:"code"`
+	Message   string            `json:"message"`
+	Fields    map[string]string `json:"fields,omitempty"`
+	RequestID string            `json:"requestId,omitempty"`
+	Status    int               `json:"-"`
+
+	args []any
+
+	field     string
+	rule      string
+	ruleArgs  []any
+	violation validator.ValidationErrors
 }
 
-func NewAppError(message string) *AppError {
+func New(status int, code, message string) *AppError {
 	return &AppError{
+		Code:    code,
 		Message: message,
+		Status:  status,
 	}
 }
 
+// NewAppError creates a bad request error.
+func NewAppError(code, message string) *AppError {
+	return New(http.StatusBadRequest, code, message)
+}
+
+// NewAppErrorf creates a bad request error whose catalog entry takes the
+// same args as format.
+func NewAppErrorf(code, format string, args ...any) *AppError {
+	err := NewAppError(code, fmt.Sprintf(format, args...))
+	err.args = args
+
+	return err
+}
+
+// NewConflictError is for requests clashing with existing state, e.g. a taken name.
+func NewConflictError(code, message string) *AppError {
+	return New(http.StatusConflict, code, message)
+}
+
+// NewFieldError reports a single invalid body field, query or path parameter.
+// rule is a catalog key such as "field.positive_integer", args fill it in.
+func NewFieldError(field, rule string, args ...any) *AppError {
+	err := NewAppError(codeInvalidField, "")
+	err.field = field
+	err.rule = rule
+	err.ruleArgs = args
+	err.localize(i18n.DefaultLocale)
+
+	return err
+}
+
 func (e *AppError) Error() string {
 	return e.Message
 }
 
+// WithStatus returns a copy of the error answered with another status.
+func (e *AppError) WithStatus(status int) *AppError {
+	copied := *e
+	copied.Status = status
+
+	return &copied
+}
+
 func (e *AppError) Marshal() []byte {
 	marshal, err := json.Marshal(e)
 	if err != nil {
@@ -37,25 +103,57 @@ func (e *AppError) Marshal() []byte {
 	return marshal
 }
 
+// NewValidationErr expects errors of a validator created with NewValidator,
+// so that field names are the JSON ones and messages can be translated.
 func NewValidationErr(errs validator.ValidationErrors) *AppError {
-	var errMsgs []string
-
-	for _, err := range errs {
-		switch err.ActualTag() {
-		case "required":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is a required field", err.Field()))
-		case "email":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not a valid email", err.Field()))
-		case "min":
-			errMsgs = append(errMsgs, fmt.Sprintf("the minimum length of the %s field is %s characters", err.Field(), err.Param()))
-		default:
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not valid", err.Field()))
+	err := NewAppError(codeValidationFailed, "")
+	err.violation = errs
+	err.localize(i18n.DefaultLocale)
+
+	return err
+}
+
+// localize rewrites Message and Fields in the locale. It must only be
+// called on copies of shared errors.
+func (e *AppError) localize(locale string) {
+	switch {
+	case e.violation != nil:
+		trans := i18n.ValidationTranslator(locale)
+
+		messages := make([]string, 0, len(e.violation))
+		e.Fields = make(map[string]string, len(e.violation))
+
+		for _, fieldErr := range e.violation {
+			message := fieldErr.Translate(trans)
+
+			e.Fields[fieldName(fieldErr)] = message
+			messages = append(messages, message)
+		}
+
+		e.Message = strings.Join(messages, ", ")
+	case e.field != "":
+		reason := i18n.T(locale, e.rule, e.ruleArgs...)
+
+		e.Fields = map[string]string{e.field: reason}
+		e.Message = i18n.T(locale, codeInvalidField, e.field, reason)
+	default:
+		if message, ok := i18n.Lookup(locale, e.Code, e.args...); ok {
+			e.Message = message
 		}
 	}
+}
+
+// fieldName drops the root struct from the namespace, e.g.
+// "createRequest.states[0].id" becomes "states[0].id".
+func fieldName(err validator.FieldError) string {
+	_, name, found := strings.Cut(err.Namespace(), ".")
+	if !found {
+		return err.Field()
+	}
 
-	return NewAppError(strings.Join(errMsgs, ", "))
+	return name
 }
 
 func internalError() *AppError {
-	return NewAppError("internal error")
+	return New(http.StatusInternalServerError, codeInternal, "internal error")
 }
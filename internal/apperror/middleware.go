@@ -3,6 +3,9 @@ package apperror
 import (
 	"errors"
 	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/xw1nchester/kushfinds-backend/internal/i18n"
 )
 
 type handler func(w http.ResponseWriter, r *http.Request) error
@@ -12,27 +15,33 @@ func Middleware(h handler) http.HandlerFunc {
 		w.Header().Set("Content-Type", "application/json")
 
 		err := h(w, r)
+		if err == nil {
+			return
+		}
 
 		var appErr *AppError
-		if err != nil {
-			if errors.As(err, &appErr) {
-				if errors.Is(err, ErrNotFound) {
-					w.WriteHeader(http.StatusNotFound)
-				} else if errors.Is(err, ErrUnauthorized) {
-					w.WriteHeader(http.StatusUnauthorized)
-				} else if errors.Is(err, ErrForbidden) {
-					w.WriteHeader(http.StatusForbidden)
-				} else {
-					w.WriteHeader(http.StatusBadRequest)
-				}
-
-				w.Write(appErr.Marshal())
-
-				return
-			}
-
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write(internalError().Marshal())
+		if !errors.As(err, &appErr) {
+			appErr = internalError()
 		}
+
+		Write(w, r, appErr)
 	}
 }
+
+// Write sends the error with its status in the request locale, tagged with
+// the chi request ID. The error itself is not modified, so sentinel errors
+// are safe to pass.
+func Write(w http.ResponseWriter, r *http.Request, appErr *AppError) {
+	resp := *appErr
+	resp.RequestID = middleware.GetReqID(r.Context())
+	resp.localize(i18n.FromContext(r.Context()))
+
+	status := resp.Status
+	if status == 0 {
+		status = http.StatusBadRequest
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(resp.Marshal())
+}
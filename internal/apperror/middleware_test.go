@@ -0,0 +1,164 @@
+package apperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xw1nchester/kushfinds-backend/internal/i18n"
+)
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "Not found",
+			err:            ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "not_found",
+		},
+		{
+			name:           "Wrapped conflict",
+			err:            fmt.Errorf("create brand: %w", NewConflictError("brand.name_already_exists", "taken")),
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "brand.name_already_exists",
+		},
+		{
+			name:           "Bad request",
+			err:            NewFieldError("id", "should be positive integer"),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "request.invalid_field",
+		},
+		{
+			name:           "Unexpected error",
+			err:            errors.New("connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "internal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := middleware.RequestID(Middleware(func(w http.ResponseWriter, r *http.Request) error {
+				return tt.err
+			}))
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+			var body AppError
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.expectedCode, body.Code)
+			assert.NotEmpty(t, body.RequestID)
+		})
+	}
+
+	t.Run("Sentinel is not modified", func(t *testing.T) {
+		h := middleware.RequestID(Middleware(func(w http.ResponseWriter, r *http.Request) error {
+			return ErrNotFound
+		}))
+
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Empty(t, ErrNotFound.RequestID)
+	})
+}
+
+func TestNewValidationErr(t *testing.T) {
+	type state struct {
+		ID int `json:"id" validate:"required"`
+	}
+	type request struct {
+		Email    string  `json:"email" validate:"required,email"`
+		Password string  `json:"password" validate:"min=8"`
+		States   []state `json:"states" validate:"dive"`
+	}
+
+	err := NewValidator().Struct(request{Email: "nope", Password: "short", States: []state{{}}})
+	require.Error(t, err)
+
+	appErr := NewValidationErr(err.(validator.ValidationErrors))
+
+	assert.Equal(t, "request.validation_failed", appErr.Code)
+	assert.Equal(t, http.StatusBadRequest, appErr.Status)
+	assert.Equal(t, map[string]string{
+		"email":        "email must be a valid email address",
+		"password":     "password must be at least 8 characters in length",
+		"states[0].id": "id is a required field",
+	}, appErr.Fields)
+
+	t.Run("Translated on write", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req = req.WithContext(i18n.WithLocale(req.Context(), i18n.LocaleRu))
+
+		Write(rec, req, appErr)
+
+		var body AppError
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "id обязательное поле", body.Fields["states[0].id"])
+		assert.Equal(t, "id is a required field", appErr.Fields["states[0].id"])
+	})
+}
+
+func TestWriteLocalized(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             *AppError
+		expectedMessage string
+		expectedFields  map[string]string
+	}{
+		{
+			name:            "Catalog entry",
+			err:             ErrNotFound,
+			expectedMessage: "не найдено",
+		},
+		{
+			name:            "Catalog entry with args",
+			err:             NewAppErrorf("order.minimal_price", "minimal order price is %d", 500),
+			expectedMessage: "минимальная сумма заказа 500",
+		},
+		{
+			name:            "Field error",
+			err:             NewFieldError("id", "field.positive_integer"),
+			expectedMessage: "id: должно быть положительным целым числом",
+			expectedFields:  map[string]string{"id": "должно быть положительным целым числом"},
+		},
+		{
+			name:            "Unknown code keeps message",
+			err:             NewAppError("test.unknown", "something went wrong"),
+			expectedMessage: "something went wrong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req = req.WithContext(i18n.WithLocale(req.Context(), i18n.LocaleRu))
+
+			Write(rec, req, tt.err)
+
+			var body AppError
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.expectedMessage, body.Message)
+			assert.Equal(t, tt.expectedFields, body.Fields)
+		})
+	}
+
+	assert.Equal(t, "not found", ErrNotFound.Message)
+}
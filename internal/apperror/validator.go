@@ -0,0 +1,34 @@
+package apperror
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/xw1nchester/kushfinds-backend/internal/i18n"
+)
+
+// NewValidator reports fields by their JSON names, which is what
+// NewValidationErr puts into AppError.Fields, and carries translations
+// of validation messages for every supported locale.
+func NewValidator() *validator.Validate {
+	validate := validator.New()
+
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+
+		return name
+	})
+
+	if err := i18n.RegisterValidator(validate); err != nil {
+		panic(err)
+	}
+
+	return validate
+}
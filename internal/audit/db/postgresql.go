@@ -0,0 +1,128 @@
+package auditdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/xw1nchester/kushfinds-backend/internal/audit"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor/postgresql"
+	"go.uber.org/zap"
+)
+
+type repository struct {
+	client *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func New(client *pgxpool.Pool, logger *zap.Logger) *repository {
+	return &repository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// CreateEntry joins the transaction carried by ctx, so the entry is only
+// persisted together with the change it describes.
+func (r *repository) CreateEntry(ctx context.Context, data audit.Entry) error {
+	query := `
+		INSERT INTO audit_log (actor_id, action, entity_type, entity_id, before, after, ip, request_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	var before, after *string
+	if data.Before != nil {
+		value := string(data.Before)
+		before = &value
+	}
+	if data.After != nil {
+		value := string(data.After)
+		after = &value
+	}
+
+	_, err := postgresql.GetExecutor(ctx, r.client).Exec(
+		ctx,
+		query,
+		data.ActorID,
+		data.Action,
+		data.EntityType,
+		data.EntityID,
+		before,
+		after,
+		data.IP,
+		data.RequestID,
+	)
+
+	return err
+}
+
+func (r *repository) GetEntries(ctx context.Context, filter audit.Filter) ([]audit.Entry, error) {
+	query := `
+		SELECT id, actor_id, action, entity_type, entity_id, before, after, ip, request_id, created_at
+		FROM audit_log
+		WHERE ($1::int IS NULL OR actor_id=$1)
+			AND ($2='' OR action=$2)
+			AND ($3='' OR entity_type=$3)
+			AND ($4::int IS NULL OR entity_id=$4)
+			AND ($5::timestamp IS NULL OR created_at>=$5)
+			AND ($6::timestamp IS NULL OR created_at<$6)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $7 OFFSET $8
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(
+		ctx,
+		query,
+		filter.ActorID,
+		filter.Action,
+		filter.EntityType,
+		filter.EntityID,
+		filter.From,
+		filter.To,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]audit.Entry, 0)
+	for rows.Next() {
+		var (
+			entry         audit.Entry
+			before, after []byte
+		)
+
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.ActorID,
+			&entry.Action,
+			&entry.EntityType,
+			&entry.EntityID,
+			&before,
+			&after,
+			&entry.IP,
+			&entry.RequestID,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+
+		entry.Before = before
+		entry.After = after
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,85 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Diff reduces two entity snapshots to the top-level JSON fields that differ.
+// A missing snapshot stays null, so creations and deletions keep the whole
+// other side.
+func Diff(before, after any) (json.RawMessage, json.RawMessage, error) {
+	beforeFields, err := toFields(before)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	afterFields, err := toFields(after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if beforeFields != nil && afterFields != nil {
+		changedBefore := make(map[string]any)
+		changedAfter := make(map[string]any)
+
+		for key, value := range beforeFields {
+			afterValue, ok := afterFields[key]
+			if ok && reflect.DeepEqual(value, afterValue) {
+				continue
+			}
+
+			changedBefore[key] = value
+			if ok {
+				changedAfter[key] = afterValue
+			}
+		}
+
+		for key, value := range afterFields {
+			if _, ok := beforeFields[key]; !ok {
+				changedAfter[key] = value
+			}
+		}
+
+		beforeFields, afterFields = changedBefore, changedAfter
+	}
+
+	beforeJSON, err := marshalFields(beforeFields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	afterJSON, err := marshalFields(afterFields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return beforeJSON, afterJSON, nil
+}
+
+func toFields(snapshot any) (map[string]any, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal snapshot: %v", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("snapshot should be a JSON object: %v", err)
+	}
+
+	return fields, nil
+}
+
+func marshalFields(fields map[string]any) (json.RawMessage, error) {
+	if fields == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(fields)
+}
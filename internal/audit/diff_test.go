@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type snapshot struct {
+	Name        string   `json:"name"`
+	IsPublished bool     `json:"isPublished"`
+	States      []string `json:"states"`
+	Internal    string   `json:"-"`
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name           string
+		before         any
+		after          any
+		expectedBefore string
+		expectedAfter  string
+	}{
+		{
+			name:           "creation keeps full after",
+			before:         nil,
+			after:          snapshot{Name: "Green", IsPublished: true, States: []string{"CA"}},
+			expectedBefore: "",
+			expectedAfter:  `{"isPublished":true,"name":"Green","states":["CA"]}`,
+		},
+		{
+			name:           "deletion keeps full before",
+			before:         &snapshot{Name: "Green"},
+			after:          (*snapshot)(nil),
+			expectedBefore: `{"isPublished":false,"name":"Green","states":null}`,
+			expectedAfter:  "",
+		},
+		{
+			name:           "update keeps changed fields only",
+			before:         snapshot{Name: "Green", States: []string{"CA"}, Internal: "a"},
+			after:          snapshot{Name: "Green", IsPublished: true, States: []string{"CA", "NV"}, Internal: "b"},
+			expectedBefore: `{"isPublished":false,"states":["CA"]}`,
+			expectedAfter:  `{"isPublished":true,"states":["CA","NV"]}`,
+		},
+		{
+			name:           "no changes",
+			before:         snapshot{Name: "Green"},
+			after:          snapshot{Name: "Green"},
+			expectedBefore: `{}`,
+			expectedAfter:  `{}`,
+		},
+		{
+			name:           "fields missing on one side",
+			before:         map[string]any{"status": "pending", "reason": "x"},
+			after:          map[string]any{"status": "approved", "moderatorId": 1},
+			expectedBefore: `{"reason":"x","status":"pending"}`,
+			expectedAfter:  `{"moderatorId":1,"status":"approved"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, after, err := Diff(tt.before, tt.after)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedBefore, string(before))
+			require.Equal(t, tt.expectedAfter, string(after))
+		})
+	}
+}
+
+func TestDiffRejectsNonObjects(t *testing.T) {
+	_, _, err := Diff("draft", "pending")
+	require.Error(t, err)
+}
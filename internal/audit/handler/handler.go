@@ -0,0 +1,150 @@
+package audithandler
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/audit"
+	jwtmiddleware "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt/middleware"
+	"github.com/xw1nchester/kushfinds-backend/internal/handlers"
+	"go.uber.org/zap"
+)
+
+type Service interface {
+	GetEntries(ctx context.Context, adminID int, filter audit.Filter) ([]audit.Entry, error)
+}
+
+type handler struct {
+	service        Service
+	authMiddleware func(http.Handler) http.Handler
+	logger         *zap.Logger
+}
+
+func New(
+	service Service,
+	authMiddleware func(http.Handler) http.Handler,
+	logger *zap.Logger,
+) handlers.Handler {
+	return &handler{
+		service:        service,
+		authMiddleware: authMiddleware,
+		logger:         logger,
+	}
+}
+
+func (h *handler) Register(router chi.Router) {
+	router.Route("/admin/audit", func(auditRouter chi.Router) {
+		auditRouter.Use(h.authMiddleware)
+		auditRouter.Get("/", apperror.Middleware(h.getEntriesHandler))
+	})
+}
+
+func parseOptionalInt(query url.Values, name string, min int) (*int, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number < min {
+		return nil, apperror.NewFieldError(name, "field.min_integer", min)
+	}
+
+	return &number, nil
+}
+
+func parseOptionalTime(query url.Values, name string) (*time.Time, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, apperror.NewFieldError(name, "field.rfc3339")
+	}
+
+	parsed = parsed.UTC()
+
+	return &parsed, nil
+}
+
+func parseFilter(query url.Values) (audit.Filter, error) {
+	filter := audit.Filter{
+		Action:     query.Get("action"),
+		EntityType: query.Get("entityType"),
+	}
+
+	var err error
+
+	if filter.ActorID, err = parseOptionalInt(query, "actorId", 1); err != nil {
+		return filter, err
+	}
+
+	if filter.EntityID, err = parseOptionalInt(query, "entityId", 1); err != nil {
+		return filter, err
+	}
+
+	if filter.From, err = parseOptionalTime(query, "from"); err != nil {
+		return filter, err
+	}
+
+	if filter.To, err = parseOptionalTime(query, "to"); err != nil {
+		return filter, err
+	}
+
+	limit, err := parseOptionalInt(query, "limit", 1)
+	if err != nil {
+		return filter, err
+	}
+	if limit != nil {
+		filter.Limit = *limit
+	}
+
+	offset, err := parseOptionalInt(query, "offset", 0)
+	if err != nil {
+		return filter, err
+	}
+	if offset != nil {
+		filter.Offset = *offset
+	}
+
+	return filter, nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		admin audit
+// @Param		actorId		query		int		false	"user who made the change"
+// @Param		action		query		string	false	"action, e.g. brand.publish"
+// @Param		entityType	query		string	false	"entity type, e.g. brand"
+// @Param		entityId	query		int		false	"entity id"
+// @Param		from		query		string	false	"RFC 3339 timestamp, inclusive"
+// @Param		to			query		string	false	"RFC 3339 timestamp, exclusive"
+// @Param		limit		query		int		false	"page size, 50 by default, at most 200"
+// @Param		offset		query		int		false	"number of entries to skip"
+// @Success	200			{object}	EntriesResponse
+// @Failure	400,500		{object}	apperror.AppError
+// @Router		/admin/audit [get]
+func (h *handler) getEntriesHandler(w http.ResponseWriter, r *http.Request) error {
+	filter, err := parseFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	adminID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	entries, err := h.service.GetEntries(r.Context(), adminID, filter)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, EntriesResponse{Entries: entries})
+
+	return nil
+}
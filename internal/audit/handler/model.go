@@ -0,0 +1,7 @@
+package audithandler
+
+import "github.com/xw1nchester/kushfinds-backend/internal/audit"
+
+type EntriesResponse struct {
+	Entries []audit.Entry `json:"entries"`
+}
@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	EntityBusinessProfile = "business_profile"
+	EntityBrand           = "brand"
+	EntityStore           = "store"
+	EntityProduct         = "product"
+	EntityReview          = "review"
+	EntityUser            = "user"
+	EntityTeam            = "team"
+)
+
+const (
+	ActionBusinessProfileUpdate  = "business_profile.update"
+	ActionBusinessProfileApprove = "business_profile.approve"
+	ActionBusinessProfileReject  = "business_profile.reject"
+	ActionBrandPublish           = "brand.publish"
+	ActionBrandUnpublish         = "brand.unpublish"
+	ActionBrandApprove           = "brand.approve"
+	ActionBrandReject            = "brand.reject"
+	ActionBrandDelete            = "brand.delete"
+	ActionStorePublish           = "store.publish"
+	ActionProductDelete          = "product.delete"
+	ActionReviewDelete           = "review.delete"
+	ActionReviewHide             = "review.hide"
+	ActionSessionRevoke          = "session.revoke"
+	ActionTeamMemberJoin         = "team.member_join"
+	ActionTeamMemberUpdate       = "team.member_update"
+	ActionTeamMemberRemove       = "team.member_remove"
+)
+
+// Record describes a change to be written to the audit log.
+// Before and After are entity snapshots, nil when the entity did not exist.
+type Record struct {
+	ActorID    int
+	Action     string
+	EntityType string
+	EntityID   int
+	Before     any
+	After      any
+}
+
+type Entry struct {
+	ID         int64           `json:"id"`
+	ActorID    *int            `json:"actorId"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entityType"`
+	EntityID   int             `json:"entityId"`
+	Before     json.RawMessage `json:"before" swaggertype:"object"`
+	After      json.RawMessage `json:"after" swaggertype:"object"`
+	IP         *string         `json:"ip"`
+	RequestID  *string         `json:"requestId"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+type Filter struct {
+	ActorID    *int
+	Action     string
+	EntityType string
+	EntityID   *int
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
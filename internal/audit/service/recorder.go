@@ -0,0 +1,65 @@
+package auditservice
+
+import (
+	"context"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/audit"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"go.uber.org/zap"
+)
+
+type EntryWriter interface {
+	CreateEntry(ctx context.Context, data audit.Entry) error
+}
+
+type recorder struct {
+	repository EntryWriter
+	logger     *zap.Logger
+}
+
+func NewRecorder(repository EntryWriter, logger *zap.Logger) *recorder {
+	return &recorder{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// Record writes the change to the audit log. Callers run it inside the
+// transaction of the change itself, so a failed write rolls the change back.
+func (r *recorder) Record(ctx context.Context, record audit.Record) error {
+	before, after, err := audit.Diff(record.Before, record.After)
+	if err != nil {
+		logging.FromContext(ctx, r.logger).Error("unexpected error when building audit diff", zap.Error(err))
+		return err
+	}
+
+	var actorID *int
+	if record.ActorID != 0 {
+		actorID = &record.ActorID
+	}
+
+	metadata := audit.MetadataFromContext(ctx)
+
+	if err := r.repository.CreateEntry(ctx, audit.Entry{
+		ActorID:    actorID,
+		Action:     record.Action,
+		EntityType: record.EntityType,
+		EntityID:   record.EntityID,
+		Before:     before,
+		After:      after,
+		IP:         optionalString(metadata.IP),
+		RequestID:  optionalString(metadata.RequestID),
+	}); err != nil {
+		logging.FromContext(ctx, r.logger).Error("unexpected error when writing audit entry", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package auditservice
+
+import (
+	"context"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/audit"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/user"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+)
+
+type Repository interface {
+	GetEntries(ctx context.Context, filter audit.Filter) ([]audit.Entry, error)
+}
+
+type UserService interface {
+	GetByID(ctx context.Context, id int) (*user.User, error)
+}
+
+type service struct {
+	repository  Repository
+	userService UserService
+	logger      *zap.Logger
+}
+
+func New(repository Repository, userService UserService, logger *zap.Logger) *service {
+	return &service{
+		repository:  repository,
+		userService: userService,
+		logger:      logger,
+	}
+}
+
+func (s *service) checkAdmin(ctx context.Context, userID int) error {
+	existingUser, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !existingUser.IsAdmin {
+		return apperror.ErrForbidden
+	}
+
+	return nil
+}
+
+func (s *service) GetEntries(ctx context.Context, adminID int, filter audit.Filter) ([]audit.Entry, error) {
+	if err := s.checkAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultLimit
+	}
+	if filter.Limit > maxLimit {
+		filter.Limit = maxLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	entries, err := s.repository.GetEntries(ctx, filter)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching audit entries", zap.Error(err))
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/xw1nchester/kushfinds-backend/internal/auth"
 	jwtmiddleware "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt/middleware"
 	"github.com/xw1nchester/kushfinds-backend/internal/handlers"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
 	"github.com/xw1nchester/kushfinds-backend/internal/user"
 	"go.uber.org/zap"
 )
@@ -19,7 +20,7 @@ const (
 	RefreshTokenCookieName = "refresh-token"
 )
 
-var validate = validator.New()
+var validate = apperror.NewValidator()
 
 //go:generate mockgen -source=handler.go -destination=mocks/mock.go -package=mockauthservice
 type Service interface {
@@ -106,7 +107,7 @@ func (h *handler) clearCookie(w http.ResponseWriter) {
 func (h *handler) RegisterEmailHandler(w http.ResponseWriter, r *http.Request) error {
 	var dto auth.EmailRequest
 	if err := render.DecodeJSON(r.Body, &dto); err != nil {
-		h.logger.Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
 		return apperror.ErrDecodeBody
 	}
 
@@ -125,7 +126,7 @@ func (h *handler) RegisterEmailHandler(w http.ResponseWriter, r *http.Request) e
 func (h *handler) registerVerifyHandler(w http.ResponseWriter, r *http.Request) error {
 	var dto auth.CodeRequest
 	if err := render.DecodeJSON(r.Body, &dto); err != nil {
-		h.logger.Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
 		return apperror.ErrDecodeBody
 	}
 
@@ -153,7 +154,7 @@ func (h *handler) registerVerifyHandler(w http.ResponseWriter, r *http.Request)
 func (h *handler) VerifyResendHandler(w http.ResponseWriter, r *http.Request) error {
 	var dto auth.EmailRequest
 	if err := render.DecodeJSON(r.Body, &dto); err != nil {
-		h.logger.Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
 		return apperror.ErrDecodeBody
 	}
 
@@ -173,7 +174,7 @@ func (h *handler) VerifyResendHandler(w http.ResponseWriter, r *http.Request) er
 func (h *handler) registerProfileHandler(w http.ResponseWriter, r *http.Request) error {
 	var dto auth.ProfileRequest
 	if err := render.DecodeJSON(r.Body, &dto); err != nil {
-		h.logger.Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
 		return apperror.ErrDecodeBody
 	}
 
@@ -202,7 +203,7 @@ func (h *handler) registerProfileHandler(w http.ResponseWriter, r *http.Request)
 func (h *handler) registerPasswordHandler(w http.ResponseWriter, r *http.Request) error {
 	var dto auth.PasswordRequest
 	if err := render.DecodeJSON(r.Body, &dto); err != nil {
-		h.logger.Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
 		return apperror.ErrDecodeBody
 	}
 
@@ -223,7 +224,7 @@ func (h *handler) registerPasswordHandler(w http.ResponseWriter, r *http.Request
 func (h *handler) loginEmailHandler(w http.ResponseWriter, r *http.Request) error {
 	var dto auth.EmailRequest
 	if err := render.DecodeJSON(r.Body, &dto); err != nil {
-		h.logger.Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
 		return apperror.ErrDecodeBody
 	}
 
@@ -249,7 +250,7 @@ func (h *handler) loginEmailHandler(w http.ResponseWriter, r *http.Request) erro
 func (h *handler) loginPasswordHandler(w http.ResponseWriter, r *http.Request) error {
 	var dto auth.EmailPasswordRequest
 	if err := render.DecodeJSON(r.Body, &dto); err != nil {
-		h.logger.Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
 		return apperror.ErrDecodeBody
 	}
 
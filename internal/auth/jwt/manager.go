@@ -20,6 +20,8 @@ func NewManager(jwtConfig config.JWT) *manager {
 type UserClaims struct {
 	UserID  int  `json:"user_id"`
 	IsAdmin bool `json:"is_admin"`
+	// Locale is the stored user preference, empty when not set.
+	Locale string `json:"locale,omitempty"`
 }
 
 type customClaims struct {
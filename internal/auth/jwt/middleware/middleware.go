@@ -5,7 +5,10 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
 	jwtauth "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt"
+	"github.com/xw1nchester/kushfinds-backend/internal/i18n"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
 	"go.uber.org/zap"
 )
 
@@ -21,24 +24,28 @@ func NewMiddleware(logger *zap.Logger, tokenManager JwtManager) func(http.Handle
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
-				w.WriteHeader(http.StatusUnauthorized)
+				apperror.Write(w, r, apperror.ErrUnauthorized)
 				return
 			}
 
 			headerParts := strings.Split(authHeader, " ")
 			if len(headerParts) != 2 || headerParts[0] != "Bearer" || headerParts[1] == "" {
-				w.WriteHeader(http.StatusUnauthorized)
+				apperror.Write(w, r, apperror.ErrUnauthorized)
 				return
 			}
 
 			userClaims, err := tokenManager.ParseToken(headerParts[1])
 			if err != nil {
-				logger.Warn("error when parsing JWT token", zap.Error(err))
-				w.WriteHeader(http.StatusUnauthorized)
+				logging.FromContext(r.Context(), logger).Warn("error when parsing JWT token", zap.Error(err))
+				apperror.Write(w, r, apperror.ErrUnauthorized)
 				return
 			}
 
 			ctx := context.WithValue(r.Context(), UserIDContextKey{}, userClaims.UserID)
+			ctx = logging.WithUserID(ctx, userClaims.UserID)
+			if i18n.IsSupported(userClaims.Locale) {
+				ctx = i18n.WithLocale(ctx, userClaims.Locale)
+			}
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -7,13 +7,19 @@ import (
 	"github.com/xw1nchester/kushfinds-backend/internal/config"
 )
 
+type MailMetrics interface {
+	ObserveMail(err error)
+}
+
 type mailManager struct {
 	smtpConfig config.SMTP
+	metrics    MailMetrics
 }
 
-func NewMailManager(smtpConfig config.SMTP) *mailManager {
+func NewMailManager(smtpConfig config.SMTP, metrics MailMetrics) *mailManager {
 	return &mailManager{
 		smtpConfig: smtpConfig,
+		metrics:    metrics,
 	}
 }
 
@@ -27,11 +33,15 @@ func (m mailManager) SendMail(subject string, body string, to []string) error {
 
 	msg := "Subject: " + subject + "\n" + body
 
-	return smtp.SendMail(
+	err := smtp.SendMail(
 		fmt.Sprintf("%s:%s", m.smtpConfig.Host, m.smtpConfig.Port),
 		auth,
 		m.smtpConfig.Username,
 		to,
 		[]byte(msg),
 	)
+
+	m.metrics.ObserveMail(err)
+
+	return err
 }
@@ -3,31 +3,34 @@ package service
 import (
 	"context"
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/audit"
 	"github.com/xw1nchester/kushfinds-backend/internal/auth"
 	authDB "github.com/xw1nchester/kushfinds-backend/internal/auth/db"
 	jwtauth "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt"
 	codeservice "github.com/xw1nchester/kushfinds-backend/internal/code/service"
+	"github.com/xw1nchester/kushfinds-backend/internal/i18n"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/metrics"
 	"github.com/xw1nchester/kushfinds-backend/internal/user"
 	"github.com/xw1nchester/kushfinds-backend/pkg/transactor"
 	"go.uber.org/zap"
 )
 
 var (
-	ErrInvalidCredentials    = apperror.NewAppError("invalid credentials")
-	ErrUserAlreadyVerified   = apperror.NewAppError("the user has already been verified")
-	ErrInvalidCode           = apperror.NewAppError("invalid code")
-	ErrEmailAlreadyExists    = apperror.NewAppError("the user with this email already exists")
-	ErrCodeAlreadySent       = apperror.NewAppError("code has already been sent")
-	ErrNicknameAlreadySet    = apperror.NewAppError("the nickname is already set")
-	ErrPasswordAlreadySet    = apperror.NewAppError("the password is already set")
-	ErrUsernameAlreadyExists = apperror.NewAppError("the user with this username already exists")
-	ErrUserNotVerified       = apperror.NewAppError("the user has not been verified")
-	ErrPasswordNotSet        = apperror.NewAppError("the user does not have a password set")
+	ErrInvalidCredentials    = apperror.NewAppError("auth.invalid_credentials", "invalid credentials")
+	ErrUserAlreadyVerified   = apperror.NewAppError("auth.user_already_verified", "the user has already been verified")
+	ErrInvalidCode           = apperror.NewAppError("auth.invalid_code", "invalid code")
+	ErrEmailAlreadyExists    = apperror.NewConflictError("auth.email_already_exists", "the user with this email already exists")
+	ErrCodeAlreadySent       = apperror.NewAppError("auth.code_already_sent", "code has already been sent")
+	ErrNicknameAlreadySet    = apperror.NewAppError("auth.nickname_already_set", "the nickname is already set")
+	ErrPasswordAlreadySet    = apperror.NewAppError("auth.password_already_set", "the password is already set")
+	ErrUsernameAlreadyExists = apperror.NewConflictError("auth.username_already_exists", "the user with this username already exists")
+	ErrUserNotVerified       = apperror.NewAppError("auth.user_not_verified", "the user has not been verified")
+	ErrPasswordNotSet        = apperror.NewAppError("auth.password_not_set", "the user does not have a password set")
 )
 
 //go:generate mockgen -destination=mocks/repo/mock.go -package=mockauthrepo . Repository
@@ -70,6 +73,15 @@ type PasswordManager interface {
 	CompareHashAndPassword(hashedPassword []byte, password []byte) error
 }
 
+//go:generate mockgen -destination=mocks/audit/mock.go -package=mockaudit . AuditRecorder
+type AuditRecorder interface {
+	Record(ctx context.Context, record audit.Record) error
+}
+
+type Metrics interface {
+	IncAuthEvent(event string)
+}
+
 // TODO: рефакторить
 type service struct {
 	authRepository  Repository
@@ -79,6 +91,8 @@ type service struct {
 	mailManager     MailManager
 	passwordManager PasswordManager
 	txManager       transactor.Manager
+	auditRecorder   AuditRecorder
+	metrics         Metrics
 	logger          *zap.Logger
 }
 
@@ -90,6 +104,8 @@ func New(
 	mailManager MailManager,
 	passwordManager PasswordManager,
 	txManager transactor.Manager,
+	auditRecorder AuditRecorder,
+	metrics Metrics,
 	logger *zap.Logger,
 ) *service {
 	return &service{
@@ -100,6 +116,8 @@ func New(
 		mailManager:     mailManager,
 		passwordManager: passwordManager,
 		txManager:       txManager,
+		auditRecorder:   auditRecorder,
+		metrics:         metrics,
 		logger:          logger,
 	}
 }
@@ -107,7 +125,7 @@ func New(
 func (s *service) generateTokens(ctx context.Context, userAgent string, user jwtauth.UserClaims) (*auth.Tokens, error) {
 	accessToken, err := s.tokenManager.GenerateToken(user)
 	if err != nil {
-		s.logger.Error("unexpected error when generating jwt token", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when generating jwt token", zap.Error(err))
 
 		return nil, err
 	}
@@ -117,7 +135,7 @@ func (s *service) generateTokens(ctx context.Context, userAgent string, user jwt
 
 	err = s.authRepository.CreateSession(ctx, refreshToken, userAgent, user.UserID, expiryDate)
 	if err != nil {
-		s.logger.Error("unexpected error when generating refresh token", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when generating refresh token", zap.Error(err))
 		return nil, err
 	}
 
@@ -127,6 +145,16 @@ func (s *service) generateTokens(ctx context.Context, userAgent string, user jwt
 	}, nil
 }
 
+// preferredLocale is put into access tokens, so the locale preference
+// applies to API responses without a lookup per request.
+func preferredLocale(u *user.User) string {
+	if u.Locale == nil {
+		return ""
+	}
+
+	return *u.Locale
+}
+
 func (s *service) RegisterEmail(ctx context.Context, dto auth.EmailRequest) error {
 	_, err := s.userService.GetByEmail(ctx, dto.Email)
 	if err != nil && !errors.Is(err, apperror.ErrNotFound) {
@@ -157,13 +185,11 @@ func (s *service) RegisterEmail(ctx context.Context, dto auth.EmailRequest) erro
 		return err
 	}
 
+	subject, body := i18n.Mail(i18n.FromContext(ctx), "email.registration_code", generatedCode)
+
 	go func() {
-		if err := s.mailManager.SendMail(
-			"Confirmation of registration",
-			fmt.Sprintf("Your registration confirmation code: %s", generatedCode),
-			[]string{dto.Email},
-		); err != nil {
-			s.logger.Error("unexpected error when sending email", zap.Error(err))
+		if err := s.mailManager.SendMail(subject, body, []string{dto.Email}); err != nil {
+			logging.FromContext(ctx, s.logger).Error("unexpected error when sending email", zap.Error(err))
 		}
 	}()
 
@@ -211,6 +237,7 @@ func (s *service) RegisterVerify(ctx context.Context, dto auth.CodeRequest, user
 			jwtauth.UserClaims{
 				UserID:  existingUser.ID,
 				IsAdmin: existingUser.IsAdmin,
+				Locale:  preferredLocale(existingUser),
 			},
 		)
 		if err != nil {
@@ -253,13 +280,15 @@ func (s *service) VerifyResend(ctx context.Context, dto auth.EmailRequest) error
 		return err
 	}
 
+	subject, body := i18n.Mail(
+		i18n.Resolve(existingUser.Locale, i18n.FromContext(ctx)),
+		"email.registration_code",
+		generatedCode,
+	)
+
 	go func() {
-		if err := s.mailManager.SendMail(
-			"Confirmation of registration",
-			fmt.Sprintf("Your registration confirmation code: %s", generatedCode),
-			[]string{dto.Email},
-		); err != nil {
-			s.logger.Error("unexpected error when sending email", zap.Error(err))
+		if err := s.mailManager.SendMail(subject, body, []string{dto.Email}); err != nil {
+			logging.FromContext(ctx, s.logger).Error("unexpected error when sending email", zap.Error(err))
 		}
 	}()
 
@@ -340,6 +369,7 @@ func (s *service) Login(ctx context.Context, dto auth.EmailPasswordRequest, user
 	existingUser, err := s.userService.GetByEmail(ctx, dto.Email)
 	if err != nil {
 		if errors.Is(err, apperror.ErrNotFound) {
+			s.metrics.IncAuthEvent(metrics.AuthEventLoginFailed)
 			return nil, ErrInvalidCredentials
 		}
 
@@ -355,17 +385,21 @@ func (s *service) Login(ctx context.Context, dto auth.EmailPasswordRequest, user
 	}
 
 	if err := s.passwordManager.CompareHashAndPassword(*existingUser.PasswordHash, []byte(dto.Password)); err != nil {
+		s.metrics.IncAuthEvent(metrics.AuthEventLoginFailed)
 		return nil, ErrInvalidCredentials
 	}
 
 	tokens, err := s.generateTokens(ctx, userAgent, jwtauth.UserClaims{
 		UserID:  existingUser.ID,
-		IsAdmin: existingUser.IsVerified,
+		IsAdmin: existingUser.IsAdmin,
+		Locale:  preferredLocale(existingUser),
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	s.metrics.IncAuthEvent(metrics.AuthEventLogin)
+
 	return &auth.AuthFullResponse{
 		UserResponse: user.UserResponse{User: *existingUser},
 		Tokens:       *tokens,
@@ -379,7 +413,7 @@ func (s *service) Refresh(ctx context.Context, token string, userAgent string) (
 		userID, err := s.authRepository.DeleteNotExpirySessionByToken(ctx, token)
 		if err != nil {
 			if !errors.Is(err, authDB.ErrNotFound) {
-				s.logger.Error("unexpected error when deleting refresh token", zap.Error(err))
+				logging.FromContext(ctx, s.logger).Error("unexpected error when deleting refresh token", zap.Error(err))
 			}
 			return err
 		}
@@ -392,7 +426,11 @@ func (s *service) Refresh(ctx context.Context, token string, userAgent string) (
 		tokens, err = s.generateTokens(
 			ctx,
 			userAgent,
-			jwtauth.UserClaims{UserID: userID, IsAdmin: existingUser.IsAdmin},
+			jwtauth.UserClaims{
+				UserID:  userID,
+				IsAdmin: existingUser.IsAdmin,
+				Locale:  preferredLocale(existingUser),
+			},
 		)
 		if err != nil {
 			return err
@@ -403,14 +441,26 @@ func (s *service) Refresh(ctx context.Context, token string, userAgent string) (
 		return nil, err
 	}
 
+	s.metrics.IncAuthEvent(metrics.AuthEventRefresh)
+
 	return tokens, nil
 }
 
 func (s *service) Logout(ctx context.Context, token string) error {
-	_, err := s.authRepository.DeleteNotExpirySessionByToken(ctx, token)
-	if err != nil && !errors.Is(err, authDB.ErrNotFound) {
-		s.logger.Error("unexpected error when deleting refresh token", zap.Error(err))
-	}
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		userID, err := s.authRepository.DeleteNotExpirySessionByToken(ctx, token)
+		if err != nil {
+			if !errors.Is(err, authDB.ErrNotFound) {
+				logging.FromContext(ctx, s.logger).Error("unexpected error when deleting refresh token", zap.Error(err))
+			}
+			return err
+		}
 
-	return err
+		return s.auditRecorder.Record(ctx, audit.Record{
+			ActorID:    userID,
+			Action:     audit.ActionSessionRevoke,
+			EntityType: audit.EntityUser,
+			EntityID:   userID,
+		})
+	})
 }
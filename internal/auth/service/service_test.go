@@ -8,7 +8,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/audit"
 	"github.com/xw1nchester/kushfinds-backend/internal/auth"
+	jwtauth "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt"
+	mockaudit "github.com/xw1nchester/kushfinds-backend/internal/auth/service/mocks/audit"
 	mockcodeservice "github.com/xw1nchester/kushfinds-backend/internal/auth/service/mocks/code"
 	mockmail "github.com/xw1nchester/kushfinds-backend/internal/auth/service/mocks/mail"
 	mockpassword "github.com/xw1nchester/kushfinds-backend/internal/auth/service/mocks/password"
@@ -16,6 +19,7 @@ import (
 	mocktoken "github.com/xw1nchester/kushfinds-backend/internal/auth/service/mocks/token"
 	mockuserservice "github.com/xw1nchester/kushfinds-backend/internal/auth/service/mocks/user"
 	codeservice "github.com/xw1nchester/kushfinds-backend/internal/code/service"
+	"github.com/xw1nchester/kushfinds-backend/internal/metrics"
 	"github.com/xw1nchester/kushfinds-backend/internal/user"
 	mocktransactor "github.com/xw1nchester/kushfinds-backend/pkg/transactor/mocks"
 	"go.uber.org/mock/gomock"
@@ -62,6 +66,8 @@ var (
 		PasswordHash:  PasswordHash,
 	}
 
+	Claims = jwtauth.UserClaims{UserID: UserID}
+
 	ErrUnexpected = errors.New("unexpected error")
 )
 
@@ -71,7 +77,7 @@ func TestGenerateTokens(t *testing.T) {
 		mockTokenManager *mocktoken.MockTokenManager,
 		mockAuthRepo *mockauthrepo.MockRepository,
 		userAgent string,
-		userID int,
+		claims jwtauth.UserClaims,
 	)
 
 	tests := []struct {
@@ -87,11 +93,11 @@ func TestGenerateTokens(t *testing.T) {
 				mockTokenManager *mocktoken.MockTokenManager,
 				mockAuthRepo *mockauthrepo.MockRepository,
 				userAgent string,
-				userID int,
+				claims jwtauth.UserClaims,
 			) {
-				mockTokenManager.EXPECT().GenerateToken(userID).Return(AccessToken, nil)
+				mockTokenManager.EXPECT().GenerateToken(claims).Return(AccessToken, nil)
 				mockTokenManager.EXPECT().GetRefreshTokenTTL().Return(RefreshTokenTTL)
-				mockAuthRepo.EXPECT().CreateSession(ctx, gomock.Any(), userAgent, userID, gomock.Any()).Return(nil)
+				mockAuthRepo.EXPECT().CreateSession(ctx, gomock.Any(), userAgent, claims.UserID, gomock.Any()).Return(nil)
 			},
 			expectedError:       nil,
 			expectedAccessToken: AccessToken,
@@ -103,9 +109,9 @@ func TestGenerateTokens(t *testing.T) {
 				mockTokenManager *mocktoken.MockTokenManager,
 				mockAuthRepo *mockauthrepo.MockRepository,
 				userAgent string,
-				userID int,
+				claims jwtauth.UserClaims,
 			) {
-				mockTokenManager.EXPECT().GenerateToken(userID).Return("", ErrUnexpected)
+				mockTokenManager.EXPECT().GenerateToken(claims).Return("", ErrUnexpected)
 			},
 			expectedError:       ErrUnexpected,
 			expectedAccessToken: "",
@@ -117,11 +123,11 @@ func TestGenerateTokens(t *testing.T) {
 				mockTokenManager *mocktoken.MockTokenManager,
 				mockAuthRepo *mockauthrepo.MockRepository,
 				userAgent string,
-				userID int,
+				claims jwtauth.UserClaims,
 			) {
-				mockTokenManager.EXPECT().GenerateToken(userID).Return(AccessToken, nil)
+				mockTokenManager.EXPECT().GenerateToken(claims).Return(AccessToken, nil)
 				mockTokenManager.EXPECT().GetRefreshTokenTTL().Return(RefreshTokenTTL)
-				mockAuthRepo.EXPECT().CreateSession(ctx, gomock.Any(), userAgent, userID, gomock.Any()).Return(ErrUnexpected)
+				mockAuthRepo.EXPECT().CreateSession(ctx, gomock.Any(), userAgent, claims.UserID, gomock.Any()).Return(ErrUnexpected)
 			},
 			expectedError:       ErrUnexpected,
 			expectedAccessToken: "",
@@ -143,9 +149,10 @@ func TestGenerateTokens(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			tt.mockBehavior(ctx, mockTokenManager, mockAuthRepo, UserAgent, UserID)
+			claims := jwtauth.UserClaims{UserID: UserID}
+			tt.mockBehavior(ctx, mockTokenManager, mockAuthRepo, UserAgent, claims)
 
-			resp, err := service.generateTokens(ctx, UserAgent, UserID)
+			resp, err := service.generateTokens(ctx, UserAgent, claims)
 
 			if tt.expectedError != nil {
 				require.Error(t, err)
@@ -208,9 +215,9 @@ func TestRegisterEmail(t *testing.T) {
 				mockMailManager *mockmail.MockMailManager,
 				dto auth.EmailRequest,
 			) {
-				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(UnverifiedUser, nil)
+				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(clone(UnverifiedUser), nil)
 			},
-			expectedError: apperror.NewAppError("the user with this email already exists"),
+			expectedError: ErrEmailAlreadyExists,
 		},
 		{
 			name: "unexpected error when fetching existing user",
@@ -334,12 +341,12 @@ func TestRegisterVerify(t *testing.T) {
 				dto auth.CodeRequest,
 				userAgent string,
 			) {
-				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(UnverifiedUser, nil)
+				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(clone(UnverifiedUser), nil)
 				mockCodeService.EXPECT().ValidateVerify(ctx, dto.Code, UserID).Return(nil)
 				mockTxManager.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
 					func(ctx context.Context, fn func(ctx context.Context) error) error {
-						mockUserService.EXPECT().Verify(ctx, UserID).Return(VerifiedUser, nil)
-						mockTokenManager.EXPECT().GenerateToken(UserID).Return(AccessToken, nil)
+						mockUserService.EXPECT().Verify(ctx, UserID).Return(clone(VerifiedUser), nil)
+						mockTokenManager.EXPECT().GenerateToken(Claims).Return(AccessToken, nil)
 						mockTokenManager.EXPECT().GetRefreshTokenTTL().Return(RefreshTokenTTL)
 						mockAuthRepo.EXPECT().CreateSession(ctx, gomock.Any(), userAgent, UserID, gomock.Any()).Return(nil)
 						return fn(ctx)
@@ -393,7 +400,7 @@ func TestRegisterVerify(t *testing.T) {
 				dto auth.CodeRequest,
 				userAgent string,
 			) {
-				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(VerifiedUserWithProfileInfoAndPassword, nil)
+				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(clone(VerifiedUserWithProfileInfoAndPassword), nil)
 			},
 			expectedError: ErrUserAlreadyVerified,
 		},
@@ -409,7 +416,7 @@ func TestRegisterVerify(t *testing.T) {
 				dto auth.CodeRequest,
 				userAgent string,
 			) {
-				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(UnverifiedUser, nil)
+				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(clone(UnverifiedUser), nil)
 				mockCodeService.EXPECT().ValidateVerify(ctx, dto.Code, UserID).Return(codeservice.ErrCodeNotFound)
 			},
 			expectedError: ErrInvalidCode,
@@ -426,7 +433,7 @@ func TestRegisterVerify(t *testing.T) {
 				dto auth.CodeRequest,
 				userAgent string,
 			) {
-				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(UnverifiedUser, nil)
+				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(clone(UnverifiedUser), nil)
 				mockCodeService.EXPECT().ValidateVerify(ctx, dto.Code, UserID).Return(ErrUnexpected)
 			},
 			expectedError: ErrUnexpected,
@@ -443,7 +450,7 @@ func TestRegisterVerify(t *testing.T) {
 				dto auth.CodeRequest,
 				userAgent string,
 			) {
-				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(UnverifiedUser, nil)
+				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(clone(UnverifiedUser), nil)
 				mockCodeService.EXPECT().ValidateVerify(ctx, dto.Code, UserID).Return(nil)
 				mockTxManager.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
 					func(ctx context.Context, fn func(ctx context.Context) error) error {
@@ -466,12 +473,12 @@ func TestRegisterVerify(t *testing.T) {
 				dto auth.CodeRequest,
 				userAgent string,
 			) {
-				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(UnverifiedUser, nil)
+				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(clone(UnverifiedUser), nil)
 				mockCodeService.EXPECT().ValidateVerify(ctx, dto.Code, UserID).Return(nil)
 				mockTxManager.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
 					func(ctx context.Context, fn func(ctx context.Context) error) error {
-						mockUserService.EXPECT().Verify(ctx, UserID).Return(VerifiedUser, nil)
-						mockTokenManager.EXPECT().GenerateToken(UserID).Return("", ErrUnexpected)
+						mockUserService.EXPECT().Verify(ctx, UserID).Return(clone(VerifiedUser), nil)
+						mockTokenManager.EXPECT().GenerateToken(Claims).Return("", ErrUnexpected)
 						return fn(ctx)
 					},
 				)
@@ -490,12 +497,12 @@ func TestRegisterVerify(t *testing.T) {
 				dto auth.CodeRequest,
 				userAgent string,
 			) {
-				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(UnverifiedUser, nil)
+				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(clone(UnverifiedUser), nil)
 				mockCodeService.EXPECT().ValidateVerify(ctx, dto.Code, UserID).Return(nil)
 				mockTxManager.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
 					func(ctx context.Context, fn func(ctx context.Context) error) error {
-						mockUserService.EXPECT().Verify(ctx, UserID).Return(VerifiedUser, nil)
-						mockTokenManager.EXPECT().GenerateToken(UserID).Return("token", nil)
+						mockUserService.EXPECT().Verify(ctx, UserID).Return(clone(VerifiedUser), nil)
+						mockTokenManager.EXPECT().GenerateToken(Claims).Return("token", nil)
 						mockTokenManager.EXPECT().GetRefreshTokenTTL().Return(RefreshTokenTTL)
 						mockAuthRepo.EXPECT().CreateSession(ctx, gomock.Any(), userAgent, UserID, gomock.Any()).Return(ErrUnexpected)
 						return fn(ctx)
@@ -581,7 +588,7 @@ func TestVerifyResend(t *testing.T) {
 				mockMailManager *mockmail.MockMailManager,
 				dto auth.EmailRequest,
 			) {
-				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(UnverifiedUser, nil)
+				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(clone(UnverifiedUser), nil)
 				mockCodeService.EXPECT().GenerateVerify(ctx, UnverifiedUser.ID).Return(Code, nil)
 				mockMailManager.EXPECT().SendMail(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 			},
@@ -622,7 +629,7 @@ func TestVerifyResend(t *testing.T) {
 				mockMailManager *mockmail.MockMailManager,
 				dto auth.EmailRequest,
 			) {
-				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(VerifiedUser, nil)
+				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(clone(VerifiedUserWithProfileInfoAndPassword), nil)
 			},
 			expectedError: ErrUserAlreadyVerified,
 		},
@@ -635,7 +642,7 @@ func TestVerifyResend(t *testing.T) {
 				mockMailManager *mockmail.MockMailManager,
 				dto auth.EmailRequest,
 			) {
-				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(UnverifiedUser, nil)
+				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(clone(UnverifiedUser), nil)
 				mockCodeService.EXPECT().GenerateVerify(ctx, UnverifiedUser.ID).Return("", codeservice.ErrCodeAlreadySent)
 			},
 			expectedError: ErrCodeAlreadySent,
@@ -649,7 +656,7 @@ func TestVerifyResend(t *testing.T) {
 				mockMailManager *mockmail.MockMailManager,
 				dto auth.EmailRequest,
 			) {
-				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(UnverifiedUser, nil)
+				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(clone(UnverifiedUser), nil)
 				mockCodeService.EXPECT().GenerateVerify(ctx, UnverifiedUser.ID).Return("", ErrUnexpected)
 			},
 			expectedError: ErrUnexpected,
@@ -717,7 +724,7 @@ func TestSaveProfileInfo(t *testing.T) {
 				userID int,
 				dto auth.ProfileRequest,
 			) {
-				mockUserService.EXPECT().GetByID(ctx, userID).Return(VerifiedUser, nil)
+				mockUserService.EXPECT().GetByID(ctx, userID).Return(clone(VerifiedUser), nil)
 				mockUserService.EXPECT().CheckUsernameIsAvailable(ctx, dto.Username).Return(true, nil)
 				mockUserService.EXPECT().SetProfileInfo(
 					ctx,
@@ -727,7 +734,7 @@ func TestSaveProfileInfo(t *testing.T) {
 						FirstName: &dto.FirstName,
 						LastName:  &dto.LastName,
 					},
-				).Return(VerifiedUserWithProfileInfo, nil)
+				).Return(clone(VerifiedUserWithProfileInfo), nil)
 			},
 			expectedError: nil,
 			expectedResp:  &user.UserResponse{User: *VerifiedUserWithProfileInfo},
@@ -753,7 +760,7 @@ func TestSaveProfileInfo(t *testing.T) {
 				userID int,
 				dto auth.ProfileRequest,
 			) {
-				mockUserService.EXPECT().GetByID(ctx, userID).Return(VerifiedUserWithProfileInfo, nil)
+				mockUserService.EXPECT().GetByID(ctx, userID).Return(clone(VerifiedUserWithProfileInfo), nil)
 			},
 			expectedError: ErrNicknameAlreadySet,
 			expectedResp:  nil,
@@ -766,7 +773,7 @@ func TestSaveProfileInfo(t *testing.T) {
 				userID int,
 				dto auth.ProfileRequest,
 			) {
-				mockUserService.EXPECT().GetByID(ctx, userID).Return(VerifiedUser, nil)
+				mockUserService.EXPECT().GetByID(ctx, userID).Return(clone(VerifiedUser), nil)
 				mockUserService.EXPECT().CheckUsernameIsAvailable(ctx, dto.Username).Return(false, ErrUnexpected)
 			},
 			expectedError: ErrUnexpected,
@@ -780,7 +787,7 @@ func TestSaveProfileInfo(t *testing.T) {
 				userID int,
 				dto auth.ProfileRequest,
 			) {
-				mockUserService.EXPECT().GetByID(ctx, userID).Return(VerifiedUser, nil)
+				mockUserService.EXPECT().GetByID(ctx, userID).Return(clone(VerifiedUser), nil)
 				mockUserService.EXPECT().CheckUsernameIsAvailable(ctx, dto.Username).Return(false, nil)
 			},
 			expectedError: ErrUsernameAlreadyExists,
@@ -794,7 +801,7 @@ func TestSaveProfileInfo(t *testing.T) {
 				userID int,
 				dto auth.ProfileRequest,
 			) {
-				mockUserService.EXPECT().GetByID(ctx, userID).Return(VerifiedUser, nil)
+				mockUserService.EXPECT().GetByID(ctx, userID).Return(clone(VerifiedUser), nil)
 				mockUserService.EXPECT().CheckUsernameIsAvailable(ctx, dto.Username).Return(true, nil)
 				mockUserService.EXPECT().SetProfileInfo(
 					ctx,
@@ -884,7 +891,7 @@ func TestSavePassword(t *testing.T) {
 				userID int,
 				dto auth.PasswordRequest,
 			) {
-				mockUserService.EXPECT().GetByID(ctx, userID).Return(VerifiedUserWithProfileInfo, nil)
+				mockUserService.EXPECT().GetByID(ctx, userID).Return(clone(VerifiedUserWithProfileInfo), nil)
 				mockPasswordManager.EXPECT().GenerateHashFromPassword([]byte(dto.Password)).Return(*PasswordHash, nil)
 				mockUserService.EXPECT().SetPassword(ctx, userID, *PasswordHash).Return(nil)
 			},
@@ -912,7 +919,7 @@ func TestSavePassword(t *testing.T) {
 				userID int,
 				dto auth.PasswordRequest,
 			) {
-				mockUserService.EXPECT().GetByID(ctx, userID).Return(VerifiedUserWithProfileInfoAndPassword, nil)
+				mockUserService.EXPECT().GetByID(ctx, userID).Return(clone(VerifiedUserWithProfileInfoAndPassword), nil)
 			},
 			expectedError: ErrPasswordAlreadySet,
 		},
@@ -925,7 +932,7 @@ func TestSavePassword(t *testing.T) {
 				userID int,
 				dto auth.PasswordRequest,
 			) {
-				mockUserService.EXPECT().GetByID(ctx, userID).Return(VerifiedUserWithProfileInfo, nil)
+				mockUserService.EXPECT().GetByID(ctx, userID).Return(clone(VerifiedUserWithProfileInfo), nil)
 				mockPasswordManager.EXPECT().GenerateHashFromPassword([]byte(dto.Password)).Return(nil, ErrUnexpected)
 
 			},
@@ -940,7 +947,7 @@ func TestSavePassword(t *testing.T) {
 				userID int,
 				dto auth.PasswordRequest,
 			) {
-				mockUserService.EXPECT().GetByID(ctx, userID).Return(VerifiedUserWithProfileInfo, nil)
+				mockUserService.EXPECT().GetByID(ctx, userID).Return(clone(VerifiedUserWithProfileInfo), nil)
 				mockPasswordManager.EXPECT().GenerateHashFromPassword([]byte(dto.Password)).Return(*PasswordHash, nil)
 				mockUserService.EXPECT().SetPassword(ctx, userID, *PasswordHash).Return(ErrUnexpected)
 
@@ -1012,7 +1019,7 @@ func TestGetUserByEmail(t *testing.T) {
 				mockUserService *mockuserservice.MockUserService,
 				dto auth.EmailRequest,
 			) {
-				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(VerifiedUser, nil)
+				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(clone(VerifiedUser), nil)
 			},
 			expectedError: nil,
 			expectedResp:  &user.UserResponse{User: *VerifiedUser},
@@ -1102,7 +1109,7 @@ func TestLogin(t *testing.T) {
 		expectedResp  *auth.AuthFullResponse
 	}{
 		{
-			name: "success",
+			name: "verified user who is not an admin gets no admin claim",
 			mockBehavior: func(
 				ctx context.Context,
 				mockUserService *mockuserservice.MockUserService,
@@ -1113,11 +1120,43 @@ func TestLogin(t *testing.T) {
 				userAgent string,
 			) {
 				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).
-					Return(VerifiedUserWithProfileInfoAndPassword, nil)
+					Return(clone(VerifiedUserWithProfileInfoAndPassword), nil)
 				mockPasswordManager.EXPECT().
 					CompareHashAndPassword(*VerifiedUserWithProfileInfoAndPassword.PasswordHash, []byte(dto.Password)).
 					Return(nil)
-				mockTokenManager.EXPECT().GenerateToken(UserID).Return(AccessToken, nil)
+				mockTokenManager.EXPECT().GenerateToken(Claims).Return(AccessToken, nil)
+				mockTokenManager.EXPECT().GetRefreshTokenTTL().Return(RefreshTokenTTL)
+				mockAuthRepo.EXPECT().CreateSession(ctx, gomock.Any(), UserAgent, UserID, gomock.Any())
+			},
+			expectedError: nil,
+			expectedResp: &auth.AuthFullResponse{
+				UserResponse: user.UserResponse{User: *VerifiedUserWithProfileInfoAndPassword},
+				Tokens: auth.Tokens{
+					JwtToken: auth.JwtToken{AccessToken: AccessToken},
+				},
+			},
+		},
+		{
+			name: "admin gets the admin claim",
+			mockBehavior: func(
+				ctx context.Context,
+				mockUserService *mockuserservice.MockUserService,
+				mockPasswordManager *mockpassword.MockPasswordManager,
+				mockTokenManager *mocktoken.MockTokenManager,
+				mockAuthRepo *mockauthrepo.MockRepository,
+				dto auth.EmailPasswordRequest,
+				userAgent string,
+			) {
+				admin := clone(VerifiedUserWithProfileInfoAndPassword)
+				admin.IsAdmin = true
+
+				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).Return(admin, nil)
+				mockPasswordManager.EXPECT().
+					CompareHashAndPassword(*VerifiedUserWithProfileInfoAndPassword.PasswordHash, []byte(dto.Password)).
+					Return(nil)
+				mockTokenManager.EXPECT().
+					GenerateToken(jwtauth.UserClaims{UserID: UserID, IsAdmin: true}).
+					Return(AccessToken, nil)
 				mockTokenManager.EXPECT().GetRefreshTokenTTL().Return(RefreshTokenTTL)
 				mockAuthRepo.EXPECT().CreateSession(ctx, gomock.Any(), UserAgent, UserID, gomock.Any())
 			},
@@ -1173,7 +1212,7 @@ func TestLogin(t *testing.T) {
 				userAgent string,
 			) {
 				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).
-					Return(UnverifiedUser, nil)
+					Return(clone(UnverifiedUser), nil)
 			},
 			expectedError: ErrUserNotVerified,
 		},
@@ -1189,7 +1228,7 @@ func TestLogin(t *testing.T) {
 				userAgent string,
 			) {
 				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).
-					Return(VerifiedUser, nil)
+					Return(clone(VerifiedUser), nil)
 			},
 			expectedError: ErrPasswordNotSet,
 		},
@@ -1205,7 +1244,7 @@ func TestLogin(t *testing.T) {
 				userAgent string,
 			) {
 				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).
-					Return(VerifiedUserWithProfileInfoAndPassword, nil)
+					Return(clone(VerifiedUserWithProfileInfoAndPassword), nil)
 				mockPasswordManager.EXPECT().
 					CompareHashAndPassword(*VerifiedUserWithProfileInfoAndPassword.PasswordHash, []byte(dto.Password)).
 					Return(ErrUnexpected)
@@ -1224,11 +1263,11 @@ func TestLogin(t *testing.T) {
 				userAgent string,
 			) {
 				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).
-					Return(VerifiedUserWithProfileInfoAndPassword, nil)
+					Return(clone(VerifiedUserWithProfileInfoAndPassword), nil)
 				mockPasswordManager.EXPECT().
 					CompareHashAndPassword(*VerifiedUserWithProfileInfoAndPassword.PasswordHash, []byte(dto.Password)).
 					Return(nil)
-				mockTokenManager.EXPECT().GenerateToken(UserID).Return("", ErrUnexpected)
+				mockTokenManager.EXPECT().GenerateToken(Claims).Return("", ErrUnexpected)
 			},
 			expectedError: ErrUnexpected,
 		},
@@ -1244,11 +1283,11 @@ func TestLogin(t *testing.T) {
 				userAgent string,
 			) {
 				mockUserService.EXPECT().GetByEmail(ctx, dto.Email).
-					Return(VerifiedUserWithProfileInfoAndPassword, nil)
+					Return(clone(VerifiedUserWithProfileInfoAndPassword), nil)
 				mockPasswordManager.EXPECT().
 					CompareHashAndPassword(*VerifiedUserWithProfileInfoAndPassword.PasswordHash, []byte(dto.Password)).
 					Return(nil)
-				mockTokenManager.EXPECT().GenerateToken(UserID).Return(AccessToken, nil)
+				mockTokenManager.EXPECT().GenerateToken(Claims).Return(AccessToken, nil)
 				mockTokenManager.EXPECT().GetRefreshTokenTTL().Return(RefreshTokenTTL)
 				mockAuthRepo.EXPECT().CreateSession(ctx, gomock.Any(), userAgent, UserID, gomock.Any()).Return(ErrUnexpected)
 			},
@@ -1271,6 +1310,7 @@ func TestLogin(t *testing.T) {
 				tokenManager:    mockTokenManager,
 				passwordManager: mockPasswordManager,
 				authRepository:  mockAuthRepo,
+				metrics:         metrics.NewRegistry(nil),
 				logger:          zap.NewNop(),
 			}
 
@@ -1318,6 +1358,7 @@ func TestRefresh(t *testing.T) {
 		mockTxManager *mocktransactor.MockManager,
 		mockAuthRepo *mockauthrepo.MockRepository,
 		mockTokenManager *mocktoken.MockTokenManager,
+		mockUserService *mockuserservice.MockUserService,
 		token string,
 		userAgent string,
 	)
@@ -1337,13 +1378,15 @@ func TestRefresh(t *testing.T) {
 				mockTxManager *mocktransactor.MockManager,
 				mockAuthRepo *mockauthrepo.MockRepository,
 				mockTokenManager *mocktoken.MockTokenManager,
+				mockUserService *mockuserservice.MockUserService,
 				token string,
 				userAgent string,
 			) {
 				mockTxManager.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
 					func(ctx context.Context, fn func(context.Context) error) error {
 						mockAuthRepo.EXPECT().DeleteNotExpirySessionByToken(ctx, gomock.Any()).Return(UserID, nil)
-						mockTokenManager.EXPECT().GenerateToken(UserID).Return(AccessToken, nil)
+						mockUserService.EXPECT().GetByID(ctx, UserID).Return(clone(VerifiedUser), nil)
+						mockTokenManager.EXPECT().GenerateToken(Claims).Return(AccessToken, nil)
 						mockTokenManager.EXPECT().GetRefreshTokenTTL().Return(RefreshTokenTTL)
 						mockAuthRepo.EXPECT().CreateSession(ctx, gomock.Any(), userAgent, UserID, gomock.Any())
 						return fn(ctx)
@@ -1362,6 +1405,7 @@ func TestRefresh(t *testing.T) {
 				mockTxManager *mocktransactor.MockManager,
 				mockAuthRepo *mockauthrepo.MockRepository,
 				mockTokenManager *mocktoken.MockTokenManager,
+				mockUserService *mockuserservice.MockUserService,
 				token string,
 				userAgent string,
 			) {
@@ -1381,13 +1425,15 @@ func TestRefresh(t *testing.T) {
 				mockTxManager *mocktransactor.MockManager,
 				mockAuthRepo *mockauthrepo.MockRepository,
 				mockTokenManager *mocktoken.MockTokenManager,
+				mockUserService *mockuserservice.MockUserService,
 				token string,
 				userAgent string,
 			) {
 				mockTxManager.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
 					func(ctx context.Context, fn func(context.Context) error) error {
 						mockAuthRepo.EXPECT().DeleteNotExpirySessionByToken(ctx, gomock.Any()).Return(UserID, nil)
-						mockTokenManager.EXPECT().GenerateToken(UserID).Return("", ErrUnexpected)
+						mockUserService.EXPECT().GetByID(ctx, UserID).Return(clone(VerifiedUser), nil)
+						mockTokenManager.EXPECT().GenerateToken(Claims).Return("", ErrUnexpected)
 						return fn(ctx)
 					},
 				)
@@ -1401,13 +1447,15 @@ func TestRefresh(t *testing.T) {
 				mockTxManager *mocktransactor.MockManager,
 				mockAuthRepo *mockauthrepo.MockRepository,
 				mockTokenManager *mocktoken.MockTokenManager,
+				mockUserService *mockuserservice.MockUserService,
 				token string,
 				userAgent string,
 			) {
 				mockTxManager.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
 					func(ctx context.Context, fn func(context.Context) error) error {
 						mockAuthRepo.EXPECT().DeleteNotExpirySessionByToken(ctx, gomock.Any()).Return(UserID, nil)
-						mockTokenManager.EXPECT().GenerateToken(UserID).Return(AccessToken, nil)
+						mockUserService.EXPECT().GetByID(ctx, UserID).Return(clone(VerifiedUser), nil)
+						mockTokenManager.EXPECT().GenerateToken(Claims).Return(AccessToken, nil)
 						mockTokenManager.EXPECT().GetRefreshTokenTTL().Return(RefreshTokenTTL)
 						mockAuthRepo.EXPECT().CreateSession(ctx, gomock.Any(), UserAgent, UserID, gomock.Any()).Return(ErrUnexpected)
 						return fn(ctx)
@@ -1428,15 +1476,18 @@ func TestRefresh(t *testing.T) {
 			mockTxManager := mocktransactor.NewMockManager(ctrl)
 			mockAuthRepo := mockauthrepo.NewMockRepository(ctrl)
 			mockTokenManager := mocktoken.NewMockTokenManager(ctrl)
+			mockUserService := mockuserservice.NewMockUserService(ctrl)
 
 			service := &service{
 				txManager:      mockTxManager,
 				authRepository: mockAuthRepo,
 				tokenManager:   mockTokenManager,
+				userService:    mockUserService,
+				metrics:        metrics.NewRegistry(nil),
 				logger:         zap.NewNop(),
 			}
 
-			tt.mockBehavior(ctx, mockTxManager, mockAuthRepo, mockTokenManager, gomock.Any().String(), UserAgent)
+			tt.mockBehavior(ctx, mockTxManager, mockAuthRepo, mockTokenManager, mockUserService, gomock.Any().String(), UserAgent)
 
 			resp, err := service.Refresh(ctx, gomock.Any().String(), UserAgent)
 
@@ -1457,7 +1508,9 @@ func TestRefresh(t *testing.T) {
 func TestLogout(t *testing.T) {
 	type mockBehavior func(
 		ctx context.Context,
+		mockTxManager *mocktransactor.MockManager,
 		mockAuthRepo *mockauthrepo.MockRepository,
+		mockAuditRecorder *mockaudit.MockAuditRecorder,
 	)
 
 	tests := []struct {
@@ -1469,19 +1522,39 @@ func TestLogout(t *testing.T) {
 			name: "success",
 			mockBehavior: func(
 				ctx context.Context,
+				mockTxManager *mocktransactor.MockManager,
 				mockAuthRepo *mockauthrepo.MockRepository,
+				mockAuditRecorder *mockaudit.MockAuditRecorder,
 			) {
-				mockAuthRepo.EXPECT().DeleteNotExpirySessionByToken(ctx, gomock.Any()).Return(UserID, nil)
+				mockTxManager.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error {
+						mockAuthRepo.EXPECT().DeleteNotExpirySessionByToken(ctx, gomock.Any()).Return(UserID, nil)
+						mockAuditRecorder.EXPECT().Record(ctx, audit.Record{
+							ActorID:    UserID,
+							Action:     audit.ActionSessionRevoke,
+							EntityType: audit.EntityUser,
+							EntityID:   UserID,
+						}).Return(nil)
+						return fn(ctx)
+					},
+				)
 			},
 			expectedError: nil,
 		},
 		{
-			name: "success",
+			name: "error when deleting session",
 			mockBehavior: func(
 				ctx context.Context,
+				mockTxManager *mocktransactor.MockManager,
 				mockAuthRepo *mockauthrepo.MockRepository,
+				mockAuditRecorder *mockaudit.MockAuditRecorder,
 			) {
-				mockAuthRepo.EXPECT().DeleteNotExpirySessionByToken(ctx, gomock.Any()).Return(UserID, ErrUnexpected)
+				mockTxManager.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error {
+						mockAuthRepo.EXPECT().DeleteNotExpirySessionByToken(ctx, gomock.Any()).Return(UserID, ErrUnexpected)
+						return fn(ctx)
+					},
+				)
 			},
 			expectedError: ErrUnexpected,
 		},
@@ -1492,17 +1565,23 @@ func TestLogout(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
+			mockTxManager := mocktransactor.NewMockManager(ctrl)
 			mockAuthRepo := mockauthrepo.NewMockRepository(ctrl)
+			mockAuditRecorder := mockaudit.NewMockAuditRecorder(ctrl)
 
 			service := &service{
+				txManager:      mockTxManager,
 				authRepository: mockAuthRepo,
+				auditRecorder:  mockAuditRecorder,
 				logger:         zap.NewNop(),
 			}
 
 			ctx := context.Background()
 			tt.mockBehavior(
 				ctx,
+				mockTxManager,
 				mockAuthRepo,
+				mockAuditRecorder,
 			)
 
 			err := service.Logout(
@@ -1523,3 +1602,9 @@ func TestLogout(t *testing.T) {
 func ptrStr(s string) *string {
 	return &s
 }
+
+// clone keeps the shared fixtures intact when the service updates a user it got.
+func clone(u *user.User) *user.User {
+	c := *u
+	return &c
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/xw1nchester/kushfinds-backend/internal/code/db"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
 	"go.uber.org/zap"
 )
 
@@ -55,7 +56,7 @@ func (s *service) generateVerificationCode() (string, error) {
 func (s *service) generate(ctx context.Context, codeType string, userID int) (string, error) {
 	exists, err := s.repository.CheckRecentlyCodeExists(ctx, codeType, userID)
 	if err != nil && !errors.Is(err, db.ErrCodeNotFound) {
-		s.logger.Info("error when check recently code exists", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Info("error when check recently code exists", zap.Error(err))
 		return "", ErrInternal
 	}
 
@@ -69,7 +70,7 @@ func (s *service) generate(ctx context.Context, codeType string, userID int) (st
 	}
 
 	if err := s.repository.Create(ctx, code, VerifyCodeType, userID, time.Now().Add(1*time.Minute), time.Now().Add(5*time.Minute)); err != nil {
-		s.logger.Info("error when code creation", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Info("error when code creation", zap.Error(err))
 		return "", ErrInternal
 	}
 
@@ -91,7 +92,7 @@ func (s *service) GenerateChangePassword(ctx context.Context, userID int) (strin
 func (s *service) validate(ctx context.Context, code string, codeType string, userID int) error {
 	exists, err := s.repository.CheckNotExpiryCodeExists(ctx, code, codeType, userID)
 	if err != nil && !errors.Is(err, db.ErrCodeNotFound) {
-		s.logger.Info("error when check not expiry code exists", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Info("error when check not expiry code exists", zap.Error(err))
 		return err
 	}
 
@@ -15,6 +15,10 @@ type Config struct {
 	JWT        JWT        `yaml:"jwt"`
 	SMTP       SMTP       `yaml:"smtp"`
 	Minio      Minio      `yaml:"minio"`
+	Age        Age        `yaml:"age"`
+	Tracing    Tracing    `yaml:"tracing"`
+	Health     Health     `yaml:"health"`
+	Licenses   Licenses   `yaml:"licenses"`
 }
 
 type PostgreSQL struct {
@@ -33,7 +37,16 @@ type HTTPServer struct {
 	AllowCredentials bool          `yaml:"allow_credentials"`
 	AllowedMethods   []string      `yaml:"allowed_methods" env-default:"*"`
 	AllowedHeaders   []string      `yaml:"allowed_headers" env-default:"*"`
+	ExposedHeaders   []string      `yaml:"exposed_headers"`
 	StaticURL        string        `yaml:"static_url" env-required:"true"`
+	AdminAddress     string        `yaml:"admin_address"`
+	Idempotency      Idempotency   `yaml:"idempotency"`
+}
+
+type Idempotency struct {
+	TTL           time.Duration `yaml:"ttl" env-default:"24h"`
+	Lease         time.Duration `yaml:"lease" env-default:"1m"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"`
 }
 
 type JWT struct {
@@ -56,6 +69,30 @@ type Minio struct {
 	UseSSL          bool   `yaml:"use_ssl"`
 }
 
+type Age struct {
+	Provider          string `yaml:"provider" env-default:"disabled"`
+	DefaultMinimumAge int    `yaml:"default_minimum_age" env-default:"21"`
+}
+
+type Tracing struct {
+	Exporter    string  `yaml:"exporter" env-default:"none"`
+	Endpoint    string  `yaml:"endpoint"`
+	Insecure    bool    `yaml:"insecure"`
+	ServiceName string  `yaml:"service_name" env-default:"kushfinds"`
+	SampleRatio float64 `yaml:"sample_ratio" env-default:"1"`
+}
+
+type Health struct {
+	Timeout        time.Duration `yaml:"timeout" env-default:"2s"`
+	DrainDelay     time.Duration `yaml:"drain_delay" env-default:"0s"`
+	MigrationsPath string        `yaml:"migrations_path" env-default:"migrations"`
+}
+
+type Licenses struct {
+	ReminderInterval time.Duration `yaml:"reminder_interval" env-default:"1h"`
+	ReminderLeadTime time.Duration `yaml:"reminder_lead_time" env-default:"720h"`
+}
+
 func MustLoad() *Config {
 	configPath := fetchConfigPath()
 	if configPath == "" {
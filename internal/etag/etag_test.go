@@ -0,0 +1,94 @@
+package etag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIfMatch(t *testing.T) {
+	tag := New(3, []byte(`{"id":1}`))
+
+	testCases := []struct {
+		name   string
+		header string
+		want   []int
+	}{
+		{name: "Absent", header: "", want: nil},
+		{name: "Any", header: "*", want: nil},
+		{name: "Issued tag", header: tag, want: []int{3}},
+		{name: "List", header: `"1.00", ` + tag, want: []int{1, 3}},
+		{name: "Weak tag", header: "W/" + tag, want: []int{}},
+		{name: "Unquoted", header: "3.00", want: []int{}},
+		{name: "Foreign tag", header: `"abc"`, want: []int{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPatch, "/", nil)
+			if tc.header != "" {
+				r.Header.Set(HeaderIfMatch, tc.header)
+			}
+
+			assert.Equal(t, tc.want, IfMatch(r))
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	assert.True(t, Matches(nil, 2))
+	assert.True(t, Matches([]int{1, 2}, 2))
+	assert.False(t, Matches([]int{1}, 2))
+	assert.False(t, Matches([]int{}, 2))
+}
+
+func TestNewDependsOnRepresentation(t *testing.T) {
+	assert.Equal(t, New(1, []byte("a")), New(1, []byte("a")))
+	assert.NotEqual(t, New(1, []byte("a")), New(1, []byte("b")))
+	assert.NotEqual(t, New(1, []byte("a")), New(2, []byte("a")))
+}
+
+func TestWrite(t *testing.T) {
+	body := map[string]int{"id": 1}
+
+	first := httptest.NewRecorder()
+	Write(first, httptest.NewRequest(http.MethodGet, "/", nil), 1, body)
+
+	tag := first.Header().Get(HeaderETag)
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "application/json", first.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"id":1}`, first.Body.String())
+	assert.NotEmpty(t, tag)
+
+	testCases := []struct {
+		name       string
+		method     string
+		header     string
+		wantStatus int
+	}{
+		{name: "Current tag", method: http.MethodGet, header: tag, wantStatus: http.StatusNotModified},
+		{name: "Weak current tag", method: http.MethodGet, header: "W/" + tag, wantStatus: http.StatusNotModified},
+		{name: "In list", method: http.MethodGet, header: `"2.00", ` + tag, wantStatus: http.StatusNotModified},
+		{name: "Any", method: http.MethodGet, header: "*", wantStatus: http.StatusNotModified},
+		{name: "Stale tag", method: http.MethodGet, header: New(1, []byte("old")), wantStatus: http.StatusOK},
+		{name: "Unsafe method", method: http.MethodPatch, header: tag, wantStatus: http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, "/", nil)
+			r.Header.Set(HeaderIfNoneMatch, tc.header)
+
+			rec := httptest.NewRecorder()
+			Write(rec, r, 1, body)
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			assert.Equal(t, tag, rec.Header().Get(HeaderETag))
+			if tc.wantStatus == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			}
+		})
+	}
+}
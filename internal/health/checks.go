@@ -0,0 +1,107 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/minio/minio-go/v7"
+)
+
+func PostgreSQL(pool *pgxpool.Pool) Check {
+	return func(ctx context.Context) error {
+		return pool.Ping(ctx)
+	}
+}
+
+func Minio(client *minio.Client) Check {
+	return func(ctx context.Context) error {
+		_, err := client.ListBuckets(ctx)
+		return err
+	}
+}
+
+// SMTP only dials the server, no handshake or auth is performed.
+func SMTP(host, port string) Check {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+		if err != nil {
+			return err
+		}
+
+		return conn.Close()
+	}
+}
+
+// Migrations compares the version recorded by golang-migrate
+// with the newest up migration in dir.
+func Migrations(pool *pgxpool.Pool, dir string) Check {
+	return func(ctx context.Context) error {
+		latest, err := latestMigration(dir)
+		if err != nil {
+			return err
+		}
+
+		var (
+			version uint64
+			dirty   bool
+		)
+
+		err = pool.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("no migrations applied, latest is %d", latest)
+			}
+			return err
+		}
+
+		if dirty {
+			return fmt.Errorf("migration %d is dirty", version)
+		}
+
+		if version < latest {
+			return fmt.Errorf("pending migrations: database is at %d, latest is %d", version, latest)
+		}
+
+		return nil
+	}
+}
+
+func latestMigration(dir string) (uint64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("unable to read migrations directory: %v", err)
+	}
+
+	var latest uint64
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+
+		prefix, _, _ := strings.Cut(name, "_")
+
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		latest = max(latest, version)
+	}
+
+	if latest == 0 {
+		return 0, fmt.Errorf("no migrations found in %s", dir)
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,52 @@
+package healthhandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/xw1nchester/kushfinds-backend/internal/handlers"
+	"github.com/xw1nchester/kushfinds-backend/internal/health"
+	"go.uber.org/zap"
+)
+
+type Checker interface {
+	Ready(ctx context.Context) health.Report
+}
+
+type handler struct {
+	checker Checker
+	logger  *zap.Logger
+}
+
+func New(checker Checker, logger *zap.Logger) handlers.Handler {
+	return &handler{
+		checker: checker,
+		logger:  logger,
+	}
+}
+
+func (h *handler) Register(router chi.Router) {
+	router.Get("/healthz", h.LivenessHandler)
+	router.Get("/readyz", h.ReadinessHandler)
+}
+
+// LivenessHandler only confirms the process serves HTTP, dependencies are not checked.
+func (h *handler) LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	render.JSON(w, r, health.Report{Status: health.StatusUp})
+}
+
+// ReadinessHandler answers 503 while any dependency is down or the app is shutting down.
+func (h *handler) ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	report := h.checker.Ready(r.Context())
+
+	if report.Status != health.StatusUp {
+		if !report.ShuttingDown {
+			h.logger.Warn("readiness check failed", zap.Any("checks", report.Checks))
+		}
+		render.Status(r, http.StatusServiceUnavailable)
+	}
+
+	render.JSON(w, r, report)
+}
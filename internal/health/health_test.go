@@ -0,0 +1,92 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReady(t *testing.T) {
+	t.Run("all up", func(t *testing.T) {
+		c := New(time.Second)
+		c.Add("a", func(ctx context.Context) error { return nil })
+		c.Add("b", func(ctx context.Context) error { return nil })
+
+		report := c.Ready(context.Background())
+
+		assert.Equal(t, StatusUp, report.Status)
+		assert.Len(t, report.Checks, 2)
+		assert.Equal(t, StatusUp, report.Checks["a"].Status)
+	})
+
+	t.Run("one down", func(t *testing.T) {
+		c := New(time.Second)
+		c.Add("a", func(ctx context.Context) error { return nil })
+		c.Add("b", func(ctx context.Context) error { return errors.New("connection refused") })
+
+		report := c.Ready(context.Background())
+
+		assert.Equal(t, StatusDown, report.Status)
+		assert.Equal(t, StatusUp, report.Checks["a"].Status)
+		assert.Equal(t, StatusDown, report.Checks["b"].Status)
+		assert.Equal(t, "connection refused", report.Checks["b"].Error)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		c := New(20 * time.Millisecond)
+		block := make(chan struct{})
+		t.Cleanup(func() { close(block) })
+		c.Add("slow", func(ctx context.Context) error {
+			<-block
+			return nil
+		})
+
+		start := time.Now()
+		report := c.Ready(context.Background())
+
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, StatusDown, report.Status)
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["slow"].Error)
+	})
+
+	t.Run("shutting down", func(t *testing.T) {
+		c := New(time.Second)
+		c.Add("a", func(ctx context.Context) error { return nil })
+		c.SetShuttingDown()
+
+		report := c.Ready(context.Background())
+
+		assert.Equal(t, StatusDown, report.Status)
+		assert.True(t, report.ShuttingDown)
+		assert.Empty(t, report.Checks)
+	})
+}
+
+func TestLatestMigration(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"20250101000000_init.up.sql",
+		"20250101000000_init.down.sql",
+		"20250301000000_add_index.up.sql",
+		"20250401000000_add_column.down.sql",
+		"README.md",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+
+	latest, err := latestMigration(dir)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20250301000000), latest)
+
+	_, err = latestMigration(t.TempDir())
+	assert.Error(t, err)
+
+	_, err = latestMigration(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
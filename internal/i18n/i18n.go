@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+)
+
+const (
+	LocaleEn = "en"
+	LocaleRu = "ru"
+
+	DefaultLocale = LocaleEn
+)
+
+// Locales lists the supported locales, the default one first.
+var Locales = []string{LocaleEn, LocaleRu}
+
+//go:embed locales/*.json
+var files embed.FS
+
+// catalogs maps a locale to its messages keyed by error code or
+// template name. Values are fmt formats, explicit argument indexes
+// like %[2]s let translations reorder arguments.
+var catalogs = mustLoadCatalogs()
+
+func mustLoadCatalogs() map[string]map[string]string {
+	catalogs := make(map[string]map[string]string, len(Locales))
+
+	for _, locale := range Locales {
+		data, err := files.ReadFile(path.Join("locales", locale+".json"))
+		if err != nil {
+			panic(fmt.Sprintf("missing %s catalog: %v", locale, err))
+		}
+
+		catalog := make(map[string]string)
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			panic(fmt.Sprintf("invalid %s catalog: %v", locale, err))
+		}
+
+		catalogs[locale] = catalog
+	}
+
+	return catalogs
+}
+
+// IsSupported reports whether a catalog exists for the locale.
+func IsSupported(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok
+}
+
+// Lookup formats the message of key in the locale, falling back to the
+// default locale. It reports false if neither catalog has the key.
+func Lookup(locale, key string, args ...any) (string, bool) {
+	format, ok := catalogs[locale][key]
+	if !ok {
+		format, ok = catalogs[DefaultLocale][key]
+	}
+	if !ok {
+		return "", false
+	}
+
+	// templates share arguments, so e.g. a subject may use none of them
+	if len(args) == 0 || !strings.Contains(format, "%") {
+		return format, true
+	}
+
+	return fmt.Sprintf(format, args...), true
+}
+
+// T is Lookup that returns the key itself for unknown keys,
+// so a missing entry is visible rather than an empty string.
+func T(locale, key string, args ...any) string {
+	if message, ok := Lookup(locale, key, args...); ok {
+		return message
+	}
+
+	return key
+}
+
+// Resolve picks the stored user preference when it is supported,
+// otherwise the fallback, e.g. the locale of the current request.
+func Resolve(preferred *string, fallback string) string {
+	if preferred != nil && IsSupported(*preferred) {
+		return *preferred
+	}
+
+	if IsSupported(fallback) {
+		return fallback
+	}
+
+	return DefaultLocale
+}
+
+// Mail renders the subject and body of an email template,
+// stored in catalogs as "<name>.subject" and "<name>.body".
+func Mail(locale, name string, args ...any) (string, string) {
+	return T(locale, name+".subject", args...), strings.TrimSpace(T(locale, name+".body", args...))
+}
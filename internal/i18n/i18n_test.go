@@ -0,0 +1,104 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var verb = regexp.MustCompile(`%(\[\d+\])?[sdv]`)
+
+// Every catalog must have the same keys and placeholders as the default one,
+// otherwise a translation silently falls back or renders %!(MISSING).
+func TestCatalogsMatchDefault(t *testing.T) {
+	for _, locale := range Locales[1:] {
+		for key, format := range catalogs[DefaultLocale] {
+			translated, ok := catalogs[locale][key]
+			if !assert.True(t, ok, "%s: missing %q", locale, key) {
+				continue
+			}
+
+			assert.Equal(t, placeholders(format), placeholders(translated), "%s: %q", locale, key)
+		}
+
+		for key := range catalogs[locale] {
+			_, ok := catalogs[DefaultLocale][key]
+			assert.True(t, ok, "%s: %q is not in the default catalog", locale, key)
+		}
+	}
+}
+
+func placeholders(format string) []string {
+	found := verb.FindAllString(format, -1)
+	sort.Strings(found)
+	return found
+}
+
+func TestLookup(t *testing.T) {
+	message, ok := Lookup(LocaleRu, "order.minimal_price", 100)
+	assert.True(t, ok)
+	assert.Equal(t, "минимальная сумма заказа 100", message)
+
+	message, ok = Lookup("de", "not_found")
+	assert.True(t, ok)
+	assert.Equal(t, "not found", message)
+
+	_, ok = Lookup(LocaleEn, "missing.key")
+	assert.False(t, ok)
+	assert.Equal(t, "missing.key", T(LocaleEn, "missing.key"))
+}
+
+func TestMail(t *testing.T) {
+	subject, body := Mail(LocaleRu, "email.new_store", "Brand", "Store", "Main st.", "1")
+
+	assert.Equal(t, "Brand открыл новый магазин", subject)
+	assert.Equal(t, "Brand открыл новый магазин Store по адресу Main st. 1.", body)
+
+	subject, body = Mail(LocaleEn, "email.business_profile_approved", "Acme")
+
+	assert.Equal(t, "Your business profile has been approved", subject)
+	assert.Equal(t, "Your business profile Acme has been approved.", body)
+}
+
+func TestResolve(t *testing.T) {
+	ru, de := LocaleRu, "de"
+
+	assert.Equal(t, LocaleRu, Resolve(&ru, LocaleEn))
+	assert.Equal(t, LocaleEn, Resolve(&de, LocaleEn))
+	assert.Equal(t, LocaleRu, Resolve(nil, LocaleRu))
+	assert.Equal(t, DefaultLocale, Resolve(nil, "de"))
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := map[string]string{
+		"":                        LocaleEn,
+		"ru":                      LocaleRu,
+		"ru-RU,ru;q=0.9,en;q=0.8": LocaleRu,
+		"de-DE,en;q=0.5":          LocaleEn,
+		"fr":                      LocaleEn,
+		"not a header;;":          LocaleEn,
+	}
+
+	for header, expected := range tests {
+		assert.Equal(t, expected, ParseAcceptLanguage(header), header)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var locale string
+	h := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale = FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "ru-RU")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, LocaleRu, locale)
+	assert.Equal(t, DefaultLocale, FromContext(context.Background()))
+}
@@ -0,0 +1,41 @@
+package i18n
+
+import (
+	"fmt"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ru"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	entranslations "github.com/go-playground/validator/v10/translations/en"
+	rutranslations "github.com/go-playground/validator/v10/translations/ru"
+)
+
+var universal = ut.New(en.New(), en.New(), ru.New())
+
+// RegisterValidator installs translations of the built-in validation tags
+// for every supported locale. Translations are bound to the instance,
+// so it has to be called for each validator.
+func RegisterValidator(validate *validator.Validate) error {
+	register := map[string]func(*validator.Validate, ut.Translator) error{
+		LocaleEn: entranslations.RegisterDefaultTranslations,
+		LocaleRu: rutranslations.RegisterDefaultTranslations,
+	}
+
+	for _, locale := range Locales {
+		if err := register[locale](validate, ValidationTranslator(locale)); err != nil {
+			return fmt.Errorf("unable to register %s validation translations: %v", locale, err)
+		}
+	}
+
+	return nil
+}
+
+func ValidationTranslator(locale string) ut.Translator {
+	trans, found := universal.GetTranslator(locale)
+	if !found {
+		trans, _ = universal.GetTranslator(DefaultLocale)
+	}
+
+	return trans
+}
@@ -0,0 +1,160 @@
+package idempotencydb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/xw1nchester/kushfinds-backend/internal/idempotency"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrKeyNotFound = errors.New("idempotency key not found")
+	ErrKeyClaimed  = errors.New("idempotency key is already claimed")
+)
+
+type repository struct {
+	client *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func New(client *pgxpool.Pool, logger *zap.Logger) *repository {
+	return &repository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Claim reserves the key for the user for lease. A live row makes concurrent
+// claims lose with ErrKeyClaimed, an expired one is taken over,
+// so keys of requests that died before completing are freed once the lease ends.
+func (r *repository) Claim(
+	ctx context.Context,
+	userID int,
+	key string,
+	fingerprint string,
+	lease time.Duration,
+) (*idempotency.Claim, error) {
+	query := `
+		INSERT INTO idempotency_keys (user_id, key, fingerprint, expires_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP + make_interval(secs => $4))
+		ON CONFLICT (user_id, key) DO UPDATE
+		SET 
+			fingerprint=EXCLUDED.fingerprint,
+			status_code=NULL,
+			headers=NULL,
+			body=NULL,
+			created_at=CURRENT_TIMESTAMP,
+			expires_at=EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < CURRENT_TIMESTAMP
+		RETURNING created_at
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	claim := idempotency.Claim{
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+	}
+	if err := r.client.QueryRow(ctx, query, userID, key, fingerprint, lease.Seconds()).Scan(&claim.ClaimedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrKeyClaimed
+		}
+
+		return nil, err
+	}
+
+	return &claim, nil
+}
+
+func (r *repository) Get(ctx context.Context, userID int, key string) (*idempotency.Record, error) {
+	query := `
+		SELECT fingerprint, status_code, headers, body
+		FROM idempotency_keys
+		WHERE user_id=$1 AND key=$2 AND expires_at >= CURRENT_TIMESTAMP
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	var record idempotency.Record
+	if err := r.client.QueryRow(ctx, query, userID, key).Scan(
+		&record.Fingerprint,
+		&record.StatusCode,
+		&record.Header,
+		&record.Body,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrKeyNotFound
+		}
+
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// Complete stores the response and keeps it for ttl. It does nothing
+// if the claim was taken over by another request in the meantime.
+func (r *repository) Complete(
+	ctx context.Context,
+	claim idempotency.Claim,
+	response idempotency.Response,
+	ttl time.Duration,
+) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status_code=$5, headers=$6, body=$7, expires_at=CURRENT_TIMESTAMP + make_interval(secs => $8)
+		WHERE user_id=$1 AND key=$2 AND fingerprint=$3 AND created_at=$4 AND status_code IS NULL
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	_, err := r.client.Exec(
+		ctx,
+		query,
+		claim.UserID,
+		claim.Key,
+		claim.Fingerprint,
+		claim.ClaimedAt,
+		response.StatusCode,
+		response.Header,
+		response.Body,
+		ttl.Seconds(),
+	)
+
+	return err
+}
+
+// Release drops an unfinished key, so the request can be retried.
+// Like Complete, it only touches the key while the claim is still held.
+func (r *repository) Release(ctx context.Context, claim idempotency.Claim) error {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE user_id=$1 AND key=$2 AND fingerprint=$3 AND created_at=$4 AND status_code IS NULL
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	_, err := r.client.Exec(ctx, query, claim.UserID, claim.Key, claim.Fingerprint, claim.ClaimedAt)
+
+	return err
+}
+
+// PurgeExpired deletes expired keys and returns how many were deleted.
+func (r *repository) PurgeExpired(ctx context.Context) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at < CURRENT_TIMESTAMP`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := r.client.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
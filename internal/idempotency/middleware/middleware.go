@@ -0,0 +1,195 @@
+package idempotencymiddleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	jwtmiddleware "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt/middleware"
+	"github.com/xw1nchester/kushfinds-backend/internal/idempotency"
+	idempotencydb "github.com/xw1nchester/kushfinds-backend/internal/idempotency/db"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"go.uber.org/zap"
+)
+
+const (
+	HeaderKey      = "Idempotency-Key"
+	HeaderReplayed = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+)
+
+var (
+	ErrInvalidKey = apperror.NewAppError(
+		"idempotency.invalid_key",
+		"Idempotency-Key should be at most 255 characters",
+	)
+	ErrKeyReused = apperror.New(
+		http.StatusUnprocessableEntity,
+		"idempotency.key_reused",
+		"Idempotency-Key was already used with a different request",
+	)
+	ErrRequestInProgress = apperror.NewConflictError(
+		"idempotency.request_in_progress",
+		"a request with this Idempotency-Key is still being processed",
+	)
+)
+
+type Store interface {
+	Claim(ctx context.Context, userID int, key, fingerprint string, lease time.Duration) (*idempotency.Claim, error)
+	Get(ctx context.Context, userID int, key string) (*idempotency.Record, error)
+	Complete(ctx context.Context, claim idempotency.Claim, response idempotency.Response, ttl time.Duration) error
+	Release(ctx context.Context, claim idempotency.Claim) error
+}
+
+// NewMiddleware makes requests carrying an Idempotency-Key safe to retry.
+// The first request claims the key for lease and its response is stored for ttl,
+// retries with the same method, path and body get that response replayed.
+// Server errors are not stored, so the request can be retried for real.
+// The key of a request that never completed is freed when the lease ends,
+// lease has to be longer than any request takes: a request outliving its lease
+// can no longer store its response once another one has taken the key over.
+// It has to run after the auth middleware, keys are scoped by user.
+func NewMiddleware(logger *zap.Logger, store Store, ttl, lease time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(HeaderKey)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxKeyLength {
+				apperror.Write(w, r, ErrInvalidKey)
+				return
+			}
+
+			userID, ok := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+			if !ok {
+				apperror.Write(w, r, apperror.ErrUnauthorized)
+				return
+			}
+
+			log := logging.FromContext(r.Context(), logger)
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				apperror.Write(w, r, apperror.ErrDecodeBody)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			fingerprint := fingerprint(r, body)
+
+			claim, err := store.Claim(r.Context(), userID, key, fingerprint, lease)
+			if err != nil {
+				if errors.Is(err, idempotencydb.ErrKeyClaimed) {
+					replay(w, r, log, store, userID, key, fingerprint)
+					return
+				}
+
+				log.Error("unexpected error when claiming idempotency key", zap.Error(err))
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			// the response has to be saved even if the client has gone away
+			storeCtx := context.WithoutCancel(r.Context())
+
+			completed := false
+			defer func() {
+				if completed {
+					return
+				}
+
+				if err := store.Release(storeCtx, *claim); err != nil {
+					log.Error("unexpected error when releasing idempotency key", zap.Error(err))
+				}
+			}()
+
+			var buf bytes.Buffer
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(&buf)
+
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			if status >= http.StatusInternalServerError {
+				return
+			}
+
+			if err := store.Complete(storeCtx, *claim, idempotency.Response{
+				StatusCode: status,
+				Header:     ww.Header().Clone(),
+				Body:       buf.Bytes(),
+			}, ttl); err != nil {
+				log.Error("unexpected error when saving idempotent response", zap.Error(err))
+				return
+			}
+
+			completed = true
+		})
+	}
+}
+
+func replay(
+	w http.ResponseWriter,
+	r *http.Request,
+	log *zap.Logger,
+	store Store,
+	userID int,
+	key string,
+	fingerprint string,
+) {
+	record, err := store.Get(r.Context(), userID, key)
+	if err != nil {
+		// released by a failed first request or expired right after the claim
+		if errors.Is(err, idempotencydb.ErrKeyNotFound) {
+			apperror.Write(w, r, ErrRequestInProgress)
+			return
+		}
+
+		log.Error("unexpected error when fetching idempotency key", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if record.Fingerprint != fingerprint {
+		apperror.Write(w, r, ErrKeyReused)
+		return
+	}
+
+	if record.StatusCode == nil {
+		apperror.Write(w, r, ErrRequestInProgress)
+		return
+	}
+
+	for name, values := range record.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(HeaderReplayed, "true")
+	w.WriteHeader(*record.StatusCode)
+	w.Write(record.Body)
+}
+
+func fingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(r.URL.Path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,359 @@
+package idempotencymiddleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	jwtmiddleware "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt/middleware"
+	"github.com/xw1nchester/kushfinds-backend/internal/idempotency"
+	idempotencydb "github.com/xw1nchester/kushfinds-backend/internal/idempotency/db"
+	"go.uber.org/zap"
+)
+
+type storeKey struct {
+	userID int
+	key    string
+}
+
+// memoryStore mimics the claim semantics of the PostgreSQL repository.
+type memoryStore struct {
+	mu        sync.Mutex
+	records   map[storeKey]*idempotency.Record
+	claimedAt map[storeKey]time.Time
+	expiresAt map[storeKey]time.Time
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		records:   make(map[storeKey]*idempotency.Record),
+		claimedAt: make(map[storeKey]time.Time),
+		expiresAt: make(map[storeKey]time.Time),
+	}
+}
+
+func (s *memoryStore) Claim(
+	ctx context.Context,
+	userID int,
+	key string,
+	fingerprint string,
+	lease time.Duration,
+) (*idempotency.Claim, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if expiresAt, ok := s.expiresAt[storeKey{userID, key}]; ok && !expiresAt.Before(time.Now()) {
+		return nil, idempotencydb.ErrKeyClaimed
+	}
+
+	claim := idempotency.Claim{
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		ClaimedAt:   time.Now(),
+	}
+
+	s.records[storeKey{userID, key}] = &idempotency.Record{Fingerprint: fingerprint}
+	s.claimedAt[storeKey{userID, key}] = claim.ClaimedAt
+	s.expiresAt[storeKey{userID, key}] = time.Now().Add(lease)
+
+	return &claim, nil
+}
+
+func (s *memoryStore) Get(ctx context.Context, userID int, key string) (*idempotency.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[storeKey{userID, key}]
+	if !ok {
+		return nil, idempotencydb.ErrKeyNotFound
+	}
+
+	copied := *record
+
+	return &copied, nil
+}
+
+// held reports whether the claim still holds an unfinished key.
+func (s *memoryStore) held(claim idempotency.Claim) bool {
+	record, ok := s.records[storeKey{claim.UserID, claim.Key}]
+
+	return ok &&
+		record.StatusCode == nil &&
+		record.Fingerprint == claim.Fingerprint &&
+		s.claimedAt[storeKey{claim.UserID, claim.Key}].Equal(claim.ClaimedAt)
+}
+
+func (s *memoryStore) Complete(
+	ctx context.Context,
+	claim idempotency.Claim,
+	response idempotency.Response,
+	ttl time.Duration,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.held(claim) {
+		return nil
+	}
+
+	s.expiresAt[storeKey{claim.UserID, claim.Key}] = time.Now().Add(ttl)
+
+	record := s.records[storeKey{claim.UserID, claim.Key}]
+	record.StatusCode = &response.StatusCode
+	record.Header = response.Header
+	record.Body = response.Body
+
+	return nil
+}
+
+func (s *memoryStore) Release(ctx context.Context, claim idempotency.Claim) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.held(claim) {
+		delete(s.records, storeKey{claim.UserID, claim.Key})
+		delete(s.claimedAt, storeKey{claim.UserID, claim.Key})
+		delete(s.expiresAt, storeKey{claim.UserID, claim.Key})
+	}
+
+	return nil
+}
+
+func newRequest(userID int, key, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/me/brands", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(HeaderKey, key)
+	}
+
+	return req.WithContext(context.WithValue(req.Context(), jwtmiddleware.UserIDContextKey{}, userID))
+}
+
+func errorCode(t *testing.T, rec *httptest.ResponseRecorder) string {
+	var body apperror.AppError
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body.Code
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	var calls atomic.Int32
+	created := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id":%d}`, n)
+	})
+
+	t.Run("Without key every request is handled", func(t *testing.T) {
+		calls.Store(0)
+		h := NewMiddleware(zap.NewNop(), newMemoryStore(), time.Hour, time.Minute)(created)
+
+		h.ServeHTTP(httptest.NewRecorder(), newRequest(1, "", `{}`))
+		h.ServeHTTP(httptest.NewRecorder(), newRequest(1, "", `{}`))
+
+		assert.EqualValues(t, 2, calls.Load())
+	})
+
+	t.Run("Retry replays the stored response", func(t *testing.T) {
+		calls.Store(0)
+		h := NewMiddleware(zap.NewNop(), newMemoryStore(), time.Hour, time.Minute)(created)
+
+		first := httptest.NewRecorder()
+		h.ServeHTTP(first, newRequest(1, "key-1", `{"name":"brand"}`))
+
+		retry := httptest.NewRecorder()
+		h.ServeHTTP(retry, newRequest(1, "key-1", `{"name":"brand"}`))
+
+		assert.EqualValues(t, 1, calls.Load())
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+		assert.Equal(t, "application/json", retry.Header().Get("Content-Type"))
+		assert.Equal(t, "true", retry.Header().Get(HeaderReplayed))
+		assert.Empty(t, first.Header().Get(HeaderReplayed))
+	})
+
+	t.Run("Same key with another body is rejected", func(t *testing.T) {
+		calls.Store(0)
+		h := NewMiddleware(zap.NewNop(), newMemoryStore(), time.Hour, time.Minute)(created)
+
+		h.ServeHTTP(httptest.NewRecorder(), newRequest(1, "key-1", `{"name":"brand"}`))
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, newRequest(1, "key-1", `{"name":"other"}`))
+
+		assert.EqualValues(t, 1, calls.Load())
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, "idempotency.key_reused", errorCode(t, rec))
+	})
+
+	t.Run("Keys are scoped by user", func(t *testing.T) {
+		calls.Store(0)
+		h := NewMiddleware(zap.NewNop(), newMemoryStore(), time.Hour, time.Minute)(created)
+
+		h.ServeHTTP(httptest.NewRecorder(), newRequest(1, "key-1", `{}`))
+		h.ServeHTTP(httptest.NewRecorder(), newRequest(2, "key-1", `{}`))
+
+		assert.EqualValues(t, 2, calls.Load())
+	})
+
+	t.Run("Server errors are not stored", func(t *testing.T) {
+		var failed atomic.Bool
+		h := NewMiddleware(zap.NewNop(), newMemoryStore(), time.Hour, time.Minute)(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if failed.CompareAndSwap(false, true) {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+			}),
+		)
+
+		first := httptest.NewRecorder()
+		h.ServeHTTP(first, newRequest(1, "key-1", `{}`))
+
+		retry := httptest.NewRecorder()
+		h.ServeHTTP(retry, newRequest(1, "key-1", `{}`))
+
+		assert.Equal(t, http.StatusInternalServerError, first.Code)
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Empty(t, retry.Header().Get(HeaderReplayed))
+	})
+
+	t.Run("Key of an unfinished request is taken over after the lease", func(t *testing.T) {
+		calls.Store(0)
+		store := newMemoryStore()
+		h := NewMiddleware(zap.NewNop(), store, time.Hour, time.Minute)(created)
+
+		body := []byte(`{}`)
+		fp := fingerprint(newRequest(1, "", string(body)), body)
+
+		_, err := store.Claim(context.Background(), 1, "live", fp, time.Minute)
+		require.NoError(t, err)
+		_, err = store.Claim(context.Background(), 1, "expired", fp, -time.Second)
+		require.NoError(t, err)
+
+		live := httptest.NewRecorder()
+		h.ServeHTTP(live, newRequest(1, "live", `{}`))
+
+		expired := httptest.NewRecorder()
+		h.ServeHTTP(expired, newRequest(1, "expired", `{}`))
+
+		assert.EqualValues(t, 1, calls.Load())
+		assert.Equal(t, http.StatusConflict, live.Code)
+		assert.Equal(t, http.StatusCreated, expired.Code)
+	})
+
+	t.Run("Request outliving its lease does not overwrite the new holder", func(t *testing.T) {
+		calls.Store(0)
+		entered := []chan struct{}{make(chan struct{}), make(chan struct{})}
+		release := []chan struct{}{make(chan struct{}), make(chan struct{})}
+		h := NewMiddleware(zap.NewNop(), newMemoryStore(), time.Hour, 10*time.Millisecond)(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := calls.Add(1)
+				close(entered[n-1])
+				<-release[n-1]
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintf(w, `{"id":%d}`, n)
+			}),
+		)
+
+		serve := func(rec *httptest.ResponseRecorder) chan struct{} {
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				h.ServeHTTP(rec, newRequest(1, "key-1", `{}`))
+			}()
+			return done
+		}
+
+		staleDone := serve(httptest.NewRecorder())
+		<-entered[0]
+		time.Sleep(20 * time.Millisecond)
+
+		holder := httptest.NewRecorder()
+		holderDone := serve(holder)
+		<-entered[1]
+
+		// the stale request finishes while the new holder is still running
+		close(release[0])
+		<-staleDone
+		close(release[1])
+		<-holderDone
+
+		retry := httptest.NewRecorder()
+		h.ServeHTTP(retry, newRequest(1, "key-1", `{}`))
+
+		assert.EqualValues(t, 2, calls.Load())
+		assert.Equal(t, `{"id":2}`, holder.Body.String())
+		assert.Equal(t, `{"id":2}`, retry.Body.String())
+		assert.Equal(t, "true", retry.Header().Get(HeaderReplayed))
+	})
+
+	t.Run("Too long key", func(t *testing.T) {
+		h := NewMiddleware(zap.NewNop(), newMemoryStore(), time.Hour, time.Minute)(created)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, newRequest(1, strings.Repeat("k", maxKeyLength+1), `{}`))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "idempotency.invalid_key", errorCode(t, rec))
+	})
+}
+
+func TestIdempotencyMiddlewareConcurrent(t *testing.T) {
+	const requests = 10
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	h := NewMiddleware(zap.NewNop(), newMemoryStore(), time.Hour, time.Minute)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			<-release
+			w.WriteHeader(http.StatusCreated)
+		}),
+	)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		statuses = make(map[int]int)
+	)
+
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, newRequest(1, "key-1", `{}`))
+
+			mu.Lock()
+			statuses[rec.Code]++
+			mu.Unlock()
+		}()
+	}
+
+	// every request but the one holding the key finishes on its own
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return statuses[http.StatusConflict] == requests-1
+	}, time.Second, time.Millisecond)
+
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, calls.Load())
+	assert.Equal(t, map[int]int{http.StatusCreated: 1, http.StatusConflict: requests - 1}, statuses)
+}
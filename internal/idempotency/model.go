@@ -0,0 +1,31 @@
+package idempotency
+
+import (
+	"net/http"
+	"time"
+)
+
+// Record is a stored request. StatusCode is nil while the first
+// request with the key is still being handled.
+type Record struct {
+	Fingerprint string
+	StatusCode  *int
+	Header      http.Header
+	Body        []byte
+}
+
+// Response is what gets replayed to retries of a completed request.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Claim identifies the request holding a key. Only the holder may complete
+// or release the key, a claim taken over after its lease ended no longer matches.
+type Claim struct {
+	UserID      int
+	Key         string
+	Fingerprint string
+	ClaimedAt   time.Time
+}
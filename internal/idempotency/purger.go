@@ -0,0 +1,57 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"go.uber.org/zap"
+)
+
+type Repository interface {
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type purger struct {
+	repository Repository
+	interval   time.Duration
+	logger     *zap.Logger
+}
+
+// NewPurger deletes expired idempotency keys every interval,
+// so that requests do not have to clean up after each other.
+func NewPurger(repository Repository, interval time.Duration, logger *zap.Logger) *purger {
+	return &purger{
+		repository: repository,
+		interval:   interval,
+		logger:     logger,
+	}
+}
+
+// Run purges until ctx is cancelled.
+func (p *purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.Purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *purger) Purge(ctx context.Context) {
+	purged, err := p.repository.PurgeExpired(ctx)
+	if err != nil {
+		logging.FromContext(ctx, p.logger).Error("unexpected error when purging idempotency keys", zap.Error(err))
+		return
+	}
+
+	if purged > 0 {
+		logging.FromContext(ctx, p.logger).Info("expired idempotency keys purged", zap.Int64("count", purged))
+	}
+}
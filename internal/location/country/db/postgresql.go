@@ -25,9 +25,9 @@ func New(client *pgxpool.Pool, logger *zap.Logger) *repository {
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]country.Country, error) {
-	query := `SELECT id, name FROM countries`
+	query := `SELECT id, name FROM countries WHERE is_active=true ORDER BY position, id`
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	rows, err := r.client.Query(ctx, query)
 	if err != nil {
@@ -60,10 +60,10 @@ func (r *repository) GetAll(ctx context.Context) ([]country.Country, error) {
 func (r *repository) GetByID(ctx context.Context, id int) (*country.Country, error) {
 	query := `
         SELECT id, name FROM countries
-		WHERE id=$1
+		WHERE id=$1 AND is_active=true
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var country country.Country
 	err := r.client.QueryRow(ctx, query, id).Scan(&country.ID, &country.Name)
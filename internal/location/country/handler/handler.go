@@ -62,7 +62,7 @@ func (h *handler) GetAllHandler(w http.ResponseWriter, r *http.Request) error {
 func (h *handler) GetCountryStatesHandler(w http.ResponseWriter, r *http.Request) error {
 	id, err := strconv.Atoi(chi.URLParam(r, "id"))
 	if err != nil {
-		return apperror.NewAppError("id should be positive integer")
+		return apperror.NewFieldError("id", "field.positive_integer")
 	}
 
 	states, err := h.service.GetCountryStates(r.Context(), id)
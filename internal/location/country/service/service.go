@@ -8,6 +8,8 @@ import (
 	"github.com/xw1nchester/kushfinds-backend/internal/location/country"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/country/db"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/state"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/tracing"
 	"go.uber.org/zap"
 )
 
@@ -41,7 +43,7 @@ func New(
 func (s *service) GetAll(ctx context.Context) ([]country.Country, error) {
 	countries, err := s.repository.GetAll(ctx)
 	if err != nil {
-		s.logger.Error("unexpected error when fetching all countries", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching all countries", zap.Error(err))
 
 		return nil, err
 	}
@@ -50,13 +52,16 @@ func (s *service) GetAll(ctx context.Context) ([]country.Country, error) {
 }
 
 func (s *service) GetByID(ctx context.Context, id int) (*country.Country, error) {
+	ctx, span := tracing.Start(ctx, "countryservice.GetByID")
+	defer span.End()
+
 	existingCountry, err := s.repository.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, db.ErrCountryNotFound) {
 			return nil, apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when fetching country by id", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching country by id", zap.Error(err))
 
 		return nil, err
 	}
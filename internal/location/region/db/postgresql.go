@@ -31,10 +31,10 @@ func (r *repository) GetAll(ctx context.Context) ([]region.Region, error) {
 func (r *repository) GetByID(ctx context.Context, id int) (*region.Region, error) {
 	query := `
         SELECT id, name FROM regions
-		WHERE id=$1
+		WHERE id=$1 AND is_active=true
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var region region.Region
 	err := r.client.QueryRow(ctx, query, id).Scan(&region.ID, &region.Name)
@@ -50,9 +50,9 @@ func (r *repository) GetByID(ctx context.Context, id int) (*region.Region, error
 
 // TODO: в дальнейшем реализовать GetAll, который может принимать фильтры
 func (r *repository) GetAllByStateID(ctx context.Context, stateID int) ([]region.Region, error) {
-	query := `SELECT id, name FROM regions WHERE state_id=$1`
+	query := `SELECT id, name FROM regions WHERE state_id=$1 AND is_active=true ORDER BY position, id`
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	rows, err := r.client.Query(ctx, query, stateID)
 	if err != nil {
@@ -90,10 +90,11 @@ func (r *repository) CheckLocationExists(ctx context.Context, regionID, stateID,
 		JOIN countries c ON s.country_id = c.id
 		WHERE r.id = $1
 		AND s.id = $2
-		AND c.id = $3;
+		AND c.id = $3
+		AND r.is_active AND s.is_active AND c.is_active;
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var id int
 	err := r.client.QueryRow(ctx, query, regionID, stateID, countryID).Scan(&id)
@@ -7,6 +7,8 @@ import (
 	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/region"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/region/db"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/tracing"
 	"go.uber.org/zap"
 )
 
@@ -39,7 +41,7 @@ func (s *service) GetByID(ctx context.Context, id int) (*region.Region, error) {
 			return nil, apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when fetching region by id", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching region by id", zap.Error(err))
 
 		return nil, err
 	}
@@ -53,7 +55,7 @@ func (s *service) GetByID(ctx context.Context, id int) (*region.Region, error) {
 func (s *service) GetAllByStateID(ctx context.Context, id int) ([]region.Region, error) {
 	regions, err := s.repository.GetAllByStateID(ctx, id)
 	if err != nil {
-		s.logger.Error("unexpected error when fetching regions by state id", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching regions by state id", zap.Error(err))
 
 		return nil, err
 	}
@@ -62,13 +64,16 @@ func (s *service) GetAllByStateID(ctx context.Context, id int) ([]region.Region,
 }
 
 func (s *service) CheckLocationExists(ctx context.Context, regionID, stateID, countryID int) error {
+	ctx, span := tracing.Start(ctx, "regionservice.CheckLocationExists")
+	defer span.End()
+
 	err := s.repository.CheckLocationExists(ctx, regionID, stateID, countryID)
 	if err != nil {
 		if errors.Is(err, db.ErrLocationNotFound) {
 			return apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when checking location exists", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when checking location exists", zap.Error(err))
 	}
 
 	return err
@@ -31,14 +31,14 @@ func (r *repository) GetAll(ctx context.Context) ([]state.State, error) {
 
 func (r *repository) GetByID(ctx context.Context, id int) (*state.State, error) {
 	query := `
-        SELECT id, name FROM states
-		WHERE id=$1
+        SELECT id, name, minimum_age FROM states
+		WHERE id=$1 AND is_active=true
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var state state.State
-	err := r.client.QueryRow(ctx, query, id).Scan(&state.ID, &state.Name)
+	err := r.client.QueryRow(ctx, query, id).Scan(&state.ID, &state.Name, &state.MinimumAge)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrStateNotFound
@@ -51,9 +51,9 @@ func (r *repository) GetByID(ctx context.Context, id int) (*state.State, error)
 
 // TODO: в дальнейшем реализовать GetAll, который может принимать фильтры
 func (r *repository) GetAllByCountryID(ctx context.Context, countryID int) ([]state.State, error) {
-	query := `SELECT id, name FROM states WHERE country_id=$1`
+	query := `SELECT id, name, minimum_age FROM states WHERE country_id=$1 AND is_active=true ORDER BY position, id`
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	rows, err := r.client.Query(ctx, query, countryID)
 	if err != nil {
@@ -68,6 +68,7 @@ func (r *repository) GetAllByCountryID(ctx context.Context, countryID int) ([]st
 		err := rows.Scan(
 			&state.ID,
 			&state.Name,
+			&state.MinimumAge,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %v", err)
@@ -96,11 +97,11 @@ func (r *repository) CheckStatesExist(ctx context.Context, stateIDs []int) error
 	}
 
 	query := fmt.Sprintf(
-		`SELECT COUNT(id) FROM states WHERE id IN (%s)`,
+		`SELECT COUNT(id) FROM states WHERE is_active=true AND id IN (%s)`,
 		strings.Join(placeholders, ", "),
 	)
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var count int
 	err := r.client.QueryRow(ctx, query, args...).Scan(&count)
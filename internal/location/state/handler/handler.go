@@ -7,7 +7,6 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
-	"github.com/go-playground/validator/v10"
 	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
 	"github.com/xw1nchester/kushfinds-backend/internal/handlers"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/region"
@@ -15,7 +14,7 @@ import (
 	"go.uber.org/zap"
 )
 
-var validate = validator.New()
+var validate = apperror.NewValidator()
 
 type Service interface {
 	GetStateRegions(ctx context.Context, id int) ([]region.Region, error)
@@ -47,7 +46,7 @@ func (h *handler) Register(router chi.Router) {
 func (h *handler) GetStateRegionsHandler(w http.ResponseWriter, r *http.Request) error {
 	id, err := strconv.Atoi(chi.URLParam(r, "id"))
 	if err != nil {
-		return apperror.NewAppError("id should be positive integer")
+		return apperror.NewFieldError("id", "field.positive_integer")
 	}
 
 	regions, err := h.service.GetStateRegions(r.Context(), id)
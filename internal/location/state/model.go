@@ -1,6 +1,7 @@
 package state
 
 type State struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	MinimumAge int    `json:"minimumAge,omitempty"`
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/xw1nchester/kushfinds-backend/internal/location/region"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/state"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/state/db"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/tracing"
 	"go.uber.org/zap"
 )
 
@@ -47,7 +49,7 @@ func (s *service) GetByID(ctx context.Context, id int) (*state.State, error) {
 			return nil, apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when fetching state by id", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching state by id", zap.Error(err))
 
 		return nil, err
 	}
@@ -62,7 +64,7 @@ func (s *service) GetByID(ctx context.Context, id int) (*state.State, error) {
 func (s *service) GetAllByCountryID(ctx context.Context, id int) ([]state.State, error) {
 	states, err := s.repository.GetAllByCountryID(ctx, id)
 	if err != nil {
-		s.logger.Error("unexpected error when fetching states by country id", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching states by country id", zap.Error(err))
 
 		return nil, err
 	}
@@ -75,13 +77,16 @@ func (s *service) GetStateRegions(ctx context.Context, id int) ([]region.Region,
 }
 
 func (s *service) CheckStatesExist(ctx context.Context, stateIDs []int) error {
+	ctx, span := tracing.Start(ctx, "stateservice.CheckStatesExist")
+	defer span.End()
+
 	err := s.repository.CheckStatesExist(ctx, stateIDs)
 	if err != nil {
 		if errors.Is(err, db.ErrStateNotFound) {
 			return apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when check states exists", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when check states exists", zap.Error(err))
 	}
 
 	return err
@@ -0,0 +1,44 @@
+package logging
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type loggerKey struct{}
+
+type requestInfoKey struct{}
+
+// requestInfo collects details that become known deeper in the handler
+// chain than the access log middleware, such as the authenticated user.
+type requestInfo struct {
+	userID int
+}
+
+func WithLogger(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the request-scoped logger, or fallback when ctx
+// does not belong to an HTTP request.
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		return logger
+	}
+	return fallback
+}
+
+// WithUserID tags the request logger and the access log entry with the
+// authenticated user.
+func WithUserID(ctx context.Context, userID int) context.Context {
+	if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok {
+		info.userID = userID
+	}
+
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		ctx = WithLogger(ctx, logger.With(zap.Int("user_id", userID)))
+	}
+
+	return ctx
+}
@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Middleware puts a logger tagged with the chi request ID into the request
+// context and writes an access log entry once the response is sent.
+// It expects chi's RequestID middleware to run before it; when a span is
+// already active, its trace ID is attached as well.
+func Middleware(logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			requestLogger := logger.With(zap.String("request_id", middleware.GetReqID(r.Context())))
+			if sc := trace.SpanContextFromContext(r.Context()); sc.IsValid() {
+				requestLogger = requestLogger.With(zap.String("trace_id", sc.TraceID().String()))
+			}
+			info := &requestInfo{}
+
+			ctx := context.WithValue(r.Context(), requestInfoKey{}, info)
+			ctx = WithLogger(ctx, requestLogger)
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			fields := []zap.Field{
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.String("remote", r.RemoteAddr),
+				zap.Int("status", status),
+				zap.Int("size", ww.BytesWritten()),
+				zap.Duration("duration", time.Since(start)),
+			}
+
+			if info.userID != 0 {
+				fields = append(fields, zap.Int("user_id", info.userID))
+			}
+
+			requestLogger.Info("request", fields...)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         int
+		handler        http.HandlerFunc
+		expectedStatus int64
+		expectedSize   int64
+	}{
+		{
+			name: "Anonymous request",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("pong"))
+			},
+			expectedStatus: http.StatusOK,
+			expectedSize:   4,
+		},
+		{
+			name:   "Authenticated request",
+			userID: 7,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Empty response",
+			handler:        func(w http.ResponseWriter, r *http.Request) {},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+
+			var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx := r.Context()
+				if tt.userID != 0 {
+					ctx = WithUserID(ctx, tt.userID)
+				}
+
+				LogSQLQuery(ctx, zap.NewNop(), "SELECT  1")
+
+				tt.handler(w, r.WithContext(ctx))
+			})
+			handler = middleware.RequestID(Middleware(zap.New(core))(handler))
+
+			req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			req.Header.Set(middleware.RequestIDHeader, "req-1")
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			entries := logs.All()
+			if !assert.Len(t, entries, 2) {
+				return
+			}
+
+			query := entries[0].ContextMap()
+			assert.Equal(t, "SELECT 1", entries[0].Message)
+			assert.Equal(t, "req-1", query["request_id"])
+
+			access := entries[1].ContextMap()
+			assert.Equal(t, "request", entries[1].Message)
+			assert.Equal(t, "req-1", access["request_id"])
+			assert.Equal(t, tt.expectedStatus, access["status"])
+			assert.Equal(t, tt.expectedSize, access["size"])
+
+			if tt.userID != 0 {
+				assert.Equal(t, int64(tt.userID), query["user_id"])
+				assert.Equal(t, int64(tt.userID), access["user_id"])
+			} else {
+				assert.NotContains(t, query, "user_id")
+				assert.NotContains(t, access, "user_id")
+			}
+		})
+	}
+}
+
+func TestFromContextFallback(t *testing.T) {
+	fallback := zap.NewNop()
+
+	assert.Same(t, fallback, FromContext(context.Background(), fallback))
+	assert.Equal(t, context.Background(), WithUserID(context.Background(), 1))
+}
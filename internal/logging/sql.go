@@ -1,11 +1,14 @@
 package logging
 
 import (
+	"context"
 	"strings"
 
 	"go.uber.org/zap"
 )
 
-func LogSQLQuery(logger *zap.Logger, sql string) {
-	logger.Debug(strings.Join(strings.Fields(sql), " "))
+// LogSQLQuery logs the query with the request-scoped logger when ctx has one,
+// so queries can be traced back to the request that issued them.
+func LogSQLQuery(ctx context.Context, logger *zap.Logger, sql string) {
+	FromContext(ctx, logger).Debug(strings.Join(strings.Fields(sql), " "))
 }
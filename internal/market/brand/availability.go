@@ -0,0 +1,45 @@
+package brand
+
+// AvailabilityRow is a single store of a brand together with its location.
+// Region and Store are nil for a distribution state without stores.
+type AvailabilityRow struct {
+	StateID       int
+	StateName     string
+	IsDistributed bool
+	Region        *RegionAvailability
+	Store         *AvailableStore
+}
+
+// GroupAvailability folds rows ordered by state and region into a tree.
+func GroupAvailability(rows []AvailabilityRow) []StateAvailability {
+	states := make([]StateAvailability, 0)
+
+	for _, row := range rows {
+		if len(states) == 0 || states[len(states)-1].ID != row.StateID {
+			states = append(states, StateAvailability{
+				ID:            row.StateID,
+				Name:          row.StateName,
+				IsDistributed: row.IsDistributed,
+				Regions:       make([]RegionAvailability, 0),
+			})
+		}
+
+		if row.Region == nil || row.Store == nil {
+			continue
+		}
+
+		st := &states[len(states)-1]
+		if len(st.Regions) == 0 || st.Regions[len(st.Regions)-1].ID != row.Region.ID {
+			st.Regions = append(st.Regions, RegionAvailability{
+				ID:     row.Region.ID,
+				Name:   row.Region.Name,
+				Stores: make([]AvailableStore, 0),
+			})
+		}
+
+		region := &st.Regions[len(st.Regions)-1]
+		region.Stores = append(region.Stores, *row.Store)
+	}
+
+	return states
+}
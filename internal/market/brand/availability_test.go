@@ -0,0 +1,42 @@
+package brand
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupAvailability(t *testing.T) {
+	north := &RegionAvailability{ID: 1, Name: "North"}
+	south := &RegionAvailability{ID: 2, Name: "South"}
+	coast := &RegionAvailability{ID: 3, Name: "Coast"}
+
+	rows := []AvailabilityRow{
+		{StateID: 1, StateName: "Alaska", IsDistributed: true},
+		{StateID: 2, StateName: "California", IsDistributed: true, Region: north, Store: &AvailableStore{ID: 10, Name: "A"}},
+		{StateID: 2, StateName: "California", IsDistributed: true, Region: north, Store: &AvailableStore{ID: 11, Name: "B"}},
+		{StateID: 2, StateName: "California", IsDistributed: true, Region: south, Store: &AvailableStore{ID: 12, Name: "C"}},
+		{StateID: 3, StateName: "Oregon", Region: coast, Store: &AvailableStore{ID: 13, Name: "D"}},
+	}
+
+	expected := []StateAvailability{
+		{ID: 1, Name: "Alaska", IsDistributed: true, Regions: []RegionAvailability{}},
+		{
+			ID:            2,
+			Name:          "California",
+			IsDistributed: true,
+			Regions: []RegionAvailability{
+				{ID: 1, Name: "North", Stores: []AvailableStore{{ID: 10, Name: "A"}, {ID: 11, Name: "B"}}},
+				{ID: 2, Name: "South", Stores: []AvailableStore{{ID: 12, Name: "C"}}},
+			},
+		},
+		{
+			ID:      3,
+			Name:    "Oregon",
+			Regions: []RegionAvailability{{ID: 3, Name: "Coast", Stores: []AvailableStore{{ID: 13, Name: "D"}}}},
+		},
+	}
+
+	require.Equal(t, expected, GroupAvailability(rows))
+	require.Equal(t, []StateAvailability{}, GroupAvailability(nil))
+}
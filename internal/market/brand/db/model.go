@@ -3,5 +3,6 @@ package db
 import "errors"
 
 var (
-	ErrBrandNotFound = errors.New("brand not found")
+	ErrBrandNotFound        = errors.New("brand not found")
+	ErrBrandVersionMismatch = errors.New("brand version mismatch")
 )
@@ -12,6 +12,7 @@ import (
 	"github.com/xw1nchester/kushfinds-backend/internal/market/brand"
 	marketsection "github.com/xw1nchester/kushfinds-backend/internal/market/section"
 	"github.com/xw1nchester/kushfinds-backend/internal/market/social"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor/postgresql"
 	"go.uber.org/zap"
 )
 
@@ -45,7 +46,7 @@ func (r *repository) CheckBrandNameIsAvailable(
 		args = append(args, excludeID[0])
 	}
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var id int
 	err := r.client.QueryRow(ctx, query, args...).Scan(&id)
@@ -61,12 +62,14 @@ func (r *repository) CheckBrandNameIsAvailable(
 
 func (r *repository) GetUserBrands(ctx context.Context, userID int) ([]brand.BrandSummary, error) {
 	query := `
-		SELECT id, name, logo 
-		FROM brands
-		WHERE user_id=$1
+		SELECT b.id, b.name, b.logo
+		FROM brands b
+		JOIN team_members tm ON tm.business_id = b.user_id
+		WHERE tm.user_id=$1
+		ORDER BY b.id
 	`
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	rows, err := r.client.Query(ctx, query, userID)
 	if err != nil {
@@ -97,7 +100,7 @@ func (r *repository) GetUserBrands(ctx context.Context, userID int) ([]brand.Bra
 	return brands, nil
 }
 
-func (r *repository) GetUserBrand(ctx context.Context, brandID, userID int) (*brand.Brand, error) {
+func (r *repository) getBrand(ctx context.Context, condition string, args ...any) (*brand.Brand, error) {
 	query := `
 		SELECT
 			b.id,
@@ -111,19 +114,29 @@ func (r *repository) GetUserBrand(ctx context.Context, brandID, userID int) (*br
 			b.phone_number,
 			b.logo,
 			b.banner,
+			b.followers_count,
+			ROUND(COALESCE(b.rating_sum::numeric / NULLIF(b.reviews_count, 0), 0), 2)::float8,
+			b.reviews_count,
 			b.is_published,
+			b.moderation_status,
+			b.moderation_comment,
+			b.submitted_at,
+			b.reviewed_at,
+			b.version,
 			b.created_at,
 			b.updated_at
 		FROM brands b
 		LEFT JOIN countries c ON b.country_id = c.id
 		LEFT JOIN market_sections ms ON b.market_section_id = ms.id
-		WHERE b.id=$1 AND b.user_id=$2
+		WHERE ` + condition + `
 	`
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	executor := postgresql.GetExecutor(ctx, r.client)
 
 	var br brand.Brand
-	if err := r.client.QueryRow(ctx, query, brandID, userID).Scan(
+	if err := executor.QueryRow(ctx, query, args...).Scan(
 		&br.ID,
 		&br.UserID,
 		&br.Country.ID,
@@ -135,7 +148,15 @@ func (r *repository) GetUserBrand(ctx context.Context, brandID, userID int) (*br
 		&br.PhoneNumber,
 		&br.Logo,
 		&br.Banner,
+		&br.FollowersCount,
+		&br.Rating,
+		&br.ReviewsCount,
 		&br.IsPublished,
+		&br.ModerationStatus,
+		&br.ModerationComment,
+		&br.SubmittedAt,
+		&br.ReviewedAt,
+		&br.Version,
 		&br.CreatedAt,
 		&br.UpdatedAt,
 	); err != nil {
@@ -145,6 +166,8 @@ func (r *repository) GetUserBrand(ctx context.Context, brandID, userID int) (*br
 		return nil, err
 	}
 
+	brandID := br.ID
+
 	statesQuery := `
 		SELECT s.id, s.name
 		FROM brands_states bs
@@ -152,9 +175,9 @@ func (r *repository) GetUserBrand(ctx context.Context, brandID, userID int) (*br
 		WHERE bs.brand_id = $1
 	`
 
-	logging.LogSQLQuery(r.logger, statesQuery)
+	logging.LogSQLQuery(ctx, r.logger, statesQuery)
 
-	rows, err := r.client.Query(ctx, statesQuery, brandID)
+	rows, err := executor.Query(ctx, statesQuery, brandID)
 	if err != nil {
 		return nil, err
 	}
@@ -179,9 +202,9 @@ func (r *repository) GetUserBrand(ctx context.Context, brandID, userID int) (*br
 		WHERE bmss.brand_id = $1
 	`
 
-	logging.LogSQLQuery(r.logger, mssQuery)
+	logging.LogSQLQuery(ctx, r.logger, mssQuery)
 
-	rows, err = r.client.Query(ctx, mssQuery, brandID)
+	rows, err = executor.Query(ctx, mssQuery, brandID)
 	if err != nil {
 		return nil, err
 	}
@@ -205,9 +228,9 @@ func (r *repository) GetUserBrand(ctx context.Context, brandID, userID int) (*br
 		WHERE brand_id = $1
 	`
 
-	logging.LogSQLQuery(r.logger, docsQuery)
+	logging.LogSQLQuery(ctx, r.logger, docsQuery)
 
-	rows, err = r.client.Query(ctx, docsQuery, brandID)
+	rows, err = executor.Query(ctx, docsQuery, brandID)
 	if err != nil {
 		return nil, err
 	}
@@ -232,9 +255,9 @@ func (r *repository) GetUserBrand(ctx context.Context, brandID, userID int) (*br
 		WHERE bs.brand_id = $1
 	`
 
-	logging.LogSQLQuery(r.logger, socialsQuery)
+	logging.LogSQLQuery(ctx, r.logger, socialsQuery)
 
-	rows, err = r.client.Query(ctx, socialsQuery, brandID)
+	rows, err = executor.Query(ctx, socialsQuery, brandID)
 	if err != nil {
 		return nil, err
 	}
@@ -260,6 +283,58 @@ func (r *repository) GetUserBrand(ctx context.Context, brandID, userID int) (*br
 	return &br, nil
 }
 
+func (r *repository) GetUserBrand(ctx context.Context, brandID, userID int) (*brand.Brand, error) {
+	return r.getBrand(ctx, "b.id=$1 AND b.user_id=$2", brandID, userID)
+}
+
+func (r *repository) GetBrandByID(ctx context.Context, brandID int) (*brand.Brand, error) {
+	return r.getBrand(ctx, "b.id=$1", brandID)
+}
+
+func (r *repository) GetPublishedBrand(ctx context.Context, brandID int) (*brand.Brand, error) {
+	return r.getBrand(ctx, "b.id=$1 AND b.is_published=true", brandID)
+}
+
+func (r *repository) GetFollowedBrands(ctx context.Context, userID int) ([]brand.BrandSummary, error) {
+	query := `
+		SELECT b.id, b.name, b.logo
+		FROM brands_followers bf
+		JOIN brands b ON bf.brand_id = b.id
+		WHERE bf.user_id=$1 AND b.is_published=true
+		ORDER BY bf.created_at DESC
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	brands := make([]brand.BrandSummary, 0)
+	for rows.Next() {
+		var brand brand.BrandSummary
+
+		err := rows.Scan(
+			&brand.ID,
+			&brand.Name,
+			&brand.Logo,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+
+		brands = append(brands, brand)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return brands, nil
+}
+
 func (r *repository) createBrandRelatedEntities(
 	ctx context.Context,
 	tx pgx.Tx,
@@ -273,7 +348,7 @@ func (r *repository) createBrandRelatedEntities(
         `
 		batch := &pgx.Batch{}
 		for _, s := range data.States {
-			logging.LogSQLQuery(r.logger, insertStateQuery)
+			logging.LogSQLQuery(ctx, r.logger, insertStateQuery)
 			batch.Queue(insertStateQuery, brandID, s.ID)
 		}
 		br := tx.SendBatch(ctx, batch)
@@ -289,7 +364,7 @@ func (r *repository) createBrandRelatedEntities(
         `
 		batch := &pgx.Batch{}
 		for _, ms := range data.MarketSubSections {
-			logging.LogSQLQuery(r.logger, insertMarketSubSectionQuery)
+			logging.LogSQLQuery(ctx, r.logger, insertMarketSubSectionQuery)
 			batch.Queue(insertMarketSubSectionQuery, brandID, ms.ID)
 		}
 		br := tx.SendBatch(ctx, batch)
@@ -305,7 +380,7 @@ func (r *repository) createBrandRelatedEntities(
         `
 		batch := &pgx.Batch{}
 		for _, url := range data.Documents {
-			logging.LogSQLQuery(r.logger, insertDocsQuery)
+			logging.LogSQLQuery(ctx, r.logger, insertDocsQuery)
 			batch.Queue(insertDocsQuery, brandID, url)
 		}
 		br := tx.SendBatch(ctx, batch)
@@ -321,7 +396,7 @@ func (r *repository) createBrandRelatedEntities(
         `
 		batch := &pgx.Batch{}
 		for _, s := range data.Socials {
-			logging.LogSQLQuery(r.logger, insertSocialsQuery)
+			logging.LogSQLQuery(ctx, r.logger, insertSocialsQuery)
 			batch.Queue(insertSocialsQuery, brandID, s.ID, s.Url)
 		}
 		br := tx.SendBatch(ctx, batch)
@@ -334,7 +409,7 @@ func (r *repository) createBrandRelatedEntities(
 }
 
 func (r *repository) CreateBrand(ctx context.Context, data brand.Brand) (*brand.Brand, error) {
-	tx, err := r.client.Begin(ctx)
+	tx, err := postgresql.Begin(ctx, r.client)
 	if err != nil {
 		return nil, err
 	}
@@ -342,12 +417,12 @@ func (r *repository) CreateBrand(ctx context.Context, data brand.Brand) (*brand.
 	defer tx.Rollback(ctx)
 
 	query := `
-        INSERT INTO brands (user_id, country_id, market_section_id, name, email, phone_number, logo, banner, is_published)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        INSERT INTO brands (user_id, country_id, market_section_id, name, email, phone_number, logo, banner, is_published, moderation_status, submitted_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, CASE WHEN $10='pending' THEN NOW() END)
         RETURNING id
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var brandID int
 	if err = tx.QueryRow(
@@ -362,6 +437,7 @@ func (r *repository) CreateBrand(ctx context.Context, data brand.Brand) (*brand.
 		data.Logo,
 		data.Banner,
 		data.IsPublished,
+		data.ModerationStatus,
 	).Scan(&brandID); err != nil {
 		return nil, err
 	}
@@ -377,28 +453,137 @@ func (r *repository) CreateBrand(ctx context.Context, data brand.Brand) (*brand.
 	return r.GetUserBrand(ctx, brandID, data.UserID)
 }
 
-func (r *repository) CheckBrandExists(ctx context.Context, brandID, userID int) error {
+// GetBrandOwnerID returns the business the brand belongs to.
+func (r *repository) GetBrandOwnerID(ctx context.Context, brandID int) (int, error) {
 	query := `
-        SELECT id FROM brands
-		WHERE id=$1 AND user_id=$2
+        SELECT user_id FROM brands
+		WHERE id=$1
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
-	var id int
-	err := r.client.QueryRow(ctx, query, brandID, userID).Scan(&id)
+	var ownerID int
+	err := postgresql.GetExecutor(ctx, r.client).QueryRow(ctx, query, brandID).Scan(&ownerID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return ErrBrandNotFound
+			return 0, ErrBrandNotFound
 		}
-		return err
+		return 0, err
 	}
 
-	return nil
+	return ownerID, nil
 }
 
-func (r *repository) UpdateBrand(ctx context.Context, data brand.Brand) (*brand.Brand, error) {
-	tx, err := r.client.Begin(ctx)
+// IsBrandAvailableInState reports whether the brand is distributed in the state.
+func (r *repository) IsBrandAvailableInState(ctx context.Context, brandID, stateID int) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM brands_states
+			WHERE brand_id=$1 AND state_id=$2
+		)
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	var exists bool
+	if err := postgresql.GetExecutor(ctx, r.client).QueryRow(ctx, query, brandID, stateID).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// GetBrandAvailability returns the published stores of the brand along with
+// the states it is distributed in, ordered by state, region and store.
+func (r *repository) GetBrandAvailability(ctx context.Context, brandID int) ([]brand.StateAvailability, error) {
+	query := `
+		SELECT
+			st.id,
+			st.name,
+			EXISTS (
+				SELECT 1 FROM brands_states bs
+				WHERE bs.brand_id=$1 AND bs.state_id=st.id
+			),
+			rg.id,
+			rg.name,
+			s.id,
+			s.name,
+			s.banner,
+			s.street,
+			s.house
+		FROM states st
+		LEFT JOIN stores s ON s.state_id = st.id AND s.brand_id=$1 AND s.is_published=true
+		LEFT JOIN regions rg ON s.region_id = rg.id
+		WHERE st.id IN (
+			SELECT state_id FROM brands_states WHERE brand_id=$1
+			UNION
+			SELECT state_id FROM stores WHERE brand_id=$1 AND is_published=true
+		)
+		ORDER BY st.name, st.id, rg.name, rg.id, s.name, s.id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, brandID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	availabilityRows := make([]brand.AvailabilityRow, 0)
+	for rows.Next() {
+		var (
+			row        brand.AvailabilityRow
+			regionID   *int
+			regionName *string
+			storeID    *int
+			storeName  *string
+			banner     *string
+			street     *string
+			house      *string
+		)
+
+		if err := rows.Scan(
+			&row.StateID,
+			&row.StateName,
+			&row.IsDistributed,
+			&regionID,
+			&regionName,
+			&storeID,
+			&storeName,
+			&banner,
+			&street,
+			&house,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+
+		if regionID != nil && storeID != nil {
+			row.Region = &brand.RegionAvailability{ID: *regionID, Name: *regionName}
+			row.Store = &brand.AvailableStore{
+				ID:     *storeID,
+				Name:   *storeName,
+				Banner: *banner,
+				Street: *street,
+				House:  *house,
+			}
+		}
+
+		availabilityRows = append(availabilityRows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return brand.GroupAvailability(availabilityRows), nil
+}
+
+// UpdateBrand overwrites the brand and its related entities. When versions
+// is not nil the brand is updated only if its current version is listed,
+// ErrBrandVersionMismatch is returned otherwise.
+func (r *repository) UpdateBrand(ctx context.Context, data brand.Brand, versions []int) (*brand.Brand, error) {
+	tx, err := postgresql.Begin(ctx, r.client)
 	if err != nil {
 		return nil, err
 	}
@@ -415,11 +600,14 @@ func (r *repository) UpdateBrand(ctx context.Context, data brand.Brand) (*brand.
 			logo=$8,
 			banner=$9,
 			is_published=$10,
+			moderation_status=$11,
+			submitted_at=CASE WHEN $11='pending' AND moderation_status<>'pending' THEN NOW() ELSE submitted_at END,
+			version=version+1,
 			updated_at=NOW()
-        WHERE id=$1 AND user_id=$2
+        WHERE id=$1 AND user_id=$2 AND ($12::int[] IS NULL OR version=ANY($12))
         RETURNING id
     `
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var brandID int
 	if err = tx.QueryRow(
@@ -435,30 +623,38 @@ func (r *repository) UpdateBrand(ctx context.Context, data brand.Brand) (*brand.
 		data.Logo,
 		data.Banner,
 		data.IsPublished,
+		data.ModerationStatus,
+		versions,
 	).Scan(&brandID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if versions != nil {
+				return nil, ErrBrandVersionMismatch
+			}
+			return nil, ErrBrandNotFound
+		}
 		return nil, err
 	}
 
 	deleteStatesQuery := "DELETE FROM brands_states WHERE brand_id=$1"
-	logging.LogSQLQuery(r.logger, deleteStatesQuery)
+	logging.LogSQLQuery(ctx, r.logger, deleteStatesQuery)
 	if _, err = tx.Exec(ctx, deleteStatesQuery, brandID); err != nil {
 		return nil, err
 	}
 
 	deleteMarketSubSectionsQuery := "DELETE FROM brands_market_sub_sections WHERE brand_id=$1"
-	logging.LogSQLQuery(r.logger, deleteMarketSubSectionsQuery)
+	logging.LogSQLQuery(ctx, r.logger, deleteMarketSubSectionsQuery)
 	if _, err = tx.Exec(ctx, deleteMarketSubSectionsQuery, brandID); err != nil {
 		return nil, err
 	}
 
 	deleteDocsQuery := "DELETE FROM brands_documents WHERE brand_id=$1"
-	logging.LogSQLQuery(r.logger, deleteDocsQuery)
+	logging.LogSQLQuery(ctx, r.logger, deleteDocsQuery)
 	if _, err = tx.Exec(ctx, deleteDocsQuery, brandID); err != nil {
 		return nil, err
 	}
 
 	deleteSocialsQuery := "DELETE FROM brands_socials WHERE brand_id=$1"
-	logging.LogSQLQuery(r.logger, deleteSocialsQuery)
+	logging.LogSQLQuery(ctx, r.logger, deleteSocialsQuery)
 	if _, err = tx.Exec(ctx, deleteSocialsQuery, brandID); err != nil {
 		return nil, err
 	}
@@ -474,15 +670,160 @@ func (r *repository) UpdateBrand(ctx context.Context, data brand.Brand) (*brand.
 	return r.GetUserBrand(ctx, brandID, data.UserID)
 }
 
-func (r *repository) DeleteBrand(ctx context.Context, brandID, userID int) error {
+// DeleteBrand deletes the brand, versions work as in UpdateBrand.
+// Store promotions lose the brand as a target, those targeting only it are
+// deactivated instead of turning into store-wide ones. Run it in a transaction.
+func (r *repository) DeleteBrand(ctx context.Context, brandID, userID int, versions []int) error {
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	deactivateQuery := `
+		UPDATE promotions p
+		SET is_active=false, updated_at=NOW()
+		FROM promotions_brands pb
+		WHERE pb.promotion_id = p.id AND pb.brand_id=$1 AND NOT EXISTS (
+			SELECT 1 FROM promotions_brands other
+			WHERE other.promotion_id = p.id AND other.brand_id <> $1
+		)
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, deactivateQuery)
+
+	if _, err := executor.Exec(ctx, deactivateQuery, brandID); err != nil {
+		return err
+	}
+
+	detachQuery := "DELETE FROM promotions_brands WHERE brand_id=$1"
+	logging.LogSQLQuery(ctx, r.logger, detachQuery)
+	if _, err := executor.Exec(ctx, detachQuery, brandID); err != nil {
+		return err
+	}
+
 	query := `
         DELETE FROM brands
-		WHERE id=$1 AND user_id=$2
+		WHERE id=$1 AND user_id=$2 AND ($3::int[] IS NULL OR version=ANY($3))
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
-	_, err := r.client.Exec(ctx, query, brandID, userID)
+	tag, err := executor.Exec(ctx, query, brandID, userID, versions)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		if versions != nil {
+			return ErrBrandVersionMismatch
+		}
+		return ErrBrandNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) SubmitBrand(ctx context.Context, brandID, userID int) (*brand.Brand, error) {
+	query := `
+		UPDATE brands
+		SET moderation_status='pending', submitted_at=NOW(), version=version+1, updated_at=NOW()
+		WHERE id=$1 AND user_id=$2 AND moderation_status IN ('draft', 'rejected')
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := r.client.Exec(ctx, query, brandID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return nil, ErrBrandNotFound
+	}
+
+	return r.GetUserBrand(ctx, brandID, userID)
+}
+
+func (r *repository) GetBrandsForModeration(ctx context.Context, status string) ([]brand.ModerationSummary, error) {
+	query := `
+		SELECT
+			b.id,
+			b.user_id,
+			b.name,
+			b.logo,
+			COALESCE(c.name, ''),
+			b.moderation_status,
+			b.submitted_at,
+			b.reviewed_at
+		FROM brands b
+		LEFT JOIN countries c ON b.country_id = c.id
+		WHERE $1='' OR b.moderation_status::text=$1
+		ORDER BY b.submitted_at NULLS LAST, b.id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	brands := make([]brand.ModerationSummary, 0)
+	for rows.Next() {
+		var b brand.ModerationSummary
+
+		if err := rows.Scan(
+			&b.ID,
+			&b.UserID,
+			&b.Name,
+			&b.Logo,
+			&b.Country,
+			&b.ModerationStatus,
+			&b.SubmittedAt,
+			&b.ReviewedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+
+		brands = append(brands, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return brands, nil
+}
+
+// SetBrandModeration records an admin decision on a pending brand.
+// Approval publishes the brand, rejection keeps it hidden.
+func (r *repository) SetBrandModeration(
+	ctx context.Context,
+	brandID int,
+	status string,
+	comment *string,
+	moderatorID int,
+) (*brand.Brand, error) {
+	query := `
+		UPDATE brands
+		SET
+			moderation_status=$2,
+			moderation_comment=$3,
+			moderator_id=$4,
+			is_published=($2='approved'),
+			reviewed_at=NOW(),
+			version=version+1
+		WHERE id=$1 AND moderation_status='pending'
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, brandID, status, comment, moderatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return nil, ErrBrandNotFound
+	}
 
-	return err
+	return r.GetBrandByID(ctx, brandID)
 }
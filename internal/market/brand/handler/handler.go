@@ -10,63 +10,88 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
 	jwtmiddleware "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt/middleware"
+	"github.com/xw1nchester/kushfinds-backend/internal/etag"
 	"github.com/xw1nchester/kushfinds-backend/internal/handlers"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
 	"github.com/xw1nchester/kushfinds-backend/internal/market/brand"
 	"go.uber.org/zap"
 )
 
-var validate = validator.New()
+var validate = apperror.NewValidator()
 
 type Service interface {
-	CreateBrand(ctx context.Context, data brand.Brand) (*brand.Brand, error)
+	CreateBrand(ctx context.Context, data brand.Brand, userID int) (*brand.Brand, error)
 	GetUserBrands(ctx context.Context, userID int) ([]brand.BrandSummary, error)
 	GetUserBrand(ctx context.Context, brandID, userID int) (*brand.Brand, error)
-	UpdateBrand(ctx context.Context, data brand.Brand) (*brand.Brand, error)
-	DeleteBrand(ctx context.Context, brandID, userID int) error
+	GetBrand(ctx context.Context, brandID int) (*brand.Brand, error)
+	GetBrandAvailability(ctx context.Context, brandID int) ([]brand.StateAvailability, error)
+	UpdateBrand(ctx context.Context, data brand.Brand, userID int, versions []int) (*brand.Brand, error)
+	DeleteBrand(ctx context.Context, brandID, userID int, versions []int) error
+	SubmitBrand(ctx context.Context, brandID, userID int) (*brand.Brand, error)
+	GetBrandsForModeration(ctx context.Context, adminID int, filter brand.ModerationFilter) ([]brand.ModerationSummary, error)
+	GetBrandForModeration(ctx context.Context, adminID, brandID int) (*brand.Brand, error)
+	ModerateBrand(ctx context.Context, adminID, brandID int, action, comment string) (*brand.Brand, error)
 }
 
 type handler struct {
-	service        Service
-	authMiddleware func(http.Handler) http.Handler
-	staticURL      string
-	logger         *zap.Logger
+	service               Service
+	authMiddleware        func(http.Handler) http.Handler
+	idempotencyMiddleware func(http.Handler) http.Handler
+	staticURL             string
+	logger                *zap.Logger
 }
 
 func New(
 	service Service,
 	authMiddleware func(http.Handler) http.Handler,
+	idempotencyMiddleware func(http.Handler) http.Handler,
 	staticURL string,
 	logger *zap.Logger,
 ) handlers.Handler {
 	return &handler{
-		service:        service,
-		authMiddleware: authMiddleware,
-		staticURL:      staticURL,
-		logger:         logger,
+		service:               service,
+		authMiddleware:        authMiddleware,
+		idempotencyMiddleware: idempotencyMiddleware,
+		staticURL:             staticURL,
+		logger:                logger,
 	}
 }
 
 func (h *handler) Register(router chi.Router) {
+	router.Route("/brands", func(brandRouter chi.Router) {
+		brandRouter.Get("/{id}", apperror.Middleware(h.getBrandHandler))
+		brandRouter.Get("/{id}/availability", apperror.Middleware(h.getBrandAvailabilityHandler))
+	})
+
 	router.Route("/me/brands", func(privateBrandRouter chi.Router) {
 		privateBrandRouter.Use(h.authMiddleware)
-		privateBrandRouter.Post("/", apperror.Middleware(h.createBrandHandler))
+		privateBrandRouter.With(h.idempotencyMiddleware).Post("/", apperror.Middleware(h.createBrandHandler))
 		privateBrandRouter.Get("/", apperror.Middleware(h.getUserBrandsHandler))
 		privateBrandRouter.Get("/{id}", apperror.Middleware(h.getUserBrandHandler))
 		privateBrandRouter.Patch("/{id}", apperror.Middleware(h.updateBrandHandler))
 		privateBrandRouter.Delete("/{id}", apperror.Middleware(h.deleteBrandHandler))
+		privateBrandRouter.Post("/{id}/submit", apperror.Middleware(h.submitBrandHandler))
+	})
+
+	router.Route("/admin/brands", func(adminBrandRouter chi.Router) {
+		adminBrandRouter.Use(h.authMiddleware)
+		adminBrandRouter.Get("/", apperror.Middleware(h.getBrandsForModerationHandler))
+		adminBrandRouter.Get("/{id}", apperror.Middleware(h.getBrandForModerationHandler))
+		adminBrandRouter.Patch("/{id}", apperror.Middleware(h.moderateBrandHandler))
 	})
 }
 
 // @Security	ApiKeyAuth
 // @Tags		market
 // @Param		request	body		BrandRequest	true	"request body"
+// @Param		Idempotency-Key	header		string	false	"replays the first response to retries with the same key"
 // @Success	200		{object}	BrandResponse
-// @Failure	400,500	{object}	apperror.AppError
+// @Failure	400,403,404,409,422,500	{object}	apperror.AppError
 // @Router		/me/brands [post]
 func (h *handler) createBrandHandler(w http.ResponseWriter, r *http.Request) error {
 	var dto BrandRequest
 	if err := render.DecodeJSON(r.Body, &dto); err != nil {
-		h.logger.Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
 		return apperror.ErrDecodeBody
 	}
 
@@ -76,12 +101,12 @@ func (h *handler) createBrandHandler(w http.ResponseWriter, r *http.Request) err
 
 	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
 
-	createdBrand, err := h.service.CreateBrand(r.Context(), *dto.ToDomain(userID))
+	createdBrand, err := h.service.CreateBrand(r.Context(), *dto.ToDomain(userID), userID)
 	if err != nil {
 		return err
 	}
 
-	render.JSON(w, r, NewBrandResponse(*createdBrand, h.staticURL))
+	etag.Write(w, r, createdBrand.Version, NewBrandResponse(*createdBrand, h.staticURL))
 
 	return nil
 }
@@ -106,13 +131,15 @@ func (h *handler) getUserBrandsHandler(w http.ResponseWriter, r *http.Request) e
 
 // @Security	ApiKeyAuth
 // @Tags		market
+// @Param		If-None-Match	header		string	false	"ETag of the cached brand"
 // @Success	200		{object}	BrandResponse
+// @Success	304
 // @Failure	400,500	{object}	apperror.AppError
 // @Router		/me/brands/{id} [get]
 func (h *handler) getUserBrandHandler(w http.ResponseWriter, r *http.Request) error {
 	brandID, err := strconv.Atoi(chi.URLParam(r, "id"))
 	if err != nil {
-		return apperror.NewAppError("id should be positive integer")
+		return apperror.NewFieldError("id", "field.positive_integer")
 	}
 
 	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
@@ -122,7 +149,49 @@ func (h *handler) getUserBrandHandler(w http.ResponseWriter, r *http.Request) er
 		return err
 	}
 
-	render.JSON(w, r, NewBrandResponse(*brand, h.staticURL))
+	etag.Write(w, r, brand.Version, NewBrandResponse(*brand, h.staticURL))
+
+	return nil
+}
+
+// @Tags		market
+// @Param		If-None-Match	header		string	false	"ETag of the cached brand"
+// @Success	200		{object}	BrandResponse
+// @Success	304
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/brands/{id} [get]
+func (h *handler) getBrandHandler(w http.ResponseWriter, r *http.Request) error {
+	brandID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return apperror.NewFieldError("id", "field.positive_integer")
+	}
+
+	brand, err := h.service.GetBrand(r.Context(), brandID)
+	if err != nil {
+		return err
+	}
+
+	etag.Write(w, r, brand.Version, NewBrandResponse(*brand, h.staticURL))
+
+	return nil
+}
+
+// @Tags		market
+// @Success	200		{object}	AvailabilityResponse
+// @Failure	400,404,500	{object}	apperror.AppError
+// @Router		/brands/{id}/availability [get]
+func (h *handler) getBrandAvailabilityHandler(w http.ResponseWriter, r *http.Request) error {
+	brandID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return apperror.NewFieldError("id", "field.positive_integer")
+	}
+
+	availability, err := h.service.GetBrandAvailability(r.Context(), brandID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewAvailabilityResponse(availability, h.staticURL))
 
 	return nil
 }
@@ -130,18 +199,19 @@ func (h *handler) getUserBrandHandler(w http.ResponseWriter, r *http.Request) er
 // @Security	ApiKeyAuth
 // @Tags		market
 // @Param		request	body		BrandRequest	true	"request body"
+// @Param		If-Match	header		string	false	"ETag the edit is based on"
 // @Success	200		{object}	BrandResponse
-// @Failure	400,500	{object}	apperror.AppError
+// @Failure	400,412,500	{object}	apperror.AppError
 // @Router		/me/brands/{id} [patch]
 func (h *handler) updateBrandHandler(w http.ResponseWriter, r *http.Request) error {
 	brandID, err := strconv.Atoi(chi.URLParam(r, "id"))
 	if err != nil {
-		return apperror.NewAppError("id should be positive integer")
+		return apperror.NewFieldError("id", "field.positive_integer")
 	}
 
 	var dto BrandRequest
 	if err := render.DecodeJSON(r.Body, &dto); err != nil {
-		h.logger.Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
 		return apperror.ErrDecodeBody
 	}
 
@@ -153,28 +223,142 @@ func (h *handler) updateBrandHandler(w http.ResponseWriter, r *http.Request) err
 	brandInfo := dto.ToDomain(userID)
 	brandInfo.ID = brandID
 
-	updatedBrand, err := h.service.UpdateBrand(r.Context(), *brandInfo)
+	updatedBrand, err := h.service.UpdateBrand(r.Context(), *brandInfo, userID, etag.IfMatch(r))
 	if err != nil {
 		return err
 	}
 
-	render.JSON(w, r, NewBrandResponse(*updatedBrand, h.staticURL))
+	etag.Write(w, r, updatedBrand.Version, NewBrandResponse(*updatedBrand, h.staticURL))
 
 	return nil
 }
 
 // @Security	ApiKeyAuth
 // @Tags		market
+// @Param		If-Match	header		string	false	"ETag the deletion is based on"
 // @Success	200
-// @Failure	400,500	{object}	apperror.AppError
+// @Failure	400,412,500	{object}	apperror.AppError
 // @Router		/me/brands/{id} [delete]
 func (h *handler) deleteBrandHandler(w http.ResponseWriter, r *http.Request) error {
 	brandID, err := strconv.Atoi(chi.URLParam(r, "id"))
 	if err != nil {
-		return apperror.NewAppError("id should be positive integer")
+		return apperror.NewFieldError("id", "field.positive_integer")
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	return h.service.DeleteBrand(r.Context(), brandID, userID, etag.IfMatch(r))
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Success	200		{object}	BrandResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/brands/{id}/submit [post]
+func (h *handler) submitBrandHandler(w http.ResponseWriter, r *http.Request) error {
+	brandID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return apperror.NewFieldError("id", "field.positive_integer")
 	}
 
 	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
 
-	return h.service.DeleteBrand(r.Context(), brandID, userID)
+	submittedBrand, err := h.service.SubmitBrand(r.Context(), brandID, userID)
+	if err != nil {
+		return err
+	}
+
+	etag.Write(w, r, submittedBrand.Version, NewBrandResponse(*submittedBrand, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		admin brands
+// @Param		status	query		string	false	"moderation status, pending by default"
+// @Success	200		{object}	ModerationBrandsResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/admin/brands [get]
+func (h *handler) getBrandsForModerationHandler(w http.ResponseWriter, r *http.Request) error {
+	filter := brand.ModerationFilter{Status: r.URL.Query().Get("status")}
+	switch filter.Status {
+	case "":
+		filter.Status = brand.ModerationStatusPending
+	case "all":
+		filter.Status = ""
+	case brand.ModerationStatusDraft,
+		brand.ModerationStatusPending,
+		brand.ModerationStatusApproved,
+		brand.ModerationStatusRejected:
+	default:
+		return apperror.NewFieldError("status", "field.one_of", "draft, pending, approved, rejected, all")
+	}
+
+	adminID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	brands, err := h.service.GetBrandsForModeration(r.Context(), adminID, filter)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewModerationBrandsResponse(brands, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		admin brands
+// @Success	200		{object}	BrandResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/admin/brands/{id} [get]
+func (h *handler) getBrandForModerationHandler(w http.ResponseWriter, r *http.Request) error {
+	brandID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return apperror.NewFieldError("id", "field.positive_integer")
+	}
+
+	adminID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	brand, err := h.service.GetBrandForModeration(r.Context(), adminID, brandID)
+	if err != nil {
+		return err
+	}
+
+	etag.Write(w, r, brand.Version, NewBrandResponse(*brand, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		admin brands
+// @Param		request	body		ModerationRequest	true	"request body"
+// @Success	200		{object}	BrandResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/admin/brands/{id} [patch]
+func (h *handler) moderateBrandHandler(w http.ResponseWriter, r *http.Request) error {
+	brandID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return apperror.NewFieldError("id", "field.positive_integer")
+	}
+
+	var dto ModerationRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	adminID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	moderatedBrand, err := h.service.ModerateBrand(r.Context(), adminID, brandID, dto.Action, dto.Comment)
+	if err != nil {
+		return err
+	}
+
+	etag.Write(w, r, moderatedBrand.Version, NewBrandResponse(*moderatedBrand, h.staticURL))
+
+	return nil
 }
@@ -16,6 +16,7 @@ type Social struct {
 }
 
 type BrandRequest struct {
+	BusinessID          *types.IntOrString  `json:"businessId" validate:"omitempty,gt=0"`
 	CountryID           types.IntOrString   `json:"country" validate:"required"`
 	MarketSection       types.IntOrString   `json:"marketSection" validate:"required"`
 	MarketSubSectionIDs []types.IntOrString `json:"marketSubSectionIds" validate:"required,dive,gt=0"`
@@ -30,7 +31,12 @@ type BrandRequest struct {
 	IsPublished         *bool               `json:"isPublished" validate:"required"`
 }
 
+// ToDomain builds a brand of the user's own business unless businessId is set.
 func (br *BrandRequest) ToDomain(userID int) *brand.Brand {
+	if br.BusinessID != nil {
+		userID = int(*br.BusinessID)
+	}
+
 	var marketSubSections []marketsection.MarketSection
 	for _, id := range utils.RemoveDuplicates(br.MarketSubSectionIDs) {
 		marketSubSections = append(
@@ -103,3 +109,35 @@ func NewBrandsSummaryResponse(elements []brand.BrandSummary, staticURL string) B
 	}
 	return BrandsSummaryResponse{Brands: elements}
 }
+
+type AvailabilityResponse struct {
+	States []brand.StateAvailability `json:"states"`
+}
+
+func NewAvailabilityResponse(elements []brand.StateAvailability, staticURL string) AvailabilityResponse {
+	for i := range elements {
+		for j := range elements[i].Regions {
+			stores := elements[i].Regions[j].Stores
+			for k := range stores {
+				stores[k].Banner = staticURL + "/" + stores[k].Banner
+			}
+		}
+	}
+	return AvailabilityResponse{States: elements}
+}
+
+type ModerationRequest struct {
+	Action  string `json:"action" validate:"required,oneof=approve reject"`
+	Comment string `json:"comment" validate:"required_if=Action reject,max=1000"`
+}
+
+type ModerationBrandsResponse struct {
+	Brands []brand.ModerationSummary `json:"brands"`
+}
+
+func NewModerationBrandsResponse(elements []brand.ModerationSummary, staticURL string) ModerationBrandsResponse {
+	for i := range elements {
+		elements[i].Logo = staticURL + "/" + elements[i].Logo
+	}
+	return ModerationBrandsResponse{Brands: elements}
+}
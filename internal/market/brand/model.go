@@ -23,7 +23,15 @@ type Brand struct {
 	Banner            string                        `json:"banner"`
 	Documents         []string                      `json:"documents"`
 	Socials           []social.EntitySocial         `json:"socials"`
+	FollowersCount    int                           `json:"followersCount"`
+	Rating            float64                       `json:"rating"`
+	ReviewsCount      int                           `json:"reviewsCount"`
 	IsPublished       bool                          `json:"isPublished"`
+	ModerationStatus  string                        `json:"moderationStatus"`
+	ModerationComment *string                       `json:"moderationComment"`
+	SubmittedAt       *time.Time                    `json:"submittedAt"`
+	ReviewedAt        *time.Time                    `json:"reviewedAt"`
+	Version           int                           `json:"version"`
 	CreatedAt         time.Time                     `json:"createdAt"`
 	UpdatedAt         time.Time                     `json:"updatedAt"`
 }
@@ -33,3 +41,42 @@ type BrandSummary struct {
 	Name string `json:"name"`
 	Logo string `json:"logo"`
 }
+
+type ModerationSummary struct {
+	ID               int        `json:"id"`
+	UserID           int        `json:"userId"`
+	Name             string     `json:"name"`
+	Logo             string     `json:"logo"`
+	Country          string     `json:"country"`
+	ModerationStatus string     `json:"moderationStatus"`
+	SubmittedAt      *time.Time `json:"submittedAt"`
+	ReviewedAt       *time.Time `json:"reviewedAt"`
+}
+
+type ModerationFilter struct {
+	Status string
+}
+
+// StateAvailability lists the published stores of a brand in a state.
+// A state may have stores without being listed among the brand's states
+// if they were opened before the coverage was enforced.
+type StateAvailability struct {
+	ID            int                  `json:"id"`
+	Name          string               `json:"name"`
+	IsDistributed bool                 `json:"isDistributed"`
+	Regions       []RegionAvailability `json:"regions"`
+}
+
+type RegionAvailability struct {
+	ID     int              `json:"id"`
+	Name   string           `json:"name"`
+	Stores []AvailableStore `json:"stores"`
+}
+
+type AvailableStore struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Banner string `json:"banner"`
+	Street string `json:"street"`
+	House  string `json:"house"`
+}
@@ -0,0 +1,59 @@
+package brand
+
+import "slices"
+
+const (
+	ModerationStatusDraft    = "draft"
+	ModerationStatusPending  = "pending"
+	ModerationStatusApproved = "approved"
+	ModerationStatusRejected = "rejected"
+)
+
+// HasSensitiveChanges reports whether the update touches legally
+// significant data that an admin has to look at again.
+func HasSensitiveChanges(current, next Brand) bool {
+	if current.Name != next.Name || current.Country.ID != next.Country.ID {
+		return true
+	}
+
+	currentDocuments := slices.Clone(current.Documents)
+	nextDocuments := slices.Clone(next.Documents)
+	slices.Sort(currentDocuments)
+	slices.Sort(nextDocuments)
+
+	return !slices.Equal(currentDocuments, nextDocuments)
+}
+
+// ResolveModeration returns the moderation status and the effective
+// publication flag for a brand being saved. The owner asks to be published
+// via next.IsPublished, but only approved brands are actually published:
+// anything else is queued for review instead. current is nil on creation.
+func ResolveModeration(current *Brand, next Brand) (string, bool) {
+	if current == nil {
+		if next.IsPublished {
+			return ModerationStatusPending, false
+		}
+		return ModerationStatusDraft, false
+	}
+
+	switch current.ModerationStatus {
+	case ModerationStatusApproved:
+		if !HasSensitiveChanges(*current, next) {
+			return ModerationStatusApproved, next.IsPublished
+		}
+		if next.IsPublished {
+			return ModerationStatusPending, false
+		}
+		return ModerationStatusDraft, false
+	case ModerationStatusPending:
+		if next.IsPublished {
+			return ModerationStatusPending, false
+		}
+		return ModerationStatusDraft, false
+	default:
+		if next.IsPublished {
+			return ModerationStatusPending, false
+		}
+		return current.ModerationStatus, false
+	}
+}
@@ -0,0 +1,108 @@
+package brand
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xw1nchester/kushfinds-backend/internal/location/country"
+)
+
+func TestResolveModeration(t *testing.T) {
+	approved := Brand{
+		Name:             "Green",
+		Country:          country.Country{ID: 1},
+		Documents:        []string{"a.pdf", "b.pdf"},
+		IsPublished:      true,
+		ModerationStatus: ModerationStatusApproved,
+	}
+
+	withStatus := func(status string) *Brand {
+		b := approved
+		b.ModerationStatus = status
+		b.IsPublished = false
+		return &b
+	}
+
+	tests := []struct {
+		name              string
+		current           *Brand
+		next              Brand
+		expectedStatus    string
+		expectedPublished bool
+	}{
+		{
+			name:           "create draft",
+			next:           Brand{Name: "Green"},
+			expectedStatus: ModerationStatusDraft,
+		},
+		{
+			name:           "create with publish request goes to review",
+			next:           Brand{Name: "Green", IsPublished: true},
+			expectedStatus: ModerationStatusPending,
+		},
+		{
+			name:              "approved brand keeps publication on regular change",
+			current:           &approved,
+			next:              Brand{Name: "Green", Country: country.Country{ID: 1}, Documents: []string{"b.pdf", "a.pdf"}, IsPublished: true},
+			expectedStatus:    ModerationStatusApproved,
+			expectedPublished: true,
+		},
+		{
+			name:           "approved brand can be unpublished",
+			current:        &approved,
+			next:           Brand{Name: "Green", Country: country.Country{ID: 1}, Documents: []string{"a.pdf", "b.pdf"}},
+			expectedStatus: ModerationStatusApproved,
+		},
+		{
+			name:           "renaming approved brand triggers re-review",
+			current:        &approved,
+			next:           Brand{Name: "Greener", Country: country.Country{ID: 1}, Documents: []string{"a.pdf", "b.pdf"}, IsPublished: true},
+			expectedStatus: ModerationStatusPending,
+		},
+		{
+			name:           "changing country of approved brand triggers re-review",
+			current:        &approved,
+			next:           Brand{Name: "Green", Country: country.Country{ID: 2}, Documents: []string{"a.pdf", "b.pdf"}, IsPublished: true},
+			expectedStatus: ModerationStatusPending,
+		},
+		{
+			name:           "changing documents of unpublished approved brand resets to draft",
+			current:        &approved,
+			next:           Brand{Name: "Green", Country: country.Country{ID: 1}, Documents: []string{"a.pdf"}},
+			expectedStatus: ModerationStatusDraft,
+		},
+		{
+			name:           "pending brand stays in queue",
+			current:        withStatus(ModerationStatusPending),
+			next:           Brand{Name: "Greener", IsPublished: true},
+			expectedStatus: ModerationStatusPending,
+		},
+		{
+			name:           "pending brand is withdrawn",
+			current:        withStatus(ModerationStatusPending),
+			next:           Brand{Name: "Green"},
+			expectedStatus: ModerationStatusDraft,
+		},
+		{
+			name:           "rejected brand is resubmitted",
+			current:        withStatus(ModerationStatusRejected),
+			next:           Brand{Name: "Green", IsPublished: true},
+			expectedStatus: ModerationStatusPending,
+		},
+		{
+			name:           "rejected brand stays rejected while editing",
+			current:        withStatus(ModerationStatusRejected),
+			next:           Brand{Name: "Green"},
+			expectedStatus: ModerationStatusRejected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, published := ResolveModeration(tt.current, tt.next)
+
+			require.Equal(t, tt.expectedStatus, status)
+			require.Equal(t, tt.expectedPublished, published)
+		})
+	}
+}
@@ -3,32 +3,62 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/audit"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/country"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
 	"github.com/xw1nchester/kushfinds-backend/internal/market/brand"
 	"github.com/xw1nchester/kushfinds-backend/internal/market/brand/db"
+	"github.com/xw1nchester/kushfinds-backend/internal/team"
+	"github.com/xw1nchester/kushfinds-backend/internal/tracing"
+	"github.com/xw1nchester/kushfinds-backend/internal/user"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor"
 	"go.uber.org/zap"
 )
 
 var (
-	ErrBrandNameAlreadyExists = apperror.NewAppError("the brand with this name already exists")
+	ErrBrandNameAlreadyExists   = apperror.NewConflictError("brand.name_already_exists", "the brand with this name already exists")
+	ErrBrandAlreadySubmitted    = apperror.NewAppError("brand.already_submitted", "the brand is already submitted for review or approved")
+	ErrBrandNotPendingReview    = apperror.NewAppError("brand.not_pending_review", "the brand is not pending review")
+	ErrModerationCommentNeeded  = apperror.NewAppError("brand.moderation_comment_required", "comment is required when rejecting a brand")
+	ErrBrandNotAvailableInState = apperror.NewAppError("brand.not_available_in_state", "the brand is not distributed in this state")
 )
 
 type Repository interface {
 	CheckBrandNameIsAvailable(ctx context.Context, name string, excludeID ...int) (bool, error)
 	GetUserBrands(ctx context.Context, userID int) ([]brand.BrandSummary, error)
 	GetUserBrand(ctx context.Context, brandID, userID int) (*brand.Brand, error)
+	GetBrandByID(ctx context.Context, brandID int) (*brand.Brand, error)
+	GetPublishedBrand(ctx context.Context, brandID int) (*brand.Brand, error)
+	GetFollowedBrands(ctx context.Context, userID int) ([]brand.BrandSummary, error)
 	CreateBrand(ctx context.Context, data brand.Brand) (*brand.Brand, error)
-	CheckBrandExists(ctx context.Context, brandID, userID int) error
-	UpdateBrand(ctx context.Context, data brand.Brand) (*brand.Brand, error)
-	DeleteBrand(ctx context.Context, brandID, userID int) error
+	GetBrandOwnerID(ctx context.Context, brandID int) (int, error)
+	IsBrandAvailableInState(ctx context.Context, brandID, stateID int) (bool, error)
+	GetBrandAvailability(ctx context.Context, brandID int) ([]brand.StateAvailability, error)
+	UpdateBrand(ctx context.Context, data brand.Brand, versions []int) (*brand.Brand, error)
+	DeleteBrand(ctx context.Context, brandID, userID int, versions []int) error
+	SubmitBrand(ctx context.Context, brandID, userID int) (*brand.Brand, error)
+	GetBrandsForModeration(ctx context.Context, status string) ([]brand.ModerationSummary, error)
+	SetBrandModeration(
+		ctx context.Context,
+		brandID int,
+		status string,
+		comment *string,
+		moderatorID int,
+	) (*brand.Brand, error)
 }
 
 type UserService interface {
+	GetByID(ctx context.Context, id int) (*user.User, error)
 	CheckBusinessProfileExists(ctx context.Context, userID int, requireVerified bool) error
 }
 
+type TeamService interface {
+	CheckPermission(ctx context.Context, businessID, userID int, permission team.Permission) error
+}
+
 type CountryService interface {
 	GetByID(ctx context.Context, id int) (*country.Country, error)
 }
@@ -45,55 +75,81 @@ type SocialService interface {
 	CheckSocialsExist(ctx context.Context, IDs []int) error
 }
 
+type AuditRecorder interface {
+	Record(ctx context.Context, record audit.Record) error
+}
+
 type service struct {
 	repository           Repository
 	userService          UserService
+	teamService          TeamService
 	countryService       CountryService
 	stateService         StateService
 	marketSectionService MarketSectionService
 	socialService        SocialService
+	txManager            transactor.Manager
+	auditRecorder        AuditRecorder
 	logger               *zap.Logger
 }
 
 func New(
 	repository Repository,
 	userService UserService,
+	teamService TeamService,
 	countryService CountryService,
 	stateService StateService,
 	marketSectionService MarketSectionService,
 	socialService SocialService,
+	txManager transactor.Manager,
+	auditRecorder AuditRecorder,
 	logger *zap.Logger,
 ) *service {
 	return &service{
 		repository:           repository,
 		userService:          userService,
+		teamService:          teamService,
 		countryService:       countryService,
 		stateService:         stateService,
 		marketSectionService: marketSectionService,
 		socialService:        socialService,
+		txManager:            txManager,
+		auditRecorder:        auditRecorder,
 		logger:               logger,
 	}
 }
 
-func (s *service) CheckBrandExists(ctx context.Context, brandID, userID int) error {
-	err := s.repository.CheckBrandExists(ctx, brandID, userID)
+// CheckBrandPermission checks that the user may act on the brand on behalf
+// of its business and returns the business ID.
+func (s *service) CheckBrandPermission(
+	ctx context.Context,
+	brandID int,
+	userID int,
+	permission team.Permission,
+) (int, error) {
+	ctx, span := tracing.Start(ctx, "brandservice.CheckBrandPermission")
+	defer span.End()
+
+	ownerID, err := s.repository.GetBrandOwnerID(ctx, brandID)
 	if err != nil {
 		if errors.Is(err, db.ErrBrandNotFound) {
-			return apperror.ErrNotFound
+			return 0, apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when check brand exists by id", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching brand owner", zap.Error(err))
+
+		return 0, err
 	}
 
-	return err
+	if err := s.teamService.CheckPermission(ctx, ownerID, userID, permission); err != nil {
+		return 0, err
+	}
+
+	return ownerID, nil
 }
 
 func (s *service) validateBrandData(ctx context.Context, data brand.Brand, isUpdate bool) error {
-	if isUpdate {
-		if err := s.CheckBrandExists(ctx, data.ID, data.UserID); err != nil {
-			return err
-		}
-	}
+	ctx, span := tracing.Start(ctx, "brandservice.validateBrandData")
+	defer span.End()
 
 	if err := s.userService.CheckBusinessProfileExists(
 		ctx,
@@ -146,7 +202,7 @@ func (s *service) validateBrandData(ctx context.Context, data brand.Brand, isUpd
 	nameIsAvailable, err := s.repository.CheckBrandNameIsAvailable(ctx, data.Name, args...)
 	if !nameIsAvailable {
 		if err != nil {
-			s.logger.Error("unexpected error when checking brand name availability", zap.Error(err))
+			logging.FromContext(ctx, s.logger).Error("unexpected error when checking brand name availability", zap.Error(err))
 			return err
 		} else {
 			return ErrBrandNameAlreadyExists
@@ -156,15 +212,36 @@ func (s *service) validateBrandData(ctx context.Context, data brand.Brand, isUpd
 	return nil
 }
 
-func (s *service) CreateBrand(ctx context.Context, data brand.Brand) (*brand.Brand, error) {
+// CreateBrand creates the brand for the business data.UserID,
+// users that are not its founder need the permission to manage brands.
+func (s *service) CreateBrand(ctx context.Context, data brand.Brand, userID int) (*brand.Brand, error) {
+	ctx, span := tracing.Start(ctx, "brandservice.CreateBrand")
+	defer span.End()
+
+	if data.UserID != userID {
+		if err := s.teamService.CheckPermission(ctx, data.UserID, userID, team.PermissionManageBrands); err != nil {
+			return nil, err
+		}
+	}
+
 	err := s.validateBrandData(ctx, data, false)
 	if err != nil {
 		return nil, err
 	}
 
-	createdBrand, err := s.repository.CreateBrand(ctx, data)
-	if err != nil {
-		s.logger.Error("unexpected error when creating brand", zap.Error(err))
+	data.ModerationStatus, data.IsPublished = brand.ResolveModeration(nil, data)
+
+	var createdBrand *brand.Brand
+
+	if err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		createdBrand, err = s.repository.CreateBrand(ctx, data)
+		if err != nil {
+			logging.FromContext(ctx, s.logger).Error("unexpected error when creating brand", zap.Error(err))
+			return err
+		}
+
+		return s.recordPublication(ctx, userID, nil, createdBrand)
+	}); err != nil {
 		return nil, err
 	}
 
@@ -174,7 +251,7 @@ func (s *service) CreateBrand(ctx context.Context, data brand.Brand) (*brand.Bra
 func (s *service) GetUserBrands(ctx context.Context, userID int) ([]brand.BrandSummary, error) {
 	brands, err := s.repository.GetUserBrands(ctx, userID)
 	if err != nil {
-		s.logger.Error("unexpected error when fetching user brands", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching user brands", zap.Error(err))
 
 		return nil, err
 	}
@@ -182,45 +259,372 @@ func (s *service) GetUserBrands(ctx context.Context, userID int) ([]brand.BrandS
 	return brands, nil
 }
 
+// authorizeBrand returns the brand if the user has the permission
+// in the team of its business.
+func (s *service) authorizeBrand(
+	ctx context.Context,
+	brandID int,
+	userID int,
+	permission team.Permission,
+) (*brand.Brand, error) {
+	existingBrand, err := s.getBrandByID(ctx, brandID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.teamService.CheckPermission(ctx, existingBrand.UserID, userID, permission); err != nil {
+		return nil, err
+	}
+
+	return existingBrand, nil
+}
+
 func (s *service) GetUserBrand(ctx context.Context, brandID, userID int) (*brand.Brand, error) {
-	brand, err := s.repository.GetUserBrand(ctx, brandID, userID)
+	return s.authorizeBrand(ctx, brandID, userID, team.PermissionView)
+}
+
+// GetManagedBrand returns the brand if the user is allowed to manage it.
+func (s *service) GetManagedBrand(ctx context.Context, brandID, userID int) (*brand.Brand, error) {
+	return s.authorizeBrand(ctx, brandID, userID, team.PermissionManageBrands)
+}
+
+func (s *service) GetBrand(ctx context.Context, brandID int) (*brand.Brand, error) {
+	ctx, span := tracing.Start(ctx, "brandservice.GetBrand")
+	defer span.End()
+
+	brand, err := s.repository.GetPublishedBrand(ctx, brandID)
 	if err != nil {
 		if errors.Is(err, db.ErrBrandNotFound) {
 			return nil, apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when fetching brand by id", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching published brand by id", zap.Error(err))
 
 		return nil, err
 	}
 
+	// documents and moderation details are visible to the brand team only
+	brand.Documents = make([]string, 0)
+	brand.ModerationComment = nil
+
 	return brand, nil
 }
 
-func (s *service) UpdateBrand(ctx context.Context, data brand.Brand) (*brand.Brand, error) {
-	err := s.validateBrandData(ctx, data, true)
+// CheckBrandAvailableInState checks that the state is listed among the
+// states the brand is distributed in.
+func (s *service) CheckBrandAvailableInState(ctx context.Context, brandID, stateID int) error {
+	ctx, span := tracing.Start(ctx, "brandservice.CheckBrandAvailableInState")
+	defer span.End()
+
+	available, err := s.repository.IsBrandAvailableInState(ctx, brandID, stateID)
 	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when checking brand state coverage", zap.Error(err))
+		return err
+	}
+
+	if !available {
+		return ErrBrandNotAvailableInState
+	}
+
+	return nil
+}
+
+func (s *service) GetBrandAvailability(ctx context.Context, brandID int) ([]brand.StateAvailability, error) {
+	ctx, span := tracing.Start(ctx, "brandservice.GetBrandAvailability")
+	defer span.End()
+
+	if _, err := s.GetBrand(ctx, brandID); err != nil {
+		return nil, err
+	}
+
+	availability, err := s.repository.GetBrandAvailability(ctx, brandID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching brand availability", zap.Error(err))
+		return nil, err
+	}
+
+	return availability, nil
+}
+
+func (s *service) GetFollowedBrands(ctx context.Context, userID int) ([]brand.BrandSummary, error) {
+	brands, err := s.repository.GetFollowedBrands(ctx, userID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching followed brands", zap.Error(err))
+
 		return nil, err
 	}
 
-	updatedBrand, err := s.repository.UpdateBrand(ctx, data)
+	return brands, nil
+}
+
+// checkVersion fails when versions is not nil and does not list the
+// current brand version, i.e. the client edits a stale copy.
+func checkVersion(existingBrand *brand.Brand, versions []int) error {
+	if versions != nil && !slices.Contains(versions, existingBrand.Version) {
+		return apperror.ErrPreconditionFailed
+	}
+
+	return nil
+}
+
+// UpdateBrand applies the edit only if the brand version is one of versions,
+// nil versions skip the check.
+func (s *service) UpdateBrand(
+	ctx context.Context,
+	data brand.Brand,
+	userID int,
+	versions []int,
+) (*brand.Brand, error) {
+	ctx, span := tracing.Start(ctx, "brandservice.UpdateBrand")
+	defer span.End()
+
+	existingBrand, err := s.authorizeBrand(ctx, data.ID, userID, team.PermissionManageBrands)
 	if err != nil {
-		s.logger.Error("unexpected error when updating brand", zap.Error(err))
+		return nil, err
+	}
+
+	data.UserID = existingBrand.UserID
+
+	if err := checkVersion(existingBrand, versions); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateBrandData(ctx, data, true); err != nil {
+		return nil, err
+	}
+
+	data.ModerationStatus, data.IsPublished = brand.ResolveModeration(existingBrand, data)
+
+	var updatedBrand *brand.Brand
+
+	if err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		updatedBrand, err = s.repository.UpdateBrand(ctx, data, versions)
+		if err != nil {
+			if errors.Is(err, db.ErrBrandVersionMismatch) {
+				return apperror.ErrPreconditionFailed
+			}
+			if errors.Is(err, db.ErrBrandNotFound) {
+				return apperror.ErrNotFound
+			}
+
+			logging.FromContext(ctx, s.logger).Error("unexpected error when updating brand", zap.Error(err))
+			return err
+		}
+
+		return s.recordPublication(ctx, userID, existingBrand, updatedBrand)
+	}); err != nil {
 		return nil, err
 	}
 
 	return updatedBrand, nil
 }
 
-func (s *service) DeleteBrand(ctx context.Context, brandID, userID int) error {
-	if err := s.CheckBrandExists(ctx, brandID, userID); err != nil {
+// recordPublication audits changes of the brand visibility made by its team.
+func (s *service) recordPublication(ctx context.Context, actorID int, before, after *brand.Brand) error {
+	wasPublished := before != nil && before.IsPublished
+	if wasPublished == after.IsPublished {
+		return nil
+	}
+
+	action := audit.ActionBrandPublish
+	if wasPublished {
+		action = audit.ActionBrandUnpublish
+	}
+
+	return s.auditRecorder.Record(ctx, audit.Record{
+		ActorID:    actorID,
+		Action:     action,
+		EntityType: audit.EntityBrand,
+		EntityID:   after.ID,
+		Before:     before,
+		After:      after,
+	})
+}
+
+func (s *service) DeleteBrand(ctx context.Context, brandID, userID int, versions []int) error {
+	ctx, span := tracing.Start(ctx, "brandservice.DeleteBrand")
+	defer span.End()
+
+	existingBrand, err := s.authorizeBrand(ctx, brandID, userID, team.PermissionDeleteBrands)
+	if err != nil {
+		return err
+	}
+
+	if err := checkVersion(existingBrand, versions); err != nil {
+		return err
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.DeleteBrand(ctx, brandID, existingBrand.UserID, versions); err != nil {
+			if errors.Is(err, db.ErrBrandVersionMismatch) {
+				return apperror.ErrPreconditionFailed
+			}
+			if errors.Is(err, db.ErrBrandNotFound) {
+				return apperror.ErrNotFound
+			}
+
+			logging.FromContext(ctx, s.logger).Error("unexpected error when deleting brand", zap.Error(err))
+			return err
+		}
+
+		return s.auditRecorder.Record(ctx, audit.Record{
+			ActorID:    userID,
+			Action:     audit.ActionBrandDelete,
+			EntityType: audit.EntityBrand,
+			EntityID:   brandID,
+			Before:     existingBrand,
+		})
+	})
+}
+
+func (s *service) SubmitBrand(ctx context.Context, brandID, userID int) (*brand.Brand, error) {
+	existingBrand, err := s.authorizeBrand(ctx, brandID, userID, team.PermissionManageBrands)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingBrand.ModerationStatus != brand.ModerationStatusDraft &&
+		existingBrand.ModerationStatus != brand.ModerationStatusRejected {
+		return nil, ErrBrandAlreadySubmitted
+	}
+
+	if err := s.userService.CheckBusinessProfileExists(ctx, existingBrand.UserID, true); err != nil {
+		return nil, err
+	}
+
+	submittedBrand, err := s.repository.SubmitBrand(ctx, brandID, existingBrand.UserID)
+	if err != nil {
+		if errors.Is(err, db.ErrBrandNotFound) {
+			return nil, ErrBrandAlreadySubmitted
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when submitting brand for review", zap.Error(err))
+
+		return nil, err
+	}
+
+	return submittedBrand, nil
+}
+
+func (s *service) checkAdmin(ctx context.Context, userID int) error {
+	existingUser, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
 		return err
 	}
 
-	err := s.repository.DeleteBrand(ctx, brandID, userID)
+	if !existingUser.IsAdmin {
+		return apperror.ErrForbidden
+	}
+
+	return nil
+}
+
+func (s *service) GetBrandsForModeration(
+	ctx context.Context,
+	adminID int,
+	filter brand.ModerationFilter,
+) ([]brand.ModerationSummary, error) {
+	if err := s.checkAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	brands, err := s.repository.GetBrandsForModeration(ctx, filter.Status)
 	if err != nil {
-		s.logger.Error("unexpected error when deleting brand", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching brands for moderation", zap.Error(err))
+		return nil, err
+	}
+
+	return brands, nil
+}
+
+func (s *service) getBrandByID(ctx context.Context, brandID int) (*brand.Brand, error) {
+	existingBrand, err := s.repository.GetBrandByID(ctx, brandID)
+	if err != nil {
+		if errors.Is(err, db.ErrBrandNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching brand by id", zap.Error(err))
+
+		return nil, err
+	}
+
+	return existingBrand, nil
+}
+
+func (s *service) GetBrandForModeration(ctx context.Context, adminID, brandID int) (*brand.Brand, error) {
+	if err := s.checkAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	return s.getBrandByID(ctx, brandID)
+}
+
+func (s *service) ModerateBrand(
+	ctx context.Context,
+	adminID int,
+	brandID int,
+	action string,
+	comment string,
+) (*brand.Brand, error) {
+	if err := s.checkAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	existingBrand, err := s.getBrandByID(ctx, brandID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingBrand.ModerationStatus != brand.ModerationStatusPending {
+		return nil, ErrBrandNotPendingReview
+	}
+
+	var status, auditAction string
+	switch action {
+	case "approve":
+		status = brand.ModerationStatusApproved
+		auditAction = audit.ActionBrandApprove
+	case "reject":
+		if comment == "" {
+			return nil, ErrModerationCommentNeeded
+		}
+		status = brand.ModerationStatusRejected
+		auditAction = audit.ActionBrandReject
+	default:
+		return nil, apperror.NewFieldError("action", "field.one_of", "approve, reject")
+	}
+
+	var moderationComment *string
+	if comment != "" {
+		moderationComment = &comment
+	}
+
+	var moderatedBrand *brand.Brand
+
+	if err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		moderatedBrand, err = s.repository.SetBrandModeration(ctx, brandID, status, moderationComment, adminID)
+		if err != nil {
+			if errors.Is(err, db.ErrBrandNotFound) {
+				return ErrBrandNotPendingReview
+			}
+
+			logging.FromContext(ctx, s.logger).Error("unexpected error when moderating brand", zap.Error(err))
+
+			return err
+		}
+
+		return s.auditRecorder.Record(ctx, audit.Record{
+			ActorID:    adminID,
+			Action:     auditAction,
+			EntityType: audit.EntityBrand,
+			EntityID:   brandID,
+			Before:     existingBrand,
+			After:      moderatedBrand,
+		})
+	}); err != nil {
+		return nil, err
 	}
 
-	return err
+	return moderatedBrand, nil
 }
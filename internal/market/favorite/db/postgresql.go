@@ -0,0 +1,139 @@
+package favoritedb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/favorite"
+	"go.uber.org/zap"
+)
+
+type repository struct {
+	client *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func New(client *pgxpool.Pool, logger *zap.Logger) *repository {
+	return &repository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Favorite and follow queries change the counter only when a row was actually
+// inserted or deleted, so repeated requests are idempotent.
+
+func (r *repository) AddFavoriteStore(ctx context.Context, userID, storeID int) error {
+	query := `
+		WITH inserted AS (
+			INSERT INTO favorites_stores (user_id, store_id)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+			RETURNING store_id
+		)
+		UPDATE stores
+		SET followers_count=followers_count+1
+		WHERE id IN (SELECT store_id FROM inserted)
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	_, err := r.client.Exec(ctx, query, userID, storeID)
+
+	return err
+}
+
+func (r *repository) RemoveFavoriteStore(ctx context.Context, userID, storeID int) error {
+	query := `
+		WITH deleted AS (
+			DELETE FROM favorites_stores
+			WHERE user_id=$1 AND store_id=$2
+			RETURNING store_id
+		)
+		UPDATE stores
+		SET followers_count=followers_count-1
+		WHERE id IN (SELECT store_id FROM deleted)
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	_, err := r.client.Exec(ctx, query, userID, storeID)
+
+	return err
+}
+
+func (r *repository) FollowBrand(ctx context.Context, userID, brandID int) error {
+	query := `
+		WITH inserted AS (
+			INSERT INTO brands_followers (user_id, brand_id)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+			RETURNING brand_id
+		)
+		UPDATE brands
+		SET followers_count=followers_count+1
+		WHERE id IN (SELECT brand_id FROM inserted)
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	_, err := r.client.Exec(ctx, query, userID, brandID)
+
+	return err
+}
+
+func (r *repository) UnfollowBrand(ctx context.Context, userID, brandID int) error {
+	query := `
+		WITH deleted AS (
+			DELETE FROM brands_followers
+			WHERE user_id=$1 AND brand_id=$2
+			RETURNING brand_id
+		)
+		UPDATE brands
+		SET followers_count=followers_count-1
+		WHERE id IN (SELECT brand_id FROM deleted)
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	_, err := r.client.Exec(ctx, query, userID, brandID)
+
+	return err
+}
+
+// GetBrandFollowers returns verified followers of the brand with their
+// locale preference. Followers of an unpublished brand are not returned.
+func (r *repository) GetBrandFollowers(ctx context.Context, brandID int) ([]favorite.Follower, error) {
+	query := `
+		SELECT u.email, u.locale
+		FROM brands_followers bf
+		JOIN brands b ON bf.brand_id = b.id
+		JOIN users u ON bf.user_id = u.id
+		WHERE bf.brand_id=$1 AND b.is_published=true AND u.is_verified=true
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, brandID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	followers := make([]favorite.Follower, 0)
+	for rows.Next() {
+		var follower favorite.Follower
+		if err := rows.Scan(&follower.Email, &follower.Locale); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+		followers = append(followers, follower)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return followers, nil
+}
@@ -0,0 +1,145 @@
+package favoritehandler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	jwtmiddleware "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt/middleware"
+	"github.com/xw1nchester/kushfinds-backend/internal/handlers"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/favorite"
+	"go.uber.org/zap"
+)
+
+type Service interface {
+	GetFavorites(ctx context.Context, userID int) (*favorite.Favorites, error)
+	AddFavoriteStore(ctx context.Context, userID, storeID int) error
+	RemoveFavoriteStore(ctx context.Context, userID, storeID int) error
+	FollowBrand(ctx context.Context, userID, brandID int) error
+	UnfollowBrand(ctx context.Context, userID, brandID int) error
+}
+
+type handler struct {
+	service        Service
+	authMiddleware func(http.Handler) http.Handler
+	staticURL      string
+	logger         *zap.Logger
+}
+
+func New(
+	service Service,
+	authMiddleware func(http.Handler) http.Handler,
+	staticURL string,
+	logger *zap.Logger,
+) handlers.Handler {
+	return &handler{
+		service:        service,
+		authMiddleware: authMiddleware,
+		staticURL:      staticURL,
+		logger:         logger,
+	}
+}
+
+func (h *handler) Register(router chi.Router) {
+	router.Route("/me/favorites", func(favoriteRouter chi.Router) {
+		favoriteRouter.Use(h.authMiddleware)
+		favoriteRouter.Get("/", apperror.Middleware(h.getFavoritesHandler))
+		favoriteRouter.Post("/stores/{id}", apperror.Middleware(h.addFavoriteStoreHandler))
+		favoriteRouter.Delete("/stores/{id}", apperror.Middleware(h.removeFavoriteStoreHandler))
+		favoriteRouter.Post("/brands/{id}", apperror.Middleware(h.followBrandHandler))
+		favoriteRouter.Delete("/brands/{id}", apperror.Middleware(h.unfollowBrandHandler))
+	})
+}
+
+func parseID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return 0, apperror.NewFieldError("id", "field.positive_integer")
+	}
+	return id, nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		favorites
+// @Success	200		{object}	FavoritesResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/favorites [get]
+func (h *handler) getFavoritesHandler(w http.ResponseWriter, r *http.Request) error {
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	favorites, err := h.service.GetFavorites(r.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewFavoritesResponse(*favorites, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		favorites
+// @Success	200
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/favorites/stores/{id} [post]
+func (h *handler) addFavoriteStoreHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseID(r)
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	return h.service.AddFavoriteStore(r.Context(), userID, storeID)
+}
+
+// @Security	ApiKeyAuth
+// @Tags		favorites
+// @Success	200
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/favorites/stores/{id} [delete]
+func (h *handler) removeFavoriteStoreHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseID(r)
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	return h.service.RemoveFavoriteStore(r.Context(), userID, storeID)
+}
+
+// @Security	ApiKeyAuth
+// @Tags		favorites
+// @Success	200
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/favorites/brands/{id} [post]
+func (h *handler) followBrandHandler(w http.ResponseWriter, r *http.Request) error {
+	brandID, err := parseID(r)
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	return h.service.FollowBrand(r.Context(), userID, brandID)
+}
+
+// @Security	ApiKeyAuth
+// @Tags		favorites
+// @Success	200
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/favorites/brands/{id} [delete]
+func (h *handler) unfollowBrandHandler(w http.ResponseWriter, r *http.Request) error {
+	brandID, err := parseID(r)
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	return h.service.UnfollowBrand(r.Context(), userID, brandID)
+}
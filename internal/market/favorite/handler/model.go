@@ -0,0 +1,18 @@
+package favoritehandler
+
+import "github.com/xw1nchester/kushfinds-backend/internal/market/favorite"
+
+type FavoritesResponse struct {
+	Favorites favorite.Favorites `json:"favorites"`
+}
+
+func NewFavoritesResponse(f favorite.Favorites, staticURL string) FavoritesResponse {
+	for i := range f.Stores {
+		f.Stores[i].Banner = staticURL + "/" + f.Stores[i].Banner
+		f.Stores[i].Brand.Logo = staticURL + "/" + f.Stores[i].Brand.Logo
+	}
+	for i := range f.Brands {
+		f.Brands[i].Logo = staticURL + "/" + f.Brands[i].Logo
+	}
+	return FavoritesResponse{Favorites: f}
+}
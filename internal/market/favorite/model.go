@@ -0,0 +1,16 @@
+package favorite
+
+import (
+	"github.com/xw1nchester/kushfinds-backend/internal/market/brand"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/store"
+)
+
+type Favorites struct {
+	Stores []store.StoreSummary `json:"stores"`
+	Brands []brand.BrandSummary `json:"brands"`
+}
+
+type Follower struct {
+	Email  string
+	Locale *string
+}
@@ -0,0 +1,65 @@
+package favoriteservice
+
+import (
+	"context"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/i18n"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/favorite"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/product"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/store"
+	"go.uber.org/zap"
+)
+
+type FollowerRepository interface {
+	GetBrandFollowers(ctx context.Context, brandID int) ([]favorite.Follower, error)
+}
+
+type MailManager interface {
+	SendMail(subject string, body string, to []string) error
+}
+
+type notifier struct {
+	repository  FollowerRepository
+	mailManager MailManager
+	logger      *zap.Logger
+}
+
+func NewNotifier(repository FollowerRepository, mailManager MailManager, logger *zap.Logger) *notifier {
+	return &notifier{
+		repository:  repository,
+		mailManager: mailManager,
+		logger:      logger,
+	}
+}
+
+func (n *notifier) NotifyNewStore(ctx context.Context, s store.Store) {
+	n.notifyFollowers(ctx, s.Brand.ID, "email.new_store", s.Brand.Name, s.Name, s.Street, s.House)
+}
+
+func (n *notifier) NotifyNewProduct(ctx context.Context, p product.Product) {
+	n.notifyFollowers(ctx, p.Brand.ID, "email.new_product", p.Brand.Name, p.Name)
+}
+
+// notifyFollowers mails every follower of the brand in the background,
+// so a slow SMTP server does not delay the request that triggered it.
+// The mail template is rendered in each follower's locale.
+func (n *notifier) notifyFollowers(ctx context.Context, brandID int, template string, args ...any) {
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		followers, err := n.repository.GetBrandFollowers(ctx, brandID)
+		if err != nil {
+			logging.FromContext(ctx, n.logger).Error("unexpected error when fetching brand followers", zap.Error(err))
+			return
+		}
+
+		for _, follower := range followers {
+			subject, body := i18n.Mail(i18n.Resolve(follower.Locale, i18n.DefaultLocale), template, args...)
+
+			if err := n.mailManager.SendMail(subject, body, []string{follower.Email}); err != nil {
+				logging.FromContext(ctx, n.logger).Error("unexpected error when sending follower notification", zap.Error(err))
+			}
+		}
+	}()
+}
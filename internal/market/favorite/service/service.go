@@ -0,0 +1,107 @@
+package favoriteservice
+
+import (
+	"context"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/brand"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/favorite"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/store"
+	"go.uber.org/zap"
+)
+
+type Repository interface {
+	AddFavoriteStore(ctx context.Context, userID, storeID int) error
+	RemoveFavoriteStore(ctx context.Context, userID, storeID int) error
+	FollowBrand(ctx context.Context, userID, brandID int) error
+	UnfollowBrand(ctx context.Context, userID, brandID int) error
+}
+
+type StoreService interface {
+	GetStore(ctx context.Context, storeID int) (*store.Store, error)
+	GetFavoriteStores(ctx context.Context, userID int) ([]store.StoreSummary, error)
+}
+
+type BrandService interface {
+	GetBrand(ctx context.Context, brandID int) (*brand.Brand, error)
+	GetFollowedBrands(ctx context.Context, userID int) ([]brand.BrandSummary, error)
+}
+
+type service struct {
+	repository   Repository
+	storeService StoreService
+	brandService BrandService
+	logger       *zap.Logger
+}
+
+func New(
+	repository Repository,
+	storeService StoreService,
+	brandService BrandService,
+	logger *zap.Logger,
+) *service {
+	return &service{
+		repository:   repository,
+		storeService: storeService,
+		brandService: brandService,
+		logger:       logger,
+	}
+}
+
+func (s *service) GetFavorites(ctx context.Context, userID int) (*favorite.Favorites, error) {
+	stores, err := s.storeService.GetFavoriteStores(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	brands, err := s.brandService.GetFollowedBrands(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &favorite.Favorites{Stores: stores, Brands: brands}, nil
+}
+
+func (s *service) AddFavoriteStore(ctx context.Context, userID, storeID int) error {
+	if _, err := s.storeService.GetStore(ctx, storeID); err != nil {
+		return err
+	}
+
+	if err := s.repository.AddFavoriteStore(ctx, userID, storeID); err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when adding favorite store", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) RemoveFavoriteStore(ctx context.Context, userID, storeID int) error {
+	if err := s.repository.RemoveFavoriteStore(ctx, userID, storeID); err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when removing favorite store", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) FollowBrand(ctx context.Context, userID, brandID int) error {
+	if _, err := s.brandService.GetBrand(ctx, brandID); err != nil {
+		return err
+	}
+
+	if err := s.repository.FollowBrand(ctx, userID, brandID); err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when following brand", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) UnfollowBrand(ctx context.Context, userID, brandID int) error {
+	if err := s.repository.UnfollowBrand(ctx, userID, brandID); err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when unfollowing brand", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -25,9 +25,9 @@ func New(client *pgxpool.Pool, logger *zap.Logger) *repository {
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]industry.Industry, error) {
-	query := `SELECT id, name FROM business_industries`
+	query := `SELECT id, name FROM business_industries WHERE is_active=true ORDER BY position, id`
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	rows, err := r.client.Query(ctx, query)
 	if err != nil {
@@ -60,10 +60,10 @@ func (r *repository) GetAll(ctx context.Context) ([]industry.Industry, error) {
 func (r *repository) GetByID(ctx context.Context, id int) (*industry.Industry, error) {
 	query := `
         SELECT id, name FROM business_industries
-		WHERE id=$1
+		WHERE id=$1 AND is_active=true
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var industry industry.Industry
 	err := r.client.QueryRow(ctx, query, id).Scan(&industry.ID, &industry.Name)
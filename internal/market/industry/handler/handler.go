@@ -6,14 +6,13 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
-	"github.com/go-playground/validator/v10"
 	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
 	"github.com/xw1nchester/kushfinds-backend/internal/handlers"
 	"github.com/xw1nchester/kushfinds-backend/internal/market/industry"
 	"go.uber.org/zap"
 )
 
-var validate = validator.New()
+var validate = apperror.NewValidator()
 
 type Service interface {
 	GetAll(ctx context.Context) ([]industry.Industry, error)
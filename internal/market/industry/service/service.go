@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
 	"github.com/xw1nchester/kushfinds-backend/internal/market/industry"
 	"github.com/xw1nchester/kushfinds-backend/internal/market/industry/db"
 	"go.uber.org/zap"
@@ -33,7 +34,7 @@ func New(
 func (s *service) GetAll(ctx context.Context) ([]industry.Industry, error) {
 	industries, err := s.repository.GetAll(ctx)
 	if err != nil {
-		s.logger.Error("unexpected error when fetching all industries", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching all industries", zap.Error(err))
 
 		return nil, err
 	}
@@ -48,7 +49,7 @@ func (s *service) GetByID(ctx context.Context, id int) (*industry.Industry, erro
 			return nil, apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when fetching business industry by id", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching business industry by id", zap.Error(err))
 
 		return nil, err
 	}
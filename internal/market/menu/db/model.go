@@ -0,0 +1,5 @@
+package menudb
+
+import "errors"
+
+var ErrMenuItemNotFound = errors.New("menu item not found")
@@ -0,0 +1,400 @@
+package menudb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/menu"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor/postgresql"
+	"go.uber.org/zap"
+)
+
+type repository struct {
+	client *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func New(client *pgxpool.Pool, logger *zap.Logger) *repository {
+	return &repository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// eachMenuItem calls fn for every menu item matching the conditions as the
+// rows are read, stopping at the first error.
+func (r *repository) eachMenuItem(
+	ctx context.Context,
+	conditions []string,
+	args []any,
+	fn func(item menu.Item) error,
+) error {
+	query := `
+		SELECT
+			mi.id,
+			p.id,
+			p.name,
+			COALESCE((
+				SELECT pp.url FROM products_pictures pp
+				WHERE pp.product_id = p.id
+				ORDER BY pp.url
+				LIMIT 1
+			), ''),
+			b.id,
+			b.name,
+			b.logo,
+			ms.id,
+			ms.name,
+			p.strain_type,
+			p.thc_percent,
+			p.cbd_percent,
+			p.is_published,
+			pv.id,
+			pv.weight,
+			pv.unit,
+			mi.price,
+			mi.sale_price,
+			mi.sale_starts_at,
+			mi.sale_ends_at,
+			mi.stock_quantity,
+			mi.in_stock,
+			mi.updated_at
+		FROM stores_menu_items mi
+		JOIN products_variants pv ON mi.product_variant_id = pv.id
+		JOIN products p ON pv.product_id = p.id
+		JOIN brands b ON p.brand_id = b.id
+		LEFT JOIN market_sections ms ON p.market_section_id = ms.id
+	`
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY ms.id, p.name, pv.unit, pv.weight"
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var i menu.Item
+
+		err := rows.Scan(
+			&i.ID,
+			&i.Product.ID,
+			&i.Product.Name,
+			&i.Product.Picture,
+			&i.Product.Brand.ID,
+			&i.Product.Brand.Name,
+			&i.Product.Brand.Logo,
+			&i.Product.MarketSection.ID,
+			&i.Product.MarketSection.Name,
+			&i.Product.StrainType,
+			&i.Product.THCPercent,
+			&i.Product.CBDPercent,
+			&i.Product.IsPublished,
+			&i.Variant.ID,
+			&i.Variant.Weight,
+			&i.Variant.Unit,
+			&i.Price,
+			&i.SalePrice,
+			&i.SaleStartsAt,
+			&i.SaleEndsAt,
+			&i.StockQuantity,
+			&i.InStock,
+			&i.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan row: %v", err)
+		}
+
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("row error: %v", err)
+	}
+
+	return nil
+}
+
+func (r *repository) getMenuItems(
+	ctx context.Context,
+	conditions []string,
+	args ...any,
+) ([]menu.Item, error) {
+	items := make([]menu.Item, 0)
+
+	if err := r.eachMenuItem(ctx, conditions, args, func(item menu.Item) error {
+		items = append(items, item)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func menuConditions(storeID int, filter menu.Filter) ([]string, []any) {
+	conditions := []string{"mi.store_id=$1"}
+	args := []any{storeID}
+
+	if filter.MarketSectionID != 0 {
+		args = append(args, filter.MarketSectionID)
+		conditions = append(
+			conditions,
+			fmt.Sprintf("(p.market_section_id=$%d OR p.market_sub_section_id=$%d)", len(args), len(args)),
+		)
+	}
+
+	if filter.InStock {
+		conditions = append(conditions, "mi.in_stock=true")
+	}
+
+	return conditions, args
+}
+
+func (r *repository) GetStoreMenu(ctx context.Context, storeID int, filter menu.Filter) ([]menu.Item, error) {
+	conditions, args := menuConditions(storeID, filter)
+
+	return r.getMenuItems(ctx, conditions, args...)
+}
+
+// EachStoreMenuItem streams the whole store menu to fn without loading it in memory.
+func (r *repository) EachStoreMenuItem(ctx context.Context, storeID int, fn func(item menu.Item) error) error {
+	conditions, args := menuConditions(storeID, menu.Filter{})
+
+	return r.eachMenuItem(ctx, conditions, args, fn)
+}
+
+func (r *repository) GetPublishedStoreMenu(ctx context.Context, storeID int, filter menu.Filter) ([]menu.Item, error) {
+	conditions, args := menuConditions(storeID, filter)
+	conditions = append(conditions, "p.is_published=true", "b.is_published=true")
+
+	return r.getMenuItems(ctx, conditions, args...)
+}
+
+func (r *repository) GetPublishedMenuItems(ctx context.Context, storeID int, itemIDs []int) ([]menu.Item, error) {
+	return r.getMenuItems(
+		ctx,
+		[]string{"mi.store_id=$1", "mi.id = ANY($2)", "p.is_published=true", "b.is_published=true"},
+		storeID,
+		itemIDs,
+	)
+}
+
+// GetAvailableVariantIDs returns those of the given variants that belong either
+// to a published product of a published brand or to a brand of the user.
+func (r *repository) GetAvailableVariantIDs(ctx context.Context, variantIDs []int, userID int) ([]int, error) {
+	query := `
+		SELECT pv.id
+		FROM products_variants pv
+		JOIN products p ON pv.product_id = p.id
+		JOIN brands b ON p.brand_id = b.id
+		WHERE pv.id = ANY($1)
+		AND ((p.is_published=true AND b.is_published=true) OR b.user_id=$2)
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, variantIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	IDs := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+		IDs = append(IDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return IDs, nil
+}
+
+// GetUndistributedVariantIDs returns those of the given variants whose brand
+// is not distributed in the state and which are not on the store menu yet.
+func (r *repository) GetUndistributedVariantIDs(
+	ctx context.Context,
+	storeID int,
+	stateID int,
+	variantIDs []int,
+) ([]int, error) {
+	query := `
+		SELECT pv.id
+		FROM products_variants pv
+		JOIN products p ON pv.product_id = p.id
+		WHERE pv.id = ANY($3)
+		AND NOT EXISTS (
+			SELECT 1 FROM brands_states bs
+			WHERE bs.brand_id = p.brand_id AND bs.state_id=$2
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM stores_menu_items smi
+			WHERE smi.store_id=$1 AND smi.product_variant_id = pv.id
+		)
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, storeID, stateID, variantIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	IDs := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+		IDs = append(IDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return IDs, nil
+}
+
+// SaveStoreMenu upserts the given items by product variant.
+// If replace is true, items whose variants are not listed are removed.
+// Run it within a transaction to apply all changes atomically.
+func (r *repository) SaveStoreMenu(ctx context.Context, storeID int, items []menu.Item, replace bool) error {
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	if replace {
+		variantIDs := make([]int, len(items))
+		for i, item := range items {
+			variantIDs[i] = item.Variant.ID
+		}
+
+		deleteQuery := "DELETE FROM stores_menu_items WHERE store_id=$1 AND NOT (product_variant_id = ANY($2))"
+		logging.LogSQLQuery(ctx, r.logger, deleteQuery)
+		if _, err := executor.Exec(ctx, deleteQuery, storeID, variantIDs); err != nil {
+			return err
+		}
+	}
+
+	upsertQuery := `
+		INSERT INTO stores_menu_items (store_id, product_variant_id, price, stock_quantity, in_stock, sale_price, sale_starts_at, sale_ends_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (store_id, product_variant_id) DO UPDATE
+		SET
+			price=EXCLUDED.price,
+			stock_quantity=EXCLUDED.stock_quantity,
+			in_stock=EXCLUDED.in_stock,
+			sale_price=EXCLUDED.sale_price,
+			sale_starts_at=EXCLUDED.sale_starts_at,
+			sale_ends_at=EXCLUDED.sale_ends_at,
+			updated_at=NOW()
+	`
+
+	for _, item := range items {
+		logging.LogSQLQuery(ctx, r.logger, upsertQuery)
+		if _, err := executor.Exec(
+			ctx,
+			upsertQuery,
+			storeID,
+			item.Variant.ID,
+			item.Price,
+			item.StockQuantity,
+			item.InStock,
+			item.SalePrice,
+			item.SaleStartsAt,
+			item.SaleEndsAt,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindVariants looks up variants available to the user by product name,
+// brand name (any brand if empty), weight and unit. The result is keyed by
+// the index of the key and contains every matching variant ID.
+func (r *repository) FindVariants(ctx context.Context, keys []menu.VariantKey, userID int) (map[int][]int, error) {
+	products := make([]string, len(keys))
+	brands := make([]string, len(keys))
+	weights := make([]float64, len(keys))
+	units := make([]string, len(keys))
+	for i, k := range keys {
+		products[i] = k.Product
+		brands[i] = k.Brand
+		weights[i] = k.Weight
+		units[i] = k.Unit
+	}
+
+	query := `
+		SELECT k.idx, pv.id
+		FROM unnest($1::text[], $2::text[], $3::numeric[], $4::text[])
+			WITH ORDINALITY AS k(product, brand, weight, unit, idx)
+		JOIN products p ON lower(p.name) = lower(k.product)
+		JOIN brands b ON p.brand_id = b.id AND (k.brand = '' OR lower(b.name) = lower(k.brand))
+		JOIN products_variants pv ON pv.product_id = p.id AND pv.weight = k.weight AND pv.unit::text = k.unit
+		WHERE (p.is_published=true AND b.is_published=true) OR b.user_id=$5
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, products, brands, weights, units, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[int][]int)
+	for rows.Next() {
+		var idx, variantID int
+		if err := rows.Scan(&idx, &variantID); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+		// ordinality starts with 1
+		res[idx-1] = append(res[idx-1], variantID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return res, nil
+}
+
+func (r *repository) DeleteMenuItem(ctx context.Context, storeID, itemID int) error {
+	query := `
+		DELETE FROM stores_menu_items
+		WHERE id=$1 AND store_id=$2
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := r.client.Exec(ctx, query, itemID, storeID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrMenuItemNotFound
+	}
+
+	return nil
+}
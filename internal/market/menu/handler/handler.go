@@ -0,0 +1,400 @@
+package menuhandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	jwtmiddleware "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt/middleware"
+	"github.com/xw1nchester/kushfinds-backend/internal/handlers"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/menu"
+	"go.uber.org/zap"
+)
+
+var validate = apperror.NewValidator()
+
+const maxImportFileSize = 10 << 20
+
+type Service interface {
+	GetUserStoreMenu(ctx context.Context, storeID, userID int, filter menu.Filter) ([]menu.Item, error)
+	ExportUserStoreMenu(ctx context.Context, storeID, userID int, fn func(item menu.Item) error) error
+	SaveStoreMenu(ctx context.Context, storeID, userID int, items []menu.Item, replace bool) ([]menu.Item, error)
+	DeleteMenuItem(ctx context.Context, storeID, itemID, userID int) error
+	ImportStoreMenu(
+		ctx context.Context,
+		storeID int,
+		userID int,
+		rows []menu.ImportRow,
+		replace bool,
+		commit bool,
+	) (*menu.ImportResult, error)
+
+	GetStoreMenu(ctx context.Context, storeID int, filter menu.Filter) ([]menu.Item, error)
+}
+
+type handler struct {
+	service        Service
+	authMiddleware func(http.Handler) http.Handler
+	ageMiddleware  func(http.Handler) http.Handler
+	staticURL      string
+	logger         *zap.Logger
+}
+
+func New(
+	service Service,
+	authMiddleware func(http.Handler) http.Handler,
+	ageMiddleware func(http.Handler) http.Handler,
+	staticURL string,
+	logger *zap.Logger,
+) handlers.Handler {
+	return &handler{
+		service:        service,
+		authMiddleware: authMiddleware,
+		ageMiddleware:  ageMiddleware,
+		staticURL:      staticURL,
+		logger:         logger,
+	}
+}
+
+func (h *handler) Register(router chi.Router) {
+	router.Route("/stores/{store_id}/menu", func(menuRouter chi.Router) {
+		menuRouter.Use(h.authMiddleware, h.ageMiddleware)
+		menuRouter.Get("/", apperror.Middleware(h.getStoreMenuHandler))
+	})
+
+	router.Route("/me/stores/{store_id}/menu", func(privateMenuRouter chi.Router) {
+		privateMenuRouter.Use(h.authMiddleware)
+		privateMenuRouter.Get("/", apperror.Middleware(h.getUserStoreMenuHandler))
+		privateMenuRouter.Put("/", apperror.Middleware(h.replaceStoreMenuHandler))
+		privateMenuRouter.Patch("/", apperror.Middleware(h.updateStoreMenuHandler))
+		privateMenuRouter.Delete("/{id}", apperror.Middleware(h.deleteMenuItemHandler))
+		privateMenuRouter.Post("/import", apperror.Middleware(h.importStoreMenuHandler))
+		privateMenuRouter.Get("/export", apperror.Middleware(h.exportStoreMenuHandler))
+	})
+}
+
+func parseFilter(r *http.Request) (menu.Filter, error) {
+	var filter menu.Filter
+
+	if marketSectionID := r.URL.Query().Get("marketSectionId"); marketSectionID != "" {
+		value, err := strconv.Atoi(marketSectionID)
+		if err != nil {
+			return filter, apperror.NewFieldError("marketSectionId", "field.positive_integer")
+		}
+		filter.MarketSectionID = value
+	}
+
+	if inStock := r.URL.Query().Get("inStock"); inStock != "" {
+		value, err := strconv.ParseBool(inStock)
+		if err != nil {
+			return filter, apperror.NewFieldError("inStock", "field.boolean")
+		}
+		filter.InStock = value
+	}
+
+	return filter, nil
+}
+
+func parseBoolParam(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, apperror.NewFieldError(name, "field.boolean")
+	}
+
+	return parsed, nil
+}
+
+func parseStoreID(r *http.Request) (int, error) {
+	storeID, err := strconv.Atoi(chi.URLParam(r, "store_id"))
+	if err != nil {
+		return 0, apperror.NewFieldError("store_id", "field.positive_integer")
+	}
+	return storeID, nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		marketSectionId	query		int		false	"market section id"
+// @Param		inStock			query		bool	false	"only items that are in stock"
+// @Success	200				{object}	MenuResponse
+// @Failure	400,500			{object}	apperror.AppError
+// @Router		/stores/{store_id}/menu [get]
+func (h *handler) getStoreMenuHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseStoreID(r)
+	if err != nil {
+		return err
+	}
+
+	filter, err := parseFilter(r)
+	if err != nil {
+		return err
+	}
+
+	items, err := h.service.GetStoreMenu(r.Context(), storeID, filter)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewMenuResponse(items, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		marketSectionId	query		int		false	"market section id"
+// @Param		inStock			query		bool	false	"only items that are in stock"
+// @Success	200				{object}	MenuResponse
+// @Failure	400,500			{object}	apperror.AppError
+// @Router		/me/stores/{store_id}/menu [get]
+func (h *handler) getUserStoreMenuHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseStoreID(r)
+	if err != nil {
+		return err
+	}
+
+	filter, err := parseFilter(r)
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	items, err := h.service.GetUserStoreMenu(r.Context(), storeID, userID, filter)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewMenuResponse(items, h.staticURL))
+
+	return nil
+}
+
+func (h *handler) saveStoreMenu(w http.ResponseWriter, r *http.Request, replace bool) error {
+	storeID, err := parseStoreID(r)
+	if err != nil {
+		return err
+	}
+
+	var dto MenuRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	items, err := h.service.SaveStoreMenu(r.Context(), storeID, userID, dto.ToDomain(), replace)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewMenuResponse(items, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Description	Synchronizes the whole menu: listed items are saved, the rest are removed
+// @Param		request	body		MenuRequest	true	"request body"
+// @Success	200		{object}	MenuResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/stores/{store_id}/menu [put]
+func (h *handler) replaceStoreMenuHandler(w http.ResponseWriter, r *http.Request) error {
+	return h.saveStoreMenu(w, r, true)
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Description	Creates or updates listed items, other items stay untouched
+// @Param		request	body		MenuRequest	true	"request body"
+// @Success	200		{object}	MenuResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/stores/{store_id}/menu [patch]
+func (h *handler) updateStoreMenuHandler(w http.ResponseWriter, r *http.Request) error {
+	return h.saveStoreMenu(w, r, false)
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Success	200
+// @Failure	400,404,500	{object}	apperror.AppError
+// @Router		/me/stores/{store_id}/menu/{id} [delete]
+func (h *handler) deleteMenuItemHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseStoreID(r)
+	if err != nil {
+		return err
+	}
+
+	itemID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return apperror.NewFieldError("id", "field.positive_integer")
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	return h.service.DeleteMenuItem(r.Context(), storeID, itemID, userID)
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Description	Validates a CSV or XLSX menu and returns a preview with per-row errors.
+// @Description	If commit is true and every row is valid, the menu is saved in one transaction.
+// @Accept		multipart/form-data
+// @Param		file	formData	file	true	"CSV or XLSX file"
+// @Param		mapping	formData	string	false	"JSON object mapping menu fields to column headers"
+// @Param		commit	query		bool	false	"save the menu if every row is valid"
+// @Param		replace	query		bool	false	"remove items that are not in the file"
+// @Success	200		{object}	ImportResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/stores/{store_id}/menu/import [post]
+func (h *handler) importStoreMenuHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseStoreID(r)
+	if err != nil {
+		return err
+	}
+
+	commit, err := parseBoolParam(r, "commit")
+	if err != nil {
+		return err
+	}
+
+	replace, err := parseBoolParam(r, "replace")
+	if err != nil {
+		return err
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return apperror.NewFieldError("file", "field.file_unreadable", err.Error())
+	}
+	defer file.Close()
+
+	format, err := menu.FormatFromFilename(header.Filename)
+	if err != nil {
+		return apperror.NewFieldError("file", "field.one_of", "csv, xlsx")
+	}
+
+	mapping := menu.Mapping{}
+	if value := r.FormValue("mapping"); value != "" {
+		if err := json.Unmarshal([]byte(value), &mapping); err != nil {
+			return apperror.NewFieldError("mapping", "field.json_object")
+		}
+	}
+
+	records, err := menu.ReadRecords(file, format)
+	if err != nil {
+		logging.FromContext(r.Context(), h.logger).Info("failed to read menu spreadsheet", zap.Error(err))
+		return apperror.NewFieldError("file", "field.file_unreadable", err.Error())
+	}
+
+	rows, err := menu.ParseRecords(records, mapping)
+	if err != nil {
+		return apperror.NewAppError("menu.invalid_import", err.Error())
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	result, err := h.service.ImportStoreMenu(r.Context(), storeID, userID, rows, replace, commit)
+	if err != nil {
+		return err
+	}
+
+	if commit && !result.Committed {
+		render.Status(r, http.StatusUnprocessableEntity)
+	}
+
+	render.JSON(w, r, ImportResponse{Import: *result})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Produce	text/csv
+// @Produce	application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Description	CSV is streamed as the menu is read. XLSX is assembled first and sent once complete.
+// @Param		format	query	string	false	"csv (default) or xlsx"
+// @Success	200
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/stores/{store_id}/menu/export [get]
+func (h *handler) exportStoreMenuHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseStoreID(r)
+	if err != nil {
+		return err
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = menu.FormatCSV
+	}
+
+	contentType, ok := map[string]string{
+		menu.FormatCSV:  "text/csv",
+		menu.FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	}[format]
+	if !ok {
+		return apperror.NewFieldError("format", "field.one_of", "csv, xlsx")
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	// the response starts with the first row, so errors before it are still
+	// reported as usual and later ones can only cut the file short
+	var writer menu.RecordWriter
+	start := func() error {
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=menu-%d.%s", storeID, format))
+
+		var err error
+		if writer, err = menu.NewRecordWriter(w, format); err != nil {
+			return err
+		}
+		return writer.Write(menu.Fields)
+	}
+
+	err = h.service.ExportUserStoreMenu(r.Context(), storeID, userID, func(item menu.Item) error {
+		if writer == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return writer.Write(item.Record())
+	})
+	if err == nil && writer == nil {
+		err = start()
+	}
+	if err != nil {
+		if writer == nil {
+			return err
+		}
+
+		logging.FromContext(r.Context(), h.logger).Error("failed to write menu export", zap.Error(err))
+		return nil
+	}
+
+	if err := writer.Close(); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error("failed to write menu export", zap.Error(err))
+	}
+
+	return nil
+}
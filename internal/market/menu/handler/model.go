@@ -0,0 +1,65 @@
+package menuhandler
+
+import (
+	"time"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/market/menu"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/product"
+	"github.com/xw1nchester/kushfinds-backend/pkg/types"
+)
+
+type MenuItemRequest struct {
+	VariantID     types.IntOrString  `json:"variantId" validate:"required"`
+	Price         types.IntOrString  `json:"price" validate:"min=0"`
+	StockQuantity types.IntOrString  `json:"stockQuantity" validate:"min=0"`
+	InStock       *bool              `json:"inStock" validate:"required"`
+	SalePrice     *types.IntOrString `json:"salePrice" validate:"omitempty,min=0"`
+	SaleStartsAt  *time.Time         `json:"saleStartsAt"`
+	SaleEndsAt    *time.Time         `json:"saleEndsAt"`
+}
+
+type MenuRequest struct {
+	Items []MenuItemRequest `json:"items" validate:"required,dive"`
+}
+
+func (mr *MenuRequest) ToDomain() []menu.Item {
+	items := make([]menu.Item, len(mr.Items))
+	for i, item := range mr.Items {
+		var salePrice *int
+		if item.SalePrice != nil {
+			value := int(*item.SalePrice)
+			salePrice = &value
+		}
+
+		items[i] = menu.Item{
+			Variant:       product.Variant{ID: int(item.VariantID)},
+			Price:         int(item.Price),
+			StockQuantity: int(item.StockQuantity),
+			InStock:       *item.InStock,
+			SalePrice:     salePrice,
+			SaleStartsAt:  item.SaleStartsAt,
+			SaleEndsAt:    item.SaleEndsAt,
+		}
+	}
+	return items
+}
+
+type MenuResponse struct {
+	Items []menu.Item `json:"items"`
+}
+
+func NewMenuResponse(elements []menu.Item, staticURL string) MenuResponse {
+	for i := range elements {
+		if elements[i].Product.Picture != "" {
+			elements[i].Product.Picture = staticURL + "/" + elements[i].Product.Picture
+		}
+		if elements[i].Product.Brand.Logo != "" {
+			elements[i].Product.Brand.Logo = staticURL + "/" + elements[i].Product.Brand.Logo
+		}
+	}
+	return MenuResponse{Items: elements}
+}
+
+type ImportResponse struct {
+	Import menu.ImportResult `json:"import"`
+}
@@ -0,0 +1,65 @@
+package menu
+
+import (
+	"time"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/market/product"
+)
+
+type Item struct {
+	ID            int                    `json:"id"`
+	Product       product.ProductSummary `json:"product"`
+	Variant       product.Variant        `json:"variant"`
+	Price         int                    `json:"price"`
+	SalePrice     *int                   `json:"salePrice"`
+	SaleStartsAt  *time.Time             `json:"saleStartsAt"`
+	SaleEndsAt    *time.Time             `json:"saleEndsAt"`
+	StockQuantity int                    `json:"stockQuantity"`
+	InStock       bool                   `json:"inStock"`
+	IsOnSale      bool                   `json:"isOnSale"`
+	CurrentPrice  int                    `json:"currentPrice"`
+	UpdatedAt     time.Time              `json:"updatedAt"`
+}
+
+// IsSaleActive reports whether the sale price applies at the given moment.
+// A missing bound of the sale window means it is open on that side.
+func (i Item) IsSaleActive(now time.Time) bool {
+	if i.SalePrice == nil {
+		return false
+	}
+	if i.SaleStartsAt != nil && now.Before(*i.SaleStartsAt) {
+		return false
+	}
+	if i.SaleEndsAt != nil && !now.Before(*i.SaleEndsAt) {
+		return false
+	}
+	return true
+}
+
+// PriceAt returns the price a customer pays at the given moment.
+func (i Item) PriceAt(now time.Time) int {
+	if i.IsSaleActive(now) {
+		return *i.SalePrice
+	}
+	return i.Price
+}
+
+type Filter struct {
+	MarketSectionID int
+	InStock         bool
+}
+
+// VariantKey identifies a product variant by names used in spreadsheets.
+type VariantKey struct {
+	Product string
+	Brand   string
+	Weight  float64
+	Unit    string
+}
+
+type ImportResult struct {
+	Rows        []ImportRow `json:"rows"`
+	ValidRows   int         `json:"validRows"`
+	InvalidRows int         `json:"invalidRows"`
+	Committed   bool        `json:"committed"`
+}
@@ -0,0 +1,72 @@
+package menu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemPriceAt(t *testing.T) {
+	now := time.Date(2025, 8, 20, 12, 0, 0, 0, time.UTC)
+	salePrice := 800
+
+	tests := []struct {
+		name             string
+		item             Item
+		expectedIsOnSale bool
+		expectedPrice    int
+	}{
+		{
+			name:          "no sale price",
+			item:          Item{Price: 1000},
+			expectedPrice: 1000,
+		},
+		{
+			name:             "sale without window",
+			item:             Item{Price: 1000, SalePrice: &salePrice},
+			expectedIsOnSale: true,
+			expectedPrice:    800,
+		},
+		{
+			name: "sale within window",
+			item: Item{
+				Price:        1000,
+				SalePrice:    &salePrice,
+				SaleStartsAt: ptrTime(now.Add(-time.Hour)),
+				SaleEndsAt:   ptrTime(now.Add(time.Hour)),
+			},
+			expectedIsOnSale: true,
+			expectedPrice:    800,
+		},
+		{
+			name: "sale not started yet",
+			item: Item{
+				Price:        1000,
+				SalePrice:    &salePrice,
+				SaleStartsAt: ptrTime(now.Add(time.Hour)),
+			},
+			expectedPrice: 1000,
+		},
+		{
+			name: "sale end is exclusive",
+			item: Item{
+				Price:      1000,
+				SalePrice:  &salePrice,
+				SaleEndsAt: ptrTime(now),
+			},
+			expectedPrice: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expectedIsOnSale, tt.item.IsSaleActive(now))
+			require.Equal(t, tt.expectedPrice, tt.item.PriceAt(now))
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
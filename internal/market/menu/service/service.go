@@ -0,0 +1,405 @@
+package menuservice
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/menu"
+	menudb "github.com/xw1nchester/kushfinds-backend/internal/market/menu/db"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/store"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor"
+	"go.uber.org/zap"
+)
+
+type Repository interface {
+	GetStoreMenu(ctx context.Context, storeID int, filter menu.Filter) ([]menu.Item, error)
+	EachStoreMenuItem(ctx context.Context, storeID int, fn func(item menu.Item) error) error
+	GetPublishedStoreMenu(ctx context.Context, storeID int, filter menu.Filter) ([]menu.Item, error)
+	GetPublishedMenuItems(ctx context.Context, storeID int, itemIDs []int) ([]menu.Item, error)
+	GetAvailableVariantIDs(ctx context.Context, variantIDs []int, userID int) ([]int, error)
+	FindVariants(ctx context.Context, keys []menu.VariantKey, userID int) (map[int][]int, error)
+	GetUndistributedVariantIDs(ctx context.Context, storeID, stateID int, variantIDs []int) ([]int, error)
+	SaveStoreMenu(ctx context.Context, storeID int, items []menu.Item, replace bool) error
+	DeleteMenuItem(ctx context.Context, storeID, itemID int) error
+}
+
+var (
+	ErrDuplicateMenuItem = apperror.NewAppError("menu.duplicate_item", "menu items should have unique product variants")
+	ErrInvalidSale       = apperror.NewAppError("menu.invalid_sale", "sale price should be less than price and sale should end after it starts")
+	ErrBrandNotInState   = apperror.NewAppError("menu.brand_not_in_state", "the brand of the product is not distributed in the state of the store")
+)
+
+type StoreService interface {
+	GetUserStore(ctx context.Context, storeID, userID int) (*store.Store, error)
+	GetStore(ctx context.Context, storeID int) (*store.Store, error)
+}
+
+type service struct {
+	repository   Repository
+	storeService StoreService
+	txManager    transactor.Manager
+	logger       *zap.Logger
+}
+
+func New(
+	repository Repository,
+	storeService StoreService,
+	txManager transactor.Manager,
+	logger *zap.Logger,
+) *service {
+	return &service{
+		repository:   repository,
+		storeService: storeService,
+		txManager:    txManager,
+		logger:       logger,
+	}
+}
+
+func setCurrentPrices(items []menu.Item) []menu.Item {
+	now := time.Now()
+	for i := range items {
+		items[i].IsOnSale = items[i].IsSaleActive(now)
+		items[i].CurrentPrice = items[i].PriceAt(now)
+	}
+	return items
+}
+
+func validateSale(item menu.Item) error {
+	if item.SalePrice != nil && *item.SalePrice >= item.Price {
+		return ErrInvalidSale
+	}
+	if item.SaleStartsAt != nil && item.SaleEndsAt != nil && !item.SaleEndsAt.After(*item.SaleStartsAt) {
+		return ErrInvalidSale
+	}
+	return nil
+}
+
+func (s *service) getAvailableVariants(ctx context.Context, variantIDs []int, userID int) (map[int]bool, error) {
+	available := make(map[int]bool)
+	if len(variantIDs) == 0 {
+		return available, nil
+	}
+
+	IDs, err := s.repository.GetAvailableVariantIDs(ctx, variantIDs, userID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching available product variants", zap.Error(err))
+		return nil, err
+	}
+
+	for _, id := range IDs {
+		available[id] = true
+	}
+
+	return available, nil
+}
+
+// getUndistributedVariants returns the variants that can't be newly added to
+// the store menu since their brand is not distributed in the state of the store.
+// Items already on the menu are kept so that a brand narrowing its states
+// does not block editing the rest of the menu.
+func (s *service) getUndistributedVariants(
+	ctx context.Context,
+	existingStore *store.Store,
+	variantIDs []int,
+) (map[int]bool, error) {
+	undistributed := make(map[int]bool)
+	if len(variantIDs) == 0 {
+		return undistributed, nil
+	}
+
+	IDs, err := s.repository.GetUndistributedVariantIDs(ctx, existingStore.ID, existingStore.State.ID, variantIDs)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when checking brand state coverage", zap.Error(err))
+		return nil, err
+	}
+
+	for _, id := range IDs {
+		undistributed[id] = true
+	}
+
+	return undistributed, nil
+}
+
+func (s *service) validateMenuItems(ctx context.Context, existingStore *store.Store, items []menu.Item) error {
+	variantIDs := make([]int, 0, len(items))
+	seen := map[int]bool{}
+	for _, item := range items {
+		if seen[item.Variant.ID] {
+			return ErrDuplicateMenuItem
+		}
+		seen[item.Variant.ID] = true
+		variantIDs = append(variantIDs, item.Variant.ID)
+
+		if err := validateSale(item); err != nil {
+			return err
+		}
+	}
+
+	// unpublished products are available to the brands of the store business
+	available, err := s.getAvailableVariants(ctx, variantIDs, existingStore.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range variantIDs {
+		if !available[id] {
+			return apperror.ErrNotFound
+		}
+	}
+
+	undistributed, err := s.getUndistributedVariants(ctx, existingStore, variantIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(undistributed) > 0 {
+		return ErrBrandNotInState
+	}
+
+	return nil
+}
+
+func (s *service) saveStoreMenu(ctx context.Context, storeID int, items []menu.Item, replace bool) error {
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.repository.SaveStoreMenu(ctx, storeID, items, replace)
+	})
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when saving store menu", zap.Error(err))
+	}
+
+	return err
+}
+
+func (s *service) GetUserStoreMenu(
+	ctx context.Context,
+	storeID int,
+	userID int,
+	filter menu.Filter,
+) ([]menu.Item, error) {
+	if _, err := s.storeService.GetUserStore(ctx, storeID, userID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repository.GetStoreMenu(ctx, storeID, filter)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching store menu", zap.Error(err))
+
+		return nil, err
+	}
+
+	return setCurrentPrices(items), nil
+}
+
+// ExportUserStoreMenu passes the items of the user's store menu to fn one by
+// one as they are read. Errors of fn are returned as is.
+func (s *service) ExportUserStoreMenu(ctx context.Context, storeID, userID int, fn func(item menu.Item) error) error {
+	if _, err := s.storeService.GetUserStore(ctx, storeID, userID); err != nil {
+		return err
+	}
+
+	return s.repository.EachStoreMenuItem(ctx, storeID, fn)
+}
+
+func (s *service) GetStoreMenu(ctx context.Context, storeID int, filter menu.Filter) ([]menu.Item, error) {
+	if _, err := s.storeService.GetStore(ctx, storeID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repository.GetPublishedStoreMenu(ctx, storeID, filter)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching published store menu", zap.Error(err))
+
+		return nil, err
+	}
+
+	return setCurrentPrices(items), nil
+}
+
+// GetStoreMenuItems returns the published items of the store menu among the given ones.
+func (s *service) GetStoreMenuItems(ctx context.Context, storeID int, itemIDs []int) ([]menu.Item, error) {
+	if len(itemIDs) == 0 {
+		return []menu.Item{}, nil
+	}
+
+	items, err := s.repository.GetPublishedMenuItems(ctx, storeID, itemIDs)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching store menu items", zap.Error(err))
+
+		return nil, err
+	}
+
+	return setCurrentPrices(items), nil
+}
+
+// SaveStoreMenu updates the menu items of the store in one transaction.
+// If replace is true, the menu is synchronized with the given items.
+func (s *service) SaveStoreMenu(
+	ctx context.Context,
+	storeID int,
+	userID int,
+	items []menu.Item,
+	replace bool,
+) ([]menu.Item, error) {
+	existingStore, err := s.storeService.GetUserStore(ctx, storeID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateMenuItems(ctx, existingStore, items); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveStoreMenu(ctx, storeID, items, replace); err != nil {
+		return nil, err
+	}
+
+	return s.GetUserStoreMenu(ctx, storeID, userID, menu.Filter{})
+}
+
+func (s *service) DeleteMenuItem(ctx context.Context, storeID, itemID, userID int) error {
+	if _, err := s.storeService.GetUserStore(ctx, storeID, userID); err != nil {
+		return err
+	}
+
+	if err := s.repository.DeleteMenuItem(ctx, storeID, itemID); err != nil {
+		if errors.Is(err, menudb.ErrMenuItemNotFound) {
+			return apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when deleting menu item", zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+// resolveVariants sets variant IDs of rows identified by product names
+// and checks that every referenced variant may be sold by the store.
+func (s *service) resolveVariants(ctx context.Context, existingStore *store.Store, rows []menu.ImportRow) error {
+	keys := make([]menu.VariantKey, 0)
+	keyRows := make([]int, 0)
+	for i, row := range rows {
+		if row.Item.Variant.ID == 0 && row.IsValid() {
+			keys = append(keys, menu.VariantKey{
+				Product: row.Item.Product.Name,
+				Brand:   row.Item.Product.Brand.Name,
+				Weight:  row.Item.Variant.Weight,
+				Unit:    row.Item.Variant.Unit,
+			})
+			keyRows = append(keyRows, i)
+		}
+	}
+
+	if len(keys) > 0 {
+		found, err := s.repository.FindVariants(ctx, keys, existingStore.UserID)
+		if err != nil {
+			logging.FromContext(ctx, s.logger).Error("unexpected error when finding product variants", zap.Error(err))
+			return err
+		}
+
+		for k, i := range keyRows {
+			switch len(found[k]) {
+			case 0:
+				rows[i].AddError("product variant is not found")
+			case 1:
+				rows[i].Item.Variant.ID = found[k][0]
+			default:
+				rows[i].AddError("product is ambiguous, specify %s", menu.FieldBrand)
+			}
+		}
+	}
+
+	variantIDs := make([]int, 0)
+	for _, row := range rows {
+		if row.Item.Variant.ID != 0 {
+			variantIDs = append(variantIDs, row.Item.Variant.ID)
+		}
+	}
+
+	available, err := s.getAvailableVariants(ctx, variantIDs, existingStore.UserID)
+	if err != nil {
+		return err
+	}
+
+	undistributed, err := s.getUndistributedVariants(ctx, existingStore, variantIDs)
+	if err != nil {
+		return err
+	}
+
+	for i, row := range rows {
+		if row.Item.Variant.ID == 0 {
+			continue
+		}
+		if !available[row.Item.Variant.ID] {
+			rows[i].AddError("product variant is not found")
+		} else if undistributed[row.Item.Variant.ID] {
+			rows[i].AddError("the brand of the product is not distributed in the state of the store")
+		}
+	}
+
+	return nil
+}
+
+// ImportStoreMenu validates every parsed row and returns them with their errors.
+// If commit is true and all rows are valid, the menu is saved in one transaction.
+func (s *service) ImportStoreMenu(
+	ctx context.Context,
+	storeID int,
+	userID int,
+	rows []menu.ImportRow,
+	replace bool,
+	commit bool,
+) (*menu.ImportResult, error) {
+	existingStore, err := s.storeService.GetUserStore(ctx, storeID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.resolveVariants(ctx, existingStore, rows); err != nil {
+		return nil, err
+	}
+
+	seen := map[int]int{}
+	for i, row := range rows {
+		if err := validateSale(row.Item); err != nil {
+			rows[i].AddError("%s", err.Error())
+		}
+
+		if row.Item.Variant.ID == 0 {
+			continue
+		}
+
+		if duplicate, ok := seen[row.Item.Variant.ID]; ok {
+			rows[i].AddError("product variant is duplicated in row %d", duplicate)
+			continue
+		}
+		seen[row.Item.Variant.ID] = row.Row
+	}
+
+	result := menu.ImportResult{Rows: rows}
+	items := make([]menu.Item, 0, len(rows))
+	for _, row := range rows {
+		if row.IsValid() {
+			result.ValidRows++
+			items = append(items, row.Item)
+		} else {
+			result.InvalidRows++
+		}
+	}
+
+	if !commit || result.InvalidRows > 0 {
+		return &result, nil
+	}
+
+	if err := s.saveStoreMenu(ctx, storeID, items, replace); err != nil {
+		return nil, err
+	}
+
+	result.Committed = true
+
+	return &result, nil
+}
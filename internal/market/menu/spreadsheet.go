@@ -0,0 +1,369 @@
+package menu
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+const (
+	FieldVariantID     = "variantId"
+	FieldBrand         = "brand"
+	FieldProduct       = "product"
+	FieldWeight        = "weight"
+	FieldUnit          = "unit"
+	FieldPrice         = "price"
+	FieldSalePrice     = "salePrice"
+	FieldSaleStartsAt  = "saleStartsAt"
+	FieldSaleEndsAt    = "saleEndsAt"
+	FieldStockQuantity = "stockQuantity"
+	FieldInStock       = "inStock"
+)
+
+// Fields are the columns of an exported menu in their order.
+var Fields = []string{
+	FieldVariantID,
+	FieldBrand,
+	FieldProduct,
+	FieldWeight,
+	FieldUnit,
+	FieldPrice,
+	FieldSalePrice,
+	FieldSaleStartsAt,
+	FieldSaleEndsAt,
+	FieldStockQuantity,
+	FieldInStock,
+}
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported spreadsheet format")
+	ErrEmptySpreadsheet  = errors.New("spreadsheet has no header row")
+)
+
+// Mapping maps menu fields to spreadsheet column headers.
+// Fields that are not mapped are looked up by their own name.
+type Mapping map[string]string
+
+type ImportRow struct {
+	Row    int      `json:"row"`
+	Item   Item     `json:"item"`
+	Errors []string `json:"errors"`
+}
+
+func (r *ImportRow) IsValid() bool {
+	return len(r.Errors) == 0
+}
+
+func (r *ImportRow) AddError(format string, args ...any) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+func FormatFromFilename(filename string) (string, error) {
+	switch strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), ".")) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// ReadRecords reads all rows of a CSV file or of the first sheet of a XLSX file.
+func ReadRecords(r io.Reader, format string) ([][]string, error) {
+	switch format {
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		return reader.ReadAll()
+	case FormatXLSX:
+		f, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, ErrEmptySpreadsheet
+		}
+		return f.GetRows(sheets[0])
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+func columnIndexes(header []string, mapping Mapping) map[string]int {
+	positions := make(map[string]int, len(header))
+	for i, h := range header {
+		positions[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	indexes := make(map[string]int)
+	for _, field := range Fields {
+		column := field
+		if mapped, ok := mapping[field]; ok && mapped != "" {
+			column = mapped
+		}
+		if i, ok := positions[strings.ToLower(strings.TrimSpace(column))]; ok {
+			indexes[field] = i
+		}
+	}
+
+	return indexes
+}
+
+func parseTime(value string) (*time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown time format")
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "y":
+		return true, nil
+	case "no", "n":
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// ParseRecords converts spreadsheet records into menu items.
+// The first record is treated as a header. Every row is validated on its own,
+// so the returned rows carry their errors instead of failing the whole import.
+func ParseRecords(records [][]string, mapping Mapping) ([]ImportRow, error) {
+	if len(records) == 0 {
+		return nil, ErrEmptySpreadsheet
+	}
+
+	indexes := columnIndexes(records[0], mapping)
+
+	if _, ok := indexes[FieldPrice]; !ok {
+		return nil, fmt.Errorf("column for %s is not found", FieldPrice)
+	}
+
+	if _, ok := indexes[FieldVariantID]; !ok {
+		for _, field := range []string{FieldProduct, FieldWeight, FieldUnit} {
+			if _, ok := indexes[field]; !ok {
+				return nil, fmt.Errorf("column for %s or %s is not found", FieldVariantID, field)
+			}
+		}
+	}
+
+	rows := make([]ImportRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		value := func(field string) string {
+			index, ok := indexes[field]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+
+		empty := true
+		for _, v := range record {
+			if strings.TrimSpace(v) != "" {
+				empty = false
+				break
+			}
+		}
+		if empty {
+			continue
+		}
+
+		// the header is the first row of the spreadsheet
+		row := ImportRow{Row: i + 2, Item: Item{InStock: true}, Errors: make([]string, 0)}
+
+		if v := value(FieldVariantID); v != "" {
+			id, err := strconv.Atoi(v)
+			if err != nil || id <= 0 {
+				row.AddError("%s should be positive integer", FieldVariantID)
+			}
+			row.Item.Variant.ID = id
+		}
+
+		row.Item.Product.Brand.Name = value(FieldBrand)
+		row.Item.Product.Name = value(FieldProduct)
+		row.Item.Variant.Unit = strings.ToLower(value(FieldUnit))
+
+		if v := value(FieldWeight); v != "" {
+			weight, err := strconv.ParseFloat(v, 64)
+			if err != nil || weight <= 0 {
+				row.AddError("%s should be positive number", FieldWeight)
+			}
+			row.Item.Variant.Weight = weight
+		}
+
+		if row.Item.Variant.ID == 0 &&
+			(row.Item.Product.Name == "" || row.Item.Variant.Weight == 0 || row.Item.Variant.Unit == "") {
+			row.AddError("%s or %s, %s and %s are required", FieldVariantID, FieldProduct, FieldWeight, FieldUnit)
+		}
+
+		price, err := strconv.Atoi(value(FieldPrice))
+		if err != nil || price < 0 {
+			row.AddError("%s should be non-negative integer", FieldPrice)
+		}
+		row.Item.Price = price
+
+		if v := value(FieldSalePrice); v != "" {
+			salePrice, err := strconv.Atoi(v)
+			if err != nil || salePrice < 0 {
+				row.AddError("%s should be non-negative integer", FieldSalePrice)
+			}
+			row.Item.SalePrice = &salePrice
+		}
+
+		if v := value(FieldSaleStartsAt); v != "" {
+			t, err := parseTime(v)
+			if err != nil {
+				row.AddError("%s should be a date or RFC 3339 time", FieldSaleStartsAt)
+			}
+			row.Item.SaleStartsAt = t
+		}
+
+		if v := value(FieldSaleEndsAt); v != "" {
+			t, err := parseTime(v)
+			if err != nil {
+				row.AddError("%s should be a date or RFC 3339 time", FieldSaleEndsAt)
+			}
+			row.Item.SaleEndsAt = t
+		}
+
+		if v := value(FieldStockQuantity); v != "" {
+			quantity, err := strconv.Atoi(v)
+			if err != nil || quantity < 0 {
+				row.AddError("%s should be non-negative integer", FieldStockQuantity)
+			}
+			row.Item.StockQuantity = quantity
+		}
+
+		if v := value(FieldInStock); v != "" {
+			inStock, err := parseBool(v)
+			if err != nil {
+				row.AddError("%s should be boolean", FieldInStock)
+			}
+			row.Item.InStock = inStock
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// Record converts the item into an exported spreadsheet row ordered as Fields.
+func (i Item) Record() []string {
+	optionalInt := func(v *int) string {
+		if v == nil {
+			return ""
+		}
+		return strconv.Itoa(*v)
+	}
+	optionalTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	return []string{
+		strconv.Itoa(i.Variant.ID),
+		i.Product.Brand.Name,
+		i.Product.Name,
+		strconv.FormatFloat(i.Variant.Weight, 'f', -1, 64),
+		i.Variant.Unit,
+		strconv.Itoa(i.Price),
+		optionalInt(i.SalePrice),
+		optionalTime(i.SaleStartsAt),
+		optionalTime(i.SaleEndsAt),
+		strconv.Itoa(i.StockQuantity),
+		strconv.FormatBool(i.InStock),
+	}
+}
+
+type RecordWriter interface {
+	Write(record []string) error
+	Close() error
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+// Write buffers the record, the buffer goes to the output whenever it fills up.
+func (w *csvWriter) Write(record []string) error {
+	return w.writer.Write(record)
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type xlsxWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func (w *xlsxWriter) Write(record []string) error {
+	w.row++
+
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+
+	values := make([]any, len(record))
+	for i, v := range record {
+		values[i] = v
+	}
+
+	return w.stream.SetRow(cell, values)
+}
+
+func (w *xlsxWriter) Close() error {
+	defer w.file.Close()
+
+	if err := w.stream.Flush(); err != nil {
+		return err
+	}
+
+	return w.file.Write(w.out)
+}
+
+// NewRecordWriter returns a writer producing a spreadsheet of the given format.
+// CSV goes to out as it is written. XLSX is a zip archive that can only be
+// produced whole, so its rows are kept by excelize (in memory, then in a
+// temporary file for large sheets) and written to out on Close.
+func NewRecordWriter(out io.Writer, format string) (RecordWriter, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{writer: csv.NewWriter(out)}, nil
+	case FormatXLSX:
+		f := excelize.NewFile()
+		stream, err := f.NewStreamWriter(f.GetSheetName(0))
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return &xlsxWriter{out: out, file: f, stream: stream}, nil
+	}
+	return nil, ErrUnsupportedFormat
+}
@@ -0,0 +1,95 @@
+package menu
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecords(t *testing.T) {
+	records := [][]string{
+		{"Product Name", "Brand", "Weight", "Unit", "Price ($)", "Stock", "Available"},
+		{"Blue Dream", "Kush Co", "3.5", "G", "4000", "10", "yes"},
+		{"", "", "", "", "", "", ""},
+		{"Blue Dream", "", "7", "g", "abc", "-1", "maybe"},
+		{"", "", "", "", "100"},
+	}
+
+	mapping := Mapping{
+		FieldProduct:       "product name",
+		FieldPrice:         "Price ($)",
+		FieldStockQuantity: "Stock",
+		FieldInStock:       "Available",
+	}
+
+	rows, err := ParseRecords(records, mapping)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	require.Equal(t, 2, rows[0].Row)
+	require.True(t, rows[0].IsValid())
+	require.Equal(t, "Blue Dream", rows[0].Item.Product.Name)
+	require.Equal(t, "Kush Co", rows[0].Item.Product.Brand.Name)
+	require.Equal(t, 3.5, rows[0].Item.Variant.Weight)
+	require.Equal(t, "g", rows[0].Item.Variant.Unit)
+	require.Equal(t, 4000, rows[0].Item.Price)
+	require.Equal(t, 10, rows[0].Item.StockQuantity)
+	require.True(t, rows[0].Item.InStock)
+
+	require.Equal(t, 4, rows[1].Row)
+	require.Len(t, rows[1].Errors, 3)
+
+	require.Equal(t, 5, rows[2].Row)
+	require.Len(t, rows[2].Errors, 1)
+}
+
+func TestParseRecordsMissingColumns(t *testing.T) {
+	_, err := ParseRecords([][]string{{"product", "weight", "unit"}}, nil)
+	require.Error(t, err)
+
+	_, err = ParseRecords([][]string{{"product", "price"}}, nil)
+	require.Error(t, err)
+
+	_, err = ParseRecords(nil, nil)
+	require.ErrorIs(t, err, ErrEmptySpreadsheet)
+}
+
+func TestRecordsRoundTrip(t *testing.T) {
+	salePrice := 3000
+	item := Item{
+		Price:         4000,
+		SalePrice:     &salePrice,
+		StockQuantity: 5,
+		InStock:       false,
+	}
+	item.Variant.ID = 7
+	item.Variant.Weight = 3.5
+	item.Variant.Unit = "g"
+	item.Product.Name = "Blue Dream"
+
+	for _, format := range []string{FormatCSV, FormatXLSX} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			writer, err := NewRecordWriter(&buf, format)
+			require.NoError(t, err)
+			require.NoError(t, writer.Write(Fields))
+			require.NoError(t, writer.Write(item.Record()))
+			require.NoError(t, writer.Close())
+
+			records, err := ReadRecords(&buf, format)
+			require.NoError(t, err)
+
+			rows, err := ParseRecords(records, nil)
+			require.NoError(t, err)
+			require.Len(t, rows, 1)
+			require.True(t, rows[0].IsValid(), rows[0].Errors)
+			require.Equal(t, item.Variant, rows[0].Item.Variant)
+			require.Equal(t, item.Price, rows[0].Item.Price)
+			require.Equal(t, salePrice, *rows[0].Item.SalePrice)
+			require.Equal(t, item.StockQuantity, rows[0].Item.StockQuantity)
+			require.False(t, rows[0].Item.InStock)
+		})
+	}
+}
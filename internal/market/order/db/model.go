@@ -0,0 +1,11 @@
+package orderdb
+
+import "errors"
+
+var (
+	ErrCartNotFound       = errors.New("cart not found")
+	ErrOrderNotFound      = errors.New("order not found")
+	ErrOrderStatusChanged = errors.New("order status has been changed")
+	ErrNotEnoughStock     = errors.New("not enough stock")
+	ErrCartChanged        = errors.New("cart has been changed")
+)
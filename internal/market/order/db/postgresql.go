@@ -0,0 +1,636 @@
+package orderdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/order"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/promotion"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor/postgresql"
+	"go.uber.org/zap"
+)
+
+type repository struct {
+	client *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func New(client *pgxpool.Pool, logger *zap.Logger) *repository {
+	return &repository{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (r *repository) getCarts(ctx context.Context, condition string, args ...any) ([]order.Cart, error) {
+	query := fmt.Sprintf(`
+		SELECT c.id, c.user_id, s.id, s.name, s.banner, c.updated_at
+		FROM carts c
+		JOIN stores s ON c.store_id = s.id
+		WHERE %s
+		ORDER BY c.updated_at DESC
+	`, condition)
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	carts := make([]order.Cart, 0)
+	cartIDs := make([]int, 0)
+	for rows.Next() {
+		var c order.Cart
+
+		if err := rows.Scan(
+			&c.ID,
+			&c.UserID,
+			&c.Store.ID,
+			&c.Store.Name,
+			&c.Store.Banner,
+			&c.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+
+		c.Items = make([]order.CartItem, 0)
+		carts = append(carts, c)
+		cartIDs = append(cartIDs, c.ID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	if len(carts) == 0 {
+		return carts, nil
+	}
+
+	itemsQuery := `
+		SELECT cart_id, menu_item_id, quantity
+		FROM carts_items
+		WHERE cart_id = ANY($1)
+		ORDER BY menu_item_id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, itemsQuery)
+
+	rows, err = r.client.Query(ctx, itemsQuery, cartIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	indexes := make(map[int]int, len(carts))
+	for i, c := range carts {
+		indexes[c.ID] = i
+	}
+
+	for rows.Next() {
+		var cartID int
+		var item order.CartItem
+		if err := rows.Scan(&cartID, &item.MenuItemID, &item.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+		carts[indexes[cartID]].Items = append(carts[indexes[cartID]].Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return carts, nil
+}
+
+func (r *repository) GetUserCarts(ctx context.Context, userID int) ([]order.Cart, error) {
+	return r.getCarts(ctx, "c.user_id=$1", userID)
+}
+
+func (r *repository) GetCart(ctx context.Context, userID, storeID int) (*order.Cart, error) {
+	carts, err := r.getCarts(ctx, "c.user_id=$1 AND c.store_id=$2", userID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(carts) == 0 {
+		return nil, ErrCartNotFound
+	}
+
+	return &carts[0], nil
+}
+
+func (r *repository) SetCartItem(ctx context.Context, userID, storeID, menuItemID, quantity int) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	cartQuery := `
+		INSERT INTO carts (user_id, store_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, store_id) DO UPDATE SET updated_at=NOW()
+		RETURNING id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, cartQuery)
+
+	var cartID int
+	if err = tx.QueryRow(ctx, cartQuery, userID, storeID).Scan(&cartID); err != nil {
+		return err
+	}
+
+	itemQuery := `
+		INSERT INTO carts_items (cart_id, menu_item_id, quantity)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (cart_id, menu_item_id) DO UPDATE SET quantity=EXCLUDED.quantity
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, itemQuery)
+
+	if _, err = tx.Exec(ctx, itemQuery, cartID, menuItemID, quantity); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *repository) DeleteCartItem(ctx context.Context, userID, storeID, menuItemID int) error {
+	query := `
+		DELETE FROM carts_items ci
+		USING carts c
+		WHERE ci.cart_id = c.id AND c.user_id=$1 AND c.store_id=$2 AND ci.menu_item_id=$3
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	_, err := r.client.Exec(ctx, query, userID, storeID, menuItemID)
+
+	return err
+}
+
+func (r *repository) DeleteCart(ctx context.Context, userID, storeID int) error {
+	query := `
+		DELETE FROM carts
+		WHERE user_id=$1 AND store_id=$2
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	_, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, userID, storeID)
+
+	return err
+}
+
+// DeleteOrderedItems removes the ordered items from the cart and the cart
+// once it is empty. Items added after the cart was read stay, items changed
+// or removed in the meantime fail the checkout.
+func (r *repository) DeleteOrderedItems(ctx context.Context, cartID int, items []order.CartItem) error {
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	// the cart row lock makes concurrent SetCartItem wait for the checkout
+	lockQuery := `SELECT id FROM carts WHERE id=$1 FOR UPDATE`
+
+	logging.LogSQLQuery(ctx, r.logger, lockQuery)
+
+	var id int
+	if err := executor.QueryRow(ctx, lockQuery, cartID).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCartNotFound
+		}
+		return err
+	}
+
+	menuItemIDs := make([]int, len(items))
+	quantities := make([]int, len(items))
+	for i, item := range items {
+		menuItemIDs[i] = item.MenuItemID
+		quantities[i] = item.Quantity
+	}
+
+	itemsQuery := `
+		DELETE FROM carts_items
+		WHERE cart_id=$1 AND (menu_item_id, quantity) IN (
+			SELECT * FROM unnest($2::int[], $3::int[])
+		)
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, itemsQuery)
+
+	tag, err := executor.Exec(ctx, itemsQuery, cartID, menuItemIDs, quantities)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() != int64(len(items)) {
+		return ErrCartChanged
+	}
+
+	cartQuery := `
+		DELETE FROM carts c
+		WHERE c.id=$1 AND NOT EXISTS (SELECT 1 FROM carts_items ci WHERE ci.cart_id = c.id)
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, cartQuery)
+
+	_, err = executor.Exec(ctx, cartQuery, cartID)
+
+	return err
+}
+
+// ReserveStock takes the ordered quantities off the store stock. Every row
+// is locked by its update and checked in the same statement, so concurrent
+// checkouts can not sell more than there is.
+func (r *repository) ReserveStock(ctx context.Context, storeID int, items []order.CartItem) error {
+	query := `
+		UPDATE stores_menu_items
+		SET stock_quantity = stock_quantity - $3
+		WHERE id=$1 AND store_id=$2 AND in_stock AND stock_quantity >= $3
+	`
+
+	for _, item := range items {
+		logging.LogSQLQuery(ctx, r.logger, query)
+
+		tag, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, item.MenuItemID, storeID, item.Quantity)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return ErrNotEnoughStock
+		}
+	}
+
+	return nil
+}
+
+// ReleaseStock returns the quantities of the order to the store stock.
+func (r *repository) ReleaseStock(ctx context.Context, orderID int) error {
+	query := `
+		UPDATE stores_menu_items mi
+		SET stock_quantity = mi.stock_quantity + oi.quantity
+		FROM orders_items oi
+		JOIN orders o ON oi.order_id = o.id
+		WHERE oi.order_id=$1 AND mi.store_id = o.store_id AND mi.product_variant_id = oi.product_variant_id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	_, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, orderID)
+
+	return err
+}
+
+func (r *repository) CreateOrder(ctx context.Context, data order.Order) (int, error) {
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	query := `
+		INSERT INTO orders (user_id, store_id, status, fulfillment, delivery_address, delivery_latitude, delivery_longitude, items_price, discount_price, delivery_price, total_price, coupon_code, comment, store_name, customer_name, customer_email)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, s.name, concat_ws(' ', u.first_name, u.last_name), u.email
+		FROM stores s, users u
+		WHERE s.id=$2 AND u.id=$1
+		RETURNING id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	var orderID int
+	if err := executor.QueryRow(
+		ctx,
+		query,
+		data.UserID,
+		data.Store.ID,
+		data.Status,
+		data.Fulfillment,
+		data.DeliveryAddress,
+		data.DeliveryLatitude,
+		data.DeliveryLongitude,
+		data.ItemsPrice,
+		data.DiscountPrice,
+		data.DeliveryPrice,
+		data.TotalPrice,
+		data.CouponCode,
+		data.Comment,
+	).Scan(&orderID); err != nil {
+		return 0, err
+	}
+
+	itemQuery := `
+		INSERT INTO orders_items (order_id, product_variant_id, product_id, product_name, brand_name, weight, unit, price, quantity)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	for _, item := range data.Items {
+		logging.LogSQLQuery(ctx, r.logger, itemQuery)
+		if _, err := executor.Exec(
+			ctx,
+			itemQuery,
+			orderID,
+			item.VariantID,
+			item.ProductID,
+			item.ProductName,
+			item.BrandName,
+			item.Weight,
+			item.Unit,
+			item.Price,
+			item.Quantity,
+		); err != nil {
+			return 0, err
+		}
+	}
+
+	promotionQuery := `
+		INSERT INTO orders_promotions (order_id, promotion_id, name, amount)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	for _, p := range data.Promotions {
+		logging.LogSQLQuery(ctx, r.logger, promotionQuery)
+		if _, err := executor.Exec(ctx, promotionQuery, orderID, p.PromotionID, p.Name, p.Amount); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := r.addStatusChange(ctx, orderID, data.Status); err != nil {
+		return 0, err
+	}
+
+	return orderID, nil
+}
+
+func (r *repository) addStatusChange(ctx context.Context, orderID int, status string) error {
+	query := `
+		INSERT INTO orders_status_history (order_id, status)
+		VALUES ($1, $2)
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	_, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, orderID, status)
+
+	return err
+}
+
+// UpdateOrderStatus moves the order to a new status only if it still has
+// the expected one, so concurrent updates can not skip the state machine.
+func (r *repository) UpdateOrderStatus(ctx context.Context, orderID int, from, to, cancelReason string) error {
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	query := `
+		UPDATE orders
+		SET status=$3, cancel_reason=$4, updated_at=NOW()
+		WHERE id=$1 AND status=$2
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := executor.Exec(ctx, query, orderID, from, to, cancelReason)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrOrderStatusChanged
+	}
+
+	return r.addStatusChange(ctx, orderID, to)
+}
+
+func (r *repository) GetOrderByID(ctx context.Context, id int) (*order.Order, error) {
+	query := `
+		SELECT
+			o.id,
+			COALESCE(o.user_id, 0),
+			COALESCE(b.user_id, 0),
+			COALESCE(o.store_id, 0),
+			o.store_name,
+			COALESCE(s.banner, ''),
+			o.customer_name,
+			o.customer_email,
+			o.status,
+			o.fulfillment,
+			o.delivery_address,
+			o.delivery_latitude,
+			o.delivery_longitude,
+			o.items_price,
+			o.discount_price,
+			o.delivery_price,
+			o.total_price,
+			o.coupon_code,
+			o.comment,
+			o.cancel_reason,
+			o.created_at,
+			o.updated_at
+		FROM orders o
+		LEFT JOIN stores s ON o.store_id = s.id
+		LEFT JOIN brands b ON s.brand_id = b.id
+		WHERE o.id=$1
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	var o order.Order
+	if err := r.client.QueryRow(ctx, query, id).Scan(
+		&o.ID,
+		&o.UserID,
+		&o.StoreOwnerID,
+		&o.Store.ID,
+		&o.Store.Name,
+		&o.Store.Banner,
+		&o.Customer.Name,
+		&o.Customer.Email,
+		&o.Status,
+		&o.Fulfillment,
+		&o.DeliveryAddress,
+		&o.DeliveryLatitude,
+		&o.DeliveryLongitude,
+		&o.ItemsPrice,
+		&o.DiscountPrice,
+		&o.DeliveryPrice,
+		&o.TotalPrice,
+		&o.CouponCode,
+		&o.Comment,
+		&o.CancelReason,
+		&o.CreatedAt,
+		&o.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	itemsQuery := `
+		SELECT id, product_id, product_variant_id, product_name, brand_name, weight, unit, price, quantity
+		FROM orders_items
+		WHERE order_id = $1
+		ORDER BY id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, itemsQuery)
+
+	rows, err := r.client.Query(ctx, itemsQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	o.Items = make([]order.Item, 0)
+	for rows.Next() {
+		var i order.Item
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.VariantID,
+			&i.ProductName,
+			&i.BrandName,
+			&i.Weight,
+			&i.Unit,
+			&i.Price,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		o.Items = append(o.Items, i)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// promotions deleted since the checkout keep their name and amount
+	promotionsQuery := `
+		SELECT COALESCE(promotion_id, 0), name, amount
+		FROM orders_promotions
+		WHERE order_id = $1
+		ORDER BY id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, promotionsQuery)
+
+	rows, err = r.client.Query(ctx, promotionsQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	o.Promotions = make([]promotion.Applied, 0)
+	for rows.Next() {
+		var p promotion.Applied
+		if err := rows.Scan(&p.PromotionID, &p.Name, &p.Amount); err != nil {
+			return nil, err
+		}
+		o.Promotions = append(o.Promotions, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	historyQuery := `
+		SELECT status, created_at
+		FROM orders_status_history
+		WHERE order_id = $1
+		ORDER BY id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, historyQuery)
+
+	rows, err = r.client.Query(ctx, historyQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	o.History = make([]order.StatusChange, 0)
+	for rows.Next() {
+		var c order.StatusChange
+		if err := rows.Scan(&c.Status, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		o.History = append(o.History, c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+func (r *repository) getOrdersSummary(
+	ctx context.Context,
+	conditions []string,
+	args ...any,
+) ([]order.OrderSummary, error) {
+	query := `
+		SELECT o.id, COALESCE(o.store_id, 0), o.store_name, COALESCE(s.banner, ''), o.status, o.fulfillment, o.total_price, o.created_at, o.updated_at
+		FROM orders o
+		LEFT JOIN stores s ON o.store_id = s.id
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY o.id DESC
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := make([]order.OrderSummary, 0)
+	for rows.Next() {
+		var o order.OrderSummary
+
+		if err := rows.Scan(
+			&o.ID,
+			&o.Store.ID,
+			&o.Store.Name,
+			&o.Store.Banner,
+			&o.Status,
+			&o.Fulfillment,
+			&o.TotalPrice,
+			&o.CreatedAt,
+			&o.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+
+		orders = append(orders, o)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return orders, nil
+}
+
+func ordersConditions(column string, id int, filter order.Filter) ([]string, []any) {
+	conditions := []string{column + "=$1"}
+	args := []any{id}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("o.status=$%d", len(args)))
+	}
+
+	return conditions, args
+}
+
+func (r *repository) GetUserOrders(ctx context.Context, userID int, filter order.Filter) ([]order.OrderSummary, error) {
+	conditions, args := ordersConditions("o.user_id", userID, filter)
+
+	return r.getOrdersSummary(ctx, conditions, args...)
+}
+
+func (r *repository) GetStoreOrders(ctx context.Context, storeID int, filter order.Filter) ([]order.OrderSummary, error) {
+	conditions, args := ordersConditions("o.store_id", storeID, filter)
+
+	return r.getOrdersSummary(ctx, conditions, args...)
+}
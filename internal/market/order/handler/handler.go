@@ -0,0 +1,437 @@
+package orderhandler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	jwtmiddleware "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt/middleware"
+	"github.com/xw1nchester/kushfinds-backend/internal/handlers"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/order"
+	"go.uber.org/zap"
+)
+
+var validate = apperror.NewValidator()
+
+type Service interface {
+	GetUserCarts(ctx context.Context, userID int) ([]order.Cart, error)
+	GetCart(ctx context.Context, userID, storeID int) (*order.Cart, error)
+	SetCartItem(ctx context.Context, userID, storeID, menuItemID, quantity int) (*order.Cart, error)
+	DeleteCartItem(ctx context.Context, userID, storeID, menuItemID int) (*order.Cart, error)
+	DeleteCart(ctx context.Context, userID, storeID int) error
+	Checkout(ctx context.Context, data order.Checkout) (*order.Order, error)
+
+	GetUserOrders(ctx context.Context, userID int, filter order.Filter) ([]order.OrderSummary, error)
+	GetUserOrder(ctx context.Context, orderID, userID int) (*order.Order, error)
+	CancelUserOrder(ctx context.Context, orderID, userID int, reason string) (*order.Order, error)
+
+	GetStoreOrders(ctx context.Context, storeID, userID int, filter order.Filter) ([]order.OrderSummary, error)
+	GetStoreOrder(ctx context.Context, storeID, orderID, userID int) (*order.Order, error)
+	UpdateStoreOrderStatus(
+		ctx context.Context,
+		storeID int,
+		orderID int,
+		userID int,
+		status string,
+		reason string,
+	) (*order.Order, error)
+}
+
+type handler struct {
+	service        Service
+	authMiddleware func(http.Handler) http.Handler
+	ageMiddleware  func(http.Handler) http.Handler
+	staticURL      string
+	logger         *zap.Logger
+}
+
+func New(
+	service Service,
+	authMiddleware func(http.Handler) http.Handler,
+	ageMiddleware func(http.Handler) http.Handler,
+	staticURL string,
+	logger *zap.Logger,
+) handlers.Handler {
+	return &handler{
+		service:        service,
+		authMiddleware: authMiddleware,
+		ageMiddleware:  ageMiddleware,
+		staticURL:      staticURL,
+		logger:         logger,
+	}
+}
+
+func (h *handler) Register(router chi.Router) {
+	router.Route("/me/carts", func(cartRouter chi.Router) {
+		cartRouter.Use(h.authMiddleware, h.ageMiddleware)
+		cartRouter.Get("/", apperror.Middleware(h.getUserCartsHandler))
+		cartRouter.Get("/{store_id}", apperror.Middleware(h.getCartHandler))
+		cartRouter.Delete("/{store_id}", apperror.Middleware(h.deleteCartHandler))
+		cartRouter.Put("/{store_id}/items/{menu_item_id}", apperror.Middleware(h.setCartItemHandler))
+		cartRouter.Delete("/{store_id}/items/{menu_item_id}", apperror.Middleware(h.deleteCartItemHandler))
+		cartRouter.Post("/{store_id}/checkout", apperror.Middleware(h.checkoutHandler))
+	})
+
+	router.Route("/me/orders", func(orderRouter chi.Router) {
+		orderRouter.Use(h.authMiddleware, h.ageMiddleware)
+		orderRouter.Get("/", apperror.Middleware(h.getUserOrdersHandler))
+		orderRouter.Get("/{id}", apperror.Middleware(h.getUserOrderHandler))
+		orderRouter.Post("/{id}/cancel", apperror.Middleware(h.cancelUserOrderHandler))
+	})
+
+	router.Route("/me/stores/{store_id}/orders", func(storeOrderRouter chi.Router) {
+		storeOrderRouter.Use(h.authMiddleware)
+		storeOrderRouter.Get("/", apperror.Middleware(h.getStoreOrdersHandler))
+		storeOrderRouter.Get("/{id}", apperror.Middleware(h.getStoreOrderHandler))
+		storeOrderRouter.Patch("/{id}/status", apperror.Middleware(h.updateStoreOrderStatusHandler))
+	})
+}
+
+func parseIntParam(r *http.Request, name string) (int, error) {
+	value, err := strconv.Atoi(chi.URLParam(r, name))
+	if err != nil {
+		return 0, apperror.NewFieldError(name, "field.positive_integer")
+	}
+	return value, nil
+}
+
+func parseFilter(r *http.Request) order.Filter {
+	return order.Filter{Status: r.URL.Query().Get("status")}
+}
+
+// @Security	ApiKeyAuth
+// @Tags		orders
+// @Success	200		{object}	CartsResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/carts [get]
+func (h *handler) getUserCartsHandler(w http.ResponseWriter, r *http.Request) error {
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	carts, err := h.service.GetUserCarts(r.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewCartsResponse(carts, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		orders
+// @Success	200		{object}	CartResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/carts/{store_id} [get]
+func (h *handler) getCartHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "store_id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	cart, err := h.service.GetCart(r.Context(), userID, storeID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewCartResponse(*cart, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		orders
+// @Success	200
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/carts/{store_id} [delete]
+func (h *handler) deleteCartHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "store_id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	return h.service.DeleteCart(r.Context(), userID, storeID)
+}
+
+// @Security	ApiKeyAuth
+// @Tags		orders
+// @Param		request	body		CartItemRequest	true	"request body"
+// @Success	200		{object}	CartResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/carts/{store_id}/items/{menu_item_id} [put]
+func (h *handler) setCartItemHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "store_id")
+	if err != nil {
+		return err
+	}
+
+	menuItemID, err := parseIntParam(r, "menu_item_id")
+	if err != nil {
+		return err
+	}
+
+	var dto CartItemRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	cart, err := h.service.SetCartItem(r.Context(), userID, storeID, menuItemID, dto.Quantity)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewCartResponse(*cart, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		orders
+// @Success	200		{object}	CartResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/carts/{store_id}/items/{menu_item_id} [delete]
+func (h *handler) deleteCartItemHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "store_id")
+	if err != nil {
+		return err
+	}
+
+	menuItemID, err := parseIntParam(r, "menu_item_id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	cart, err := h.service.DeleteCartItem(r.Context(), userID, storeID, menuItemID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewCartResponse(*cart, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		orders
+// @Param		request	body		CheckoutRequest	true	"request body"
+// @Success	200		{object}	OrderResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/carts/{store_id}/checkout [post]
+func (h *handler) checkoutHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "store_id")
+	if err != nil {
+		return err
+	}
+
+	var dto CheckoutRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	createdOrder, err := h.service.Checkout(r.Context(), dto.ToDomain(userID, storeID))
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewOrderResponse(*createdOrder, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		orders
+// @Param		status	query		string	false	"order status"
+// @Success	200		{object}	OrdersSummaryResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/orders [get]
+func (h *handler) getUserOrdersHandler(w http.ResponseWriter, r *http.Request) error {
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	orders, err := h.service.GetUserOrders(r.Context(), userID, parseFilter(r))
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewOrdersSummaryResponse(orders, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		orders
+// @Success	200		{object}	OrderResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/orders/{id} [get]
+func (h *handler) getUserOrderHandler(w http.ResponseWriter, r *http.Request) error {
+	orderID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	existingOrder, err := h.service.GetUserOrder(r.Context(), orderID, userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewOrderResponse(*existingOrder, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		orders
+// @Param		request	body		CancelOrderRequest	true	"request body"
+// @Success	200		{object}	OrderResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/orders/{id}/cancel [post]
+func (h *handler) cancelUserOrderHandler(w http.ResponseWriter, r *http.Request) error {
+	orderID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var dto CancelOrderRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	cancelledOrder, err := h.service.CancelUserOrder(r.Context(), orderID, userID, dto.Reason)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewOrderResponse(*cancelledOrder, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		orders
+// @Param		status	query		string	false	"order status"
+// @Success	200		{object}	OrdersSummaryResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/stores/{store_id}/orders [get]
+func (h *handler) getStoreOrdersHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "store_id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	orders, err := h.service.GetStoreOrders(r.Context(), storeID, userID, parseFilter(r))
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewOrdersSummaryResponse(orders, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		orders
+// @Success	200		{object}	OrderResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/stores/{store_id}/orders/{id} [get]
+func (h *handler) getStoreOrderHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "store_id")
+	if err != nil {
+		return err
+	}
+
+	orderID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	existingOrder, err := h.service.GetStoreOrder(r.Context(), storeID, orderID, userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewOrderResponse(*existingOrder, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		orders
+// @Param		request	body		OrderStatusRequest	true	"request body"
+// @Success	200		{object}	OrderResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/stores/{store_id}/orders/{id}/status [patch]
+func (h *handler) updateStoreOrderStatusHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "store_id")
+	if err != nil {
+		return err
+	}
+
+	orderID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var dto OrderStatusRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	updatedOrder, err := h.service.UpdateStoreOrderStatus(
+		r.Context(),
+		storeID,
+		orderID,
+		userID,
+		dto.Status,
+		dto.Reason,
+	)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewOrderResponse(*updatedOrder, h.staticURL))
+
+	return nil
+}
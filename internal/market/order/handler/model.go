@@ -0,0 +1,97 @@
+package orderhandler
+
+import (
+	"strings"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/market/order"
+)
+
+type CartItemRequest struct {
+	Quantity int `json:"quantity" validate:"min=0,max=1000"`
+}
+
+type CheckoutRequest struct {
+	Fulfillment     string   `json:"fulfillment" validate:"required,oneof=delivery pickup"`
+	DeliveryAddress string   `json:"deliveryAddress" validate:"required_if=Fulfillment delivery"`
+	Latitude        *float64 `json:"latitude" validate:"required_if=Fulfillment delivery,omitempty,latitude"`
+	Longitude       *float64 `json:"longitude" validate:"required_if=Fulfillment delivery,omitempty,longitude"`
+	CouponCode      string   `json:"couponCode" validate:"max=32"`
+	Comment         string   `json:"comment" validate:"max=1000"`
+}
+
+func (cr *CheckoutRequest) ToDomain(userID, storeID int) order.Checkout {
+	return order.Checkout{
+		UserID:          userID,
+		StoreID:         storeID,
+		Fulfillment:     cr.Fulfillment,
+		DeliveryAddress: cr.DeliveryAddress,
+		Latitude:        cr.Latitude,
+		Longitude:       cr.Longitude,
+		CouponCode:      strings.ToUpper(strings.TrimSpace(cr.CouponCode)),
+		Comment:         cr.Comment,
+	}
+}
+
+type CancelOrderRequest struct {
+	Reason string `json:"reason" validate:"max=1000"`
+}
+
+type OrderStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=accepted preparing out_for_delivery ready_for_pickup completed cancelled"`
+	Reason string `json:"reason" validate:"max=1000"`
+}
+
+type CartResponse struct {
+	Cart order.Cart `json:"cart"`
+}
+
+func prefixCart(c *order.Cart, staticURL string) {
+	if c.Store.Banner != "" {
+		c.Store.Banner = staticURL + "/" + c.Store.Banner
+	}
+	for _, item := range c.Items {
+		if item.MenuItem != nil && item.MenuItem.Product.Picture != "" {
+			item.MenuItem.Product.Picture = staticURL + "/" + item.MenuItem.Product.Picture
+		}
+	}
+}
+
+func NewCartResponse(c order.Cart, staticURL string) CartResponse {
+	prefixCart(&c, staticURL)
+	return CartResponse{Cart: c}
+}
+
+type CartsResponse struct {
+	Carts []order.Cart `json:"carts"`
+}
+
+func NewCartsResponse(elements []order.Cart, staticURL string) CartsResponse {
+	for i := range elements {
+		prefixCart(&elements[i], staticURL)
+	}
+	return CartsResponse{Carts: elements}
+}
+
+type OrderResponse struct {
+	Order order.Order `json:"order"`
+}
+
+func NewOrderResponse(o order.Order, staticURL string) OrderResponse {
+	if o.Store.Banner != "" {
+		o.Store.Banner = staticURL + "/" + o.Store.Banner
+	}
+	return OrderResponse{Order: o}
+}
+
+type OrdersSummaryResponse struct {
+	Orders []order.OrderSummary `json:"orders"`
+}
+
+func NewOrdersSummaryResponse(elements []order.OrderSummary, staticURL string) OrdersSummaryResponse {
+	for i := range elements {
+		if elements[i].Store.Banner != "" {
+			elements[i].Store.Banner = staticURL + "/" + elements[i].Store.Banner
+		}
+	}
+	return OrdersSummaryResponse{Orders: elements}
+}
@@ -0,0 +1,110 @@
+package order
+
+import (
+	"time"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/market/menu"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/promotion"
+)
+
+const (
+	FulfillmentDelivery = "delivery"
+	FulfillmentPickup   = "pickup"
+)
+
+type StoreInfo struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Banner string `json:"banner"`
+}
+
+// CustomerInfo is the customer as they were at checkout.
+type CustomerInfo struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type CartItem struct {
+	MenuItemID  int        `json:"menuItemId"`
+	MenuItem    *menu.Item `json:"menuItem"`
+	Quantity    int        `json:"quantity"`
+	IsAvailable bool       `json:"isAvailable"`
+	Price       int        `json:"price"`
+}
+
+type Cart struct {
+	ID         int        `json:"id"`
+	UserID     int        `json:"-"`
+	Store      StoreInfo  `json:"store"`
+	Items      []CartItem `json:"items"`
+	ItemsPrice int        `json:"itemsPrice"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+// Item is an order line with product data and price captured at checkout.
+type Item struct {
+	ID          int     `json:"id"`
+	ProductID   *int    `json:"productId"`
+	VariantID   *int    `json:"variantId"`
+	ProductName string  `json:"productName"`
+	BrandName   string  `json:"brandName"`
+	Weight      float64 `json:"weight"`
+	Unit        string  `json:"unit"`
+	Price       int     `json:"price"`
+	Quantity    int     `json:"quantity"`
+}
+
+type StatusChange struct {
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Order struct {
+	ID                int                 `json:"id"`
+	UserID            int                 `json:"-"`
+	StoreOwnerID      int                 `json:"-"`
+	Store             StoreInfo           `json:"store"`
+	Customer          CustomerInfo        `json:"customer"`
+	Status            string              `json:"status"`
+	Fulfillment       string              `json:"fulfillment"`
+	DeliveryAddress   string              `json:"deliveryAddress"`
+	DeliveryLatitude  *float64            `json:"deliveryLatitude"`
+	DeliveryLongitude *float64            `json:"deliveryLongitude"`
+	ItemsPrice        int                 `json:"itemsPrice"`
+	DiscountPrice     int                 `json:"discountPrice"`
+	DeliveryPrice     int                 `json:"deliveryPrice"`
+	TotalPrice        int                 `json:"totalPrice"`
+	CouponCode        *string             `json:"couponCode"`
+	Promotions        []promotion.Applied `json:"promotions"`
+	Comment           string              `json:"comment"`
+	CancelReason      string              `json:"cancelReason"`
+	Items             []Item              `json:"items"`
+	History           []StatusChange      `json:"history"`
+	CreatedAt         time.Time           `json:"createdAt"`
+	UpdatedAt         time.Time           `json:"updatedAt"`
+}
+
+type OrderSummary struct {
+	ID          int       `json:"id"`
+	Store       StoreInfo `json:"store"`
+	Status      string    `json:"status"`
+	Fulfillment string    `json:"fulfillment"`
+	TotalPrice  int       `json:"totalPrice"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type Checkout struct {
+	UserID          int
+	StoreID         int
+	Fulfillment     string
+	DeliveryAddress string
+	Latitude        *float64
+	Longitude       *float64
+	CouponCode      string
+	Comment         string
+}
+
+type Filter struct {
+	Status string
+}
@@ -0,0 +1,529 @@
+package orderservice
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/menu"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/order"
+	orderdb "github.com/xw1nchester/kushfinds-backend/internal/market/order/db"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/promotion"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/store"
+	"github.com/xw1nchester/kushfinds-backend/internal/tracing"
+	"github.com/xw1nchester/kushfinds-backend/pkg/geo"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor"
+	"go.uber.org/zap"
+)
+
+type Repository interface {
+	GetUserCarts(ctx context.Context, userID int) ([]order.Cart, error)
+	GetCart(ctx context.Context, userID, storeID int) (*order.Cart, error)
+	SetCartItem(ctx context.Context, userID, storeID, menuItemID, quantity int) error
+	DeleteCartItem(ctx context.Context, userID, storeID, menuItemID int) error
+	DeleteCart(ctx context.Context, userID, storeID int) error
+	DeleteOrderedItems(ctx context.Context, cartID int, items []order.CartItem) error
+	ReserveStock(ctx context.Context, storeID int, items []order.CartItem) error
+	ReleaseStock(ctx context.Context, orderID int) error
+
+	CreateOrder(ctx context.Context, data order.Order) (int, error)
+	GetOrderByID(ctx context.Context, id int) (*order.Order, error)
+	GetUserOrders(ctx context.Context, userID int, filter order.Filter) ([]order.OrderSummary, error)
+	GetStoreOrders(ctx context.Context, storeID int, filter order.Filter) ([]order.OrderSummary, error)
+	UpdateOrderStatus(ctx context.Context, orderID int, from, to, cancelReason string) error
+}
+
+var (
+	ErrEmptyCart           = apperror.NewAppError("order.empty_cart", "cart is empty")
+	ErrItemsUnavailable    = apperror.NewAppError("order.items_unavailable", "some cart items are no longer available")
+	ErrNotEnoughStock      = apperror.NewAppError("order.not_enough_stock", "some cart items exceed the stock of the store")
+	ErrDeliveryUnavailable = apperror.NewAppError("order.delivery_unavailable", "store does not deliver")
+	ErrDeliveryLocation    = apperror.NewAppError("order.delivery_location_required", "delivery address and location are required")
+	ErrDeliveryTooFar      = apperror.NewAppError("order.delivery_too_far", "delivery address is out of the store delivery distance")
+	ErrInvalidTransition   = apperror.NewAppError("order.invalid_transition", "order can not be moved to this status")
+	ErrOrderStatusChanged  = apperror.NewConflictError("order.status_changed", "order status has been changed, reload the order")
+	ErrCartChanged         = apperror.NewConflictError("order.cart_changed", "cart has been changed, review the order")
+)
+
+type StoreService interface {
+	GetStore(ctx context.Context, storeID int) (*store.Store, error)
+	GetUserStore(ctx context.Context, storeID, userID int) (*store.Store, error)
+}
+
+type MenuService interface {
+	GetStoreMenuItems(ctx context.Context, storeID int, itemIDs []int) ([]menu.Item, error)
+}
+
+type PromotionService interface {
+	PriceOrder(
+		ctx context.Context,
+		st *store.Store,
+		userID int,
+		lines []promotion.Line,
+		couponCode string,
+	) (*promotion.Pricing, error)
+	RedeemPromotions(ctx context.Context, userID int, applied []promotion.Applied) error
+	ReleasePromotions(ctx context.Context, orderID int) error
+}
+
+type service struct {
+	repository       Repository
+	storeService     StoreService
+	menuService      MenuService
+	promotionService PromotionService
+	txManager        transactor.Manager
+	logger           *zap.Logger
+}
+
+func New(
+	repository Repository,
+	storeService StoreService,
+	menuService MenuService,
+	promotionService PromotionService,
+	txManager transactor.Manager,
+	logger *zap.Logger,
+) *service {
+	return &service{
+		repository:       repository,
+		storeService:     storeService,
+		menuService:      menuService,
+		promotionService: promotionService,
+		txManager:        txManager,
+		logger:           logger,
+	}
+}
+
+// fillCart attaches current menu data and prices to the cart items.
+// Items that are no longer published are kept but marked as unavailable.
+func (s *service) fillCart(ctx context.Context, cart *order.Cart) error {
+	ctx, span := tracing.Start(ctx, "orderservice.fillCart")
+	defer span.End()
+
+	itemIDs := make([]int, len(cart.Items))
+	for i, item := range cart.Items {
+		itemIDs[i] = item.MenuItemID
+	}
+
+	menuItems, err := s.menuService.GetStoreMenuItems(ctx, cart.Store.ID, itemIDs)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[int]menu.Item, len(menuItems))
+	for _, item := range menuItems {
+		byID[item.ID] = item
+	}
+
+	cart.ItemsPrice = 0
+	for i, item := range cart.Items {
+		menuItem, ok := byID[item.MenuItemID]
+		if !ok {
+			cart.Items[i].IsAvailable = false
+			continue
+		}
+
+		cart.Items[i].MenuItem = &menuItem
+		cart.Items[i].IsAvailable = menuItem.InStock
+		cart.Items[i].Price = menuItem.CurrentPrice * item.Quantity
+
+		if cart.Items[i].IsAvailable {
+			cart.ItemsPrice += cart.Items[i].Price
+		}
+	}
+
+	return nil
+}
+
+func (s *service) GetUserCarts(ctx context.Context, userID int) ([]order.Cart, error) {
+	carts, err := s.repository.GetUserCarts(ctx, userID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching user carts", zap.Error(err))
+
+		return nil, err
+	}
+
+	for i := range carts {
+		if err := s.fillCart(ctx, &carts[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return carts, nil
+}
+
+func (s *service) GetCart(ctx context.Context, userID, storeID int) (*order.Cart, error) {
+	cart, err := s.repository.GetCart(ctx, userID, storeID)
+	if err != nil {
+		if errors.Is(err, orderdb.ErrCartNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching cart", zap.Error(err))
+
+		return nil, err
+	}
+
+	if err := s.fillCart(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	return cart, nil
+}
+
+// SetCartItem puts the menu item into the cart of the store with the given quantity.
+// Zero quantity removes the item from the cart.
+func (s *service) SetCartItem(ctx context.Context, userID, storeID, menuItemID, quantity int) (*order.Cart, error) {
+	if quantity == 0 {
+		return s.DeleteCartItem(ctx, userID, storeID, menuItemID)
+	}
+
+	if _, err := s.storeService.GetStore(ctx, storeID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.menuService.GetStoreMenuItems(ctx, storeID, []int{menuItemID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, apperror.ErrNotFound
+	}
+
+	if err := s.repository.SetCartItem(ctx, userID, storeID, menuItemID, quantity); err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when saving cart item", zap.Error(err))
+
+		return nil, err
+	}
+
+	return s.GetCart(ctx, userID, storeID)
+}
+
+func (s *service) DeleteCartItem(ctx context.Context, userID, storeID, menuItemID int) (*order.Cart, error) {
+	if err := s.repository.DeleteCartItem(ctx, userID, storeID, menuItemID); err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when deleting cart item", zap.Error(err))
+
+		return nil, err
+	}
+
+	return s.GetCart(ctx, userID, storeID)
+}
+
+func (s *service) DeleteCart(ctx context.Context, userID, storeID int) error {
+	if err := s.repository.DeleteCart(ctx, userID, storeID); err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when deleting cart", zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func deliveryPrice(st *store.Store, data order.Checkout) (int, error) {
+	if data.Fulfillment != order.FulfillmentDelivery {
+		return 0, nil
+	}
+
+	if st.DeliveryDistance <= 0 || st.Latitude == nil || st.Longitude == nil {
+		return 0, ErrDeliveryUnavailable
+	}
+
+	if data.DeliveryAddress == "" || data.Latitude == nil || data.Longitude == nil {
+		return 0, ErrDeliveryLocation
+	}
+
+	distance := geo.DistanceKm(*st.Latitude, *st.Longitude, *data.Latitude, *data.Longitude)
+	if distance > float64(st.DeliveryDistance) {
+		return 0, ErrDeliveryTooFar
+	}
+
+	return st.DeliveryPrice, nil
+}
+
+// Checkout turns the cart of the store into a pending order with prices
+// and promotions captured at the moment. The stock is taken and the ordered
+// items are removed from the cart in the same transaction.
+func (s *service) Checkout(ctx context.Context, data order.Checkout) (*order.Order, error) {
+	ctx, span := tracing.Start(ctx, "orderservice.Checkout")
+	defer span.End()
+
+	st, err := s.storeService.GetStore(ctx, data.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := s.GetCart(ctx, data.UserID, data.StoreID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return nil, ErrEmptyCart
+		}
+		return nil, err
+	}
+
+	if len(cart.Items) == 0 {
+		return nil, ErrEmptyCart
+	}
+
+	now := time.Now()
+
+	newOrder := order.Order{
+		UserID:      data.UserID,
+		Store:       order.StoreInfo{ID: st.ID},
+		Status:      order.StatusPending,
+		Fulfillment: data.Fulfillment,
+		Comment:     data.Comment,
+		Items:       make([]order.Item, 0, len(cart.Items)),
+	}
+
+	lines := make([]promotion.Line, 0, len(cart.Items))
+
+	for _, item := range cart.Items {
+		if !item.IsAvailable {
+			return nil, ErrItemsUnavailable
+		}
+
+		menuItem := item.MenuItem
+		if item.Quantity > menuItem.StockQuantity {
+			return nil, ErrNotEnoughStock
+		}
+
+		productID := menuItem.Product.ID
+		variantID := menuItem.Variant.ID
+		price := menuItem.PriceAt(now)
+
+		newOrder.Items = append(newOrder.Items, order.Item{
+			ProductID:   &productID,
+			VariantID:   &variantID,
+			ProductName: menuItem.Product.Name,
+			BrandName:   menuItem.Product.Brand.Name,
+			Weight:      menuItem.Variant.Weight,
+			Unit:        menuItem.Variant.Unit,
+			Price:       price,
+			Quantity:    item.Quantity,
+		})
+		newOrder.ItemsPrice += price * item.Quantity
+
+		lines = append(lines, promotion.Line{
+			BrandID:         menuItem.Product.Brand.ID,
+			MarketSectionID: menuItem.Product.MarketSection.ID,
+			UnitPrice:       price,
+			Quantity:        item.Quantity,
+		})
+	}
+
+	pricing, err := s.promotionService.PriceOrder(ctx, st, data.UserID, lines, data.CouponCode)
+	if err != nil {
+		return nil, err
+	}
+
+	newOrder.DiscountPrice = pricing.Discount
+	newOrder.Promotions = pricing.Applied
+	if data.CouponCode != "" {
+		newOrder.CouponCode = &data.CouponCode
+	}
+
+	// the minimal price is what the store gets for the items
+	if newOrder.ItemsPrice-newOrder.DiscountPrice < st.MinimalOrderPrice {
+		return nil, apperror.NewAppErrorf("order.minimal_price", "minimal order price is %d", st.MinimalOrderPrice)
+	}
+
+	newOrder.DeliveryPrice, err = deliveryPrice(st, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Fulfillment == order.FulfillmentDelivery {
+		newOrder.DeliveryAddress = data.DeliveryAddress
+		newOrder.DeliveryLatitude = data.Latitude
+		newOrder.DeliveryLongitude = data.Longitude
+	}
+
+	newOrder.TotalPrice = newOrder.ItemsPrice - newOrder.DiscountPrice + newOrder.DeliveryPrice
+
+	var orderID int
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// taking the cart first makes parallel checkouts of it wait for each other
+		if err := s.repository.DeleteOrderedItems(ctx, cart.ID, cart.Items); err != nil {
+			return err
+		}
+
+		if err := s.repository.ReserveStock(ctx, st.ID, cart.Items); err != nil {
+			return err
+		}
+
+		if err := s.promotionService.RedeemPromotions(ctx, data.UserID, newOrder.Promotions); err != nil {
+			return err
+		}
+
+		var err error
+		orderID, err = s.repository.CreateOrder(ctx, newOrder)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, orderdb.ErrNotEnoughStock):
+			return nil, ErrNotEnoughStock
+		case errors.Is(err, orderdb.ErrCartNotFound), errors.Is(err, orderdb.ErrCartChanged):
+			// the cart has been ordered or edited since it was read
+			return nil, ErrCartChanged
+		}
+
+		// promotions used up in the meantime are reported to the customer
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return nil, err
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when creating order", zap.Error(err))
+
+		return nil, err
+	}
+
+	return s.getOrderByID(ctx, orderID)
+}
+
+func (s *service) getOrderByID(ctx context.Context, orderID int) (*order.Order, error) {
+	existingOrder, err := s.repository.GetOrderByID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, orderdb.ErrOrderNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching order by id", zap.Error(err))
+
+		return nil, err
+	}
+
+	return existingOrder, nil
+}
+
+func (s *service) GetUserOrders(ctx context.Context, userID int, filter order.Filter) ([]order.OrderSummary, error) {
+	orders, err := s.repository.GetUserOrders(ctx, userID, filter)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching user orders", zap.Error(err))
+
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (s *service) GetUserOrder(ctx context.Context, orderID, userID int) (*order.Order, error) {
+	existingOrder, err := s.getOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingOrder.UserID != userID {
+		return nil, apperror.ErrNotFound
+	}
+
+	return existingOrder, nil
+}
+
+func (s *service) changeStatus(ctx context.Context, existingOrder *order.Order, status, reason string) (*order.Order, error) {
+	if status != order.StatusCancelled {
+		reason = ""
+	}
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.UpdateOrderStatus(ctx, existingOrder.ID, existingOrder.Status, status, reason); err != nil {
+			return err
+		}
+
+		// cancelled orders give their items back to the store
+		// and the promotion uses back to the customer
+		if status == order.StatusCancelled {
+			if err := s.repository.ReleaseStock(ctx, existingOrder.ID); err != nil {
+				return err
+			}
+
+			return s.promotionService.ReleasePromotions(ctx, existingOrder.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, orderdb.ErrOrderStatusChanged) {
+			return nil, ErrOrderStatusChanged
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when updating order status", zap.Error(err))
+
+		return nil, err
+	}
+
+	return s.getOrderByID(ctx, existingOrder.ID)
+}
+
+func (s *service) CancelUserOrder(ctx context.Context, orderID, userID int, reason string) (*order.Order, error) {
+	existingOrder, err := s.GetUserOrder(ctx, orderID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !order.CanCustomerCancel(existingOrder.Status) {
+		return nil, ErrInvalidTransition
+	}
+
+	return s.changeStatus(ctx, existingOrder, order.StatusCancelled, reason)
+}
+
+func (s *service) GetStoreOrders(
+	ctx context.Context,
+	storeID int,
+	userID int,
+	filter order.Filter,
+) ([]order.OrderSummary, error) {
+	if _, err := s.storeService.GetUserStore(ctx, storeID, userID); err != nil {
+		return nil, err
+	}
+
+	orders, err := s.repository.GetStoreOrders(ctx, storeID, filter)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching store orders", zap.Error(err))
+
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (s *service) GetStoreOrder(ctx context.Context, storeID, orderID, userID int) (*order.Order, error) {
+	if _, err := s.storeService.GetUserStore(ctx, storeID, userID); err != nil {
+		return nil, err
+	}
+
+	existingOrder, err := s.getOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingOrder.Store.ID != storeID {
+		return nil, apperror.ErrNotFound
+	}
+
+	return existingOrder, nil
+}
+
+func (s *service) UpdateStoreOrderStatus(
+	ctx context.Context,
+	storeID int,
+	orderID int,
+	userID int,
+	status string,
+	reason string,
+) (*order.Order, error) {
+	existingOrder, err := s.GetStoreOrder(ctx, storeID, orderID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !order.CanTransition(existingOrder.Status, status, existingOrder.Fulfillment) {
+		return nil, ErrInvalidTransition
+	}
+
+	return s.changeStatus(ctx, existingOrder, status, reason)
+}
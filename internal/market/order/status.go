@@ -0,0 +1,43 @@
+package order
+
+const (
+	StatusPending        = "pending"
+	StatusAccepted       = "accepted"
+	StatusPreparing      = "preparing"
+	StatusOutForDelivery = "out_for_delivery"
+	StatusReadyForPickup = "ready_for_pickup"
+	StatusCompleted      = "completed"
+	StatusCancelled      = "cancelled"
+)
+
+var transitions = map[string][]string{
+	StatusPending:        {StatusAccepted, StatusCancelled},
+	StatusAccepted:       {StatusPreparing, StatusCancelled},
+	StatusPreparing:      {StatusOutForDelivery, StatusReadyForPickup, StatusCancelled},
+	StatusOutForDelivery: {StatusCompleted, StatusCancelled},
+	StatusReadyForPickup: {StatusCompleted, StatusCancelled},
+}
+
+// CanTransition reports whether an order with the given fulfillment
+// may move from one status to another.
+func CanTransition(from, to, fulfillment string) bool {
+	if to == StatusOutForDelivery && fulfillment != FulfillmentDelivery {
+		return false
+	}
+	if to == StatusReadyForPickup && fulfillment != FulfillmentPickup {
+		return false
+	}
+
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanCustomerCancel reports whether the customer may still cancel the order.
+func CanCustomerCancel(status string) bool {
+	return status == StatusPending
+}
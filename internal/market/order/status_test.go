@@ -0,0 +1,38 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		name        string
+		from        string
+		to          string
+		fulfillment string
+		expected    bool
+	}{
+		{"pending to accepted", StatusPending, StatusAccepted, FulfillmentDelivery, true},
+		{"pending to cancelled", StatusPending, StatusCancelled, FulfillmentPickup, true},
+		{"pending skips accepted", StatusPending, StatusPreparing, FulfillmentDelivery, false},
+		{"accepted to preparing", StatusAccepted, StatusPreparing, FulfillmentDelivery, true},
+		{"preparing to out for delivery", StatusPreparing, StatusOutForDelivery, FulfillmentDelivery, true},
+		{"pickup order is not delivered", StatusPreparing, StatusOutForDelivery, FulfillmentPickup, false},
+		{"preparing to ready for pickup", StatusPreparing, StatusReadyForPickup, FulfillmentPickup, true},
+		{"delivery order is not picked up", StatusPreparing, StatusReadyForPickup, FulfillmentDelivery, false},
+		{"out for delivery to completed", StatusOutForDelivery, StatusCompleted, FulfillmentDelivery, true},
+		{"ready for pickup to completed", StatusReadyForPickup, StatusCompleted, FulfillmentPickup, true},
+		{"completed is final", StatusCompleted, StatusCancelled, FulfillmentPickup, false},
+		{"cancelled is final", StatusCancelled, StatusPending, FulfillmentPickup, false},
+		{"no moving back", StatusPreparing, StatusAccepted, FulfillmentDelivery, false},
+		{"unknown status", "unknown", StatusAccepted, FulfillmentDelivery, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, CanTransition(tt.from, tt.to, tt.fulfillment))
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package productdb
+
+import "errors"
+
+var (
+	ErrProductNotFound = errors.New("product not found")
+)
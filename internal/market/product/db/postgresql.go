@@ -0,0 +1,511 @@
+package productdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/product"
+	marketsection "github.com/xw1nchester/kushfinds-backend/internal/market/section"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor/postgresql"
+	"go.uber.org/zap"
+)
+
+type repository struct {
+	client *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func New(client *pgxpool.Pool, logger *zap.Logger) *repository {
+	return &repository{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (r *repository) GetProductByID(ctx context.Context, id int) (*product.Product, error) {
+	query := `
+		SELECT
+			p.id,
+			b.user_id,
+			b.id,
+			b.name,
+			b.logo,
+			ms.id,
+			ms.name,
+			mss.id,
+			mss.name,
+			p.name,
+			p.description,
+			p.strain_type,
+			p.thc_percent,
+			p.cbd_percent,
+			p.is_published,
+			b.is_published,
+			p.created_at,
+			p.updated_at
+		FROM products p
+		JOIN brands b ON p.brand_id = b.id
+		LEFT JOIN market_sections ms ON p.market_section_id = ms.id
+		LEFT JOIN market_sections mss ON p.market_sub_section_id = mss.id
+		WHERE p.id=$1
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	var pr product.Product
+	var subSectionID *int
+	var subSectionName *string
+	if err := r.client.QueryRow(ctx, query, id).Scan(
+		&pr.ID,
+		&pr.UserID,
+		&pr.Brand.ID,
+		&pr.Brand.Name,
+		&pr.Brand.Logo,
+		&pr.MarketSection.ID,
+		&pr.MarketSection.Name,
+		&subSectionID,
+		&subSectionName,
+		&pr.Name,
+		&pr.Description,
+		&pr.StrainType,
+		&pr.THCPercent,
+		&pr.CBDPercent,
+		&pr.IsPublished,
+		&pr.IsBrandPublished,
+		&pr.CreatedAt,
+		&pr.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	if subSectionID != nil {
+		pr.MarketSubSection = &marketsection.MarketSection{
+			ID:   *subSectionID,
+			Name: *subSectionName,
+		}
+	}
+
+	picsQuery := `
+		SELECT url
+		FROM products_pictures
+		WHERE product_id = $1
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, picsQuery)
+
+	rows, err := r.client.Query(ctx, picsQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pr.Pictures = make([]string, 0)
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			return nil, err
+		}
+		pr.Pictures = append(pr.Pictures, url)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	variantsQuery := `
+		SELECT id, weight, unit
+		FROM products_variants
+		WHERE product_id = $1
+		ORDER BY unit, weight
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, variantsQuery)
+
+	rows, err = r.client.Query(ctx, variantsQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pr.Variants = make([]product.Variant, 0)
+	for rows.Next() {
+		var v product.Variant
+		if err := rows.Scan(&v.ID, &v.Weight, &v.Unit); err != nil {
+			return nil, err
+		}
+		pr.Variants = append(pr.Variants, v)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	labTestsQuery := `
+		SELECT url
+		FROM products_lab_tests
+		WHERE product_id = $1
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, labTestsQuery)
+
+	rows, err = r.client.Query(ctx, labTestsQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pr.LabTests = make([]string, 0)
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			return nil, err
+		}
+		pr.LabTests = append(pr.LabTests, url)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &pr, nil
+}
+
+func (r *repository) getProductsSummary(
+	ctx context.Context,
+	conditions []string,
+	args ...any,
+) ([]product.ProductSummary, error) {
+	query := `
+		SELECT
+			p.id,
+			p.name,
+			COALESCE((
+				SELECT pp.url FROM products_pictures pp
+				WHERE pp.product_id = p.id
+				ORDER BY pp.url
+				LIMIT 1
+			), ''),
+			b.id,
+			b.name,
+			b.logo,
+			ms.id,
+			ms.name,
+			p.strain_type,
+			p.thc_percent,
+			p.cbd_percent,
+			p.is_published
+		FROM products p
+		JOIN brands b ON p.brand_id = b.id
+		LEFT JOIN market_sections ms ON p.market_section_id = ms.id
+	`
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY p.id"
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]product.ProductSummary, 0)
+	for rows.Next() {
+		var p product.ProductSummary
+
+		err := rows.Scan(
+			&p.ID,
+			&p.Name,
+			&p.Picture,
+			&p.Brand.ID,
+			&p.Brand.Name,
+			&p.Brand.Logo,
+			&p.MarketSection.ID,
+			&p.MarketSection.Name,
+			&p.StrainType,
+			&p.THCPercent,
+			&p.CBDPercent,
+			&p.IsPublished,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+
+		products = append(products, p)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return products, nil
+}
+
+func (r *repository) GetBrandProducts(ctx context.Context, brandID int) ([]product.ProductSummary, error) {
+	return r.getProductsSummary(ctx, []string{"p.brand_id=$1"}, brandID)
+}
+
+func (r *repository) GetPublishedProducts(ctx context.Context, filter product.Filter) ([]product.ProductSummary, error) {
+	conditions := []string{"p.is_published=true", "b.is_published=true"}
+	args := []any{}
+
+	if filter.BrandID != 0 {
+		args = append(args, filter.BrandID)
+		conditions = append(conditions, fmt.Sprintf("p.brand_id=$%d", len(args)))
+	}
+
+	if filter.MarketSectionID != 0 {
+		args = append(args, filter.MarketSectionID)
+		conditions = append(
+			conditions,
+			fmt.Sprintf("(p.market_section_id=$%d OR p.market_sub_section_id=$%d)", len(args), len(args)),
+		)
+	}
+
+	return r.getProductsSummary(ctx, conditions, args...)
+}
+
+func (r *repository) createProductRelatedEntities(
+	ctx context.Context,
+	tx pgx.Tx,
+	productID int,
+	data product.Product,
+) error {
+	if len(data.Pictures) > 0 {
+		insertPicturesQuery := `
+			INSERT INTO products_pictures (product_id, url)
+			VALUES ($1, $2)
+		`
+		batch := &pgx.Batch{}
+		for _, url := range data.Pictures {
+			logging.LogSQLQuery(ctx, r.logger, insertPicturesQuery)
+			batch.Queue(insertPicturesQuery, productID, url)
+		}
+		br := tx.SendBatch(ctx, batch)
+		if err := br.Close(); err != nil {
+			return err
+		}
+	}
+
+	if len(data.LabTests) > 0 {
+		insertLabTestsQuery := `
+			INSERT INTO products_lab_tests (product_id, url)
+			VALUES ($1, $2)
+		`
+		batch := &pgx.Batch{}
+		for _, url := range data.LabTests {
+			logging.LogSQLQuery(ctx, r.logger, insertLabTestsQuery)
+			batch.Queue(insertLabTestsQuery, productID, url)
+		}
+		br := tx.SendBatch(ctx, batch)
+		if err := br.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *repository) saveProductVariants(
+	ctx context.Context,
+	tx pgx.Tx,
+	productID int,
+	variants []product.Variant,
+) error {
+	keepIDs := make([]int, 0)
+	for _, v := range variants {
+		if v.ID != 0 {
+			keepIDs = append(keepIDs, v.ID)
+		}
+	}
+
+	deleteQuery := "DELETE FROM products_variants WHERE product_id=$1 AND NOT (id = ANY($2))"
+	logging.LogSQLQuery(ctx, r.logger, deleteQuery)
+	if _, err := tx.Exec(ctx, deleteQuery, productID, keepIDs); err != nil {
+		return err
+	}
+
+	if len(variants) == 0 {
+		return nil
+	}
+
+	// variants may swap their weight and unit, the uniqueness holds only once all rows are saved
+	deferQuery := "SET CONSTRAINTS products_variants_product_id_weight_unit_key DEFERRED"
+	logging.LogSQLQuery(ctx, r.logger, deferQuery)
+	if _, err := tx.Exec(ctx, deferQuery); err != nil {
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO products_variants (product_id, weight, unit)
+		VALUES ($1, $2, $3)
+	`
+	updateQuery := `
+		UPDATE products_variants
+		SET weight=$3, unit=$4
+		WHERE id=$1 AND product_id=$2
+	`
+
+	batch := &pgx.Batch{}
+	for _, v := range variants {
+		if v.ID != 0 {
+			logging.LogSQLQuery(ctx, r.logger, updateQuery)
+			batch.Queue(updateQuery, v.ID, productID, v.Weight, v.Unit)
+		} else {
+			logging.LogSQLQuery(ctx, r.logger, insertQuery)
+			batch.Queue(insertQuery, productID, v.Weight, v.Unit)
+		}
+	}
+	br := tx.SendBatch(ctx, batch)
+
+	return br.Close()
+}
+
+func subSectionID(data product.Product) *int {
+	if data.MarketSubSection == nil {
+		return nil
+	}
+	return &data.MarketSubSection.ID
+}
+
+func (r *repository) CreateProduct(ctx context.Context, data product.Product) (*product.Product, error) {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO products (brand_id, market_section_id, market_sub_section_id, name, description, strain_type, thc_percent, cbd_percent, is_published)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	var productID int
+	if err = tx.QueryRow(
+		ctx,
+		query,
+		data.Brand.ID,
+		data.MarketSection.ID,
+		subSectionID(data),
+		data.Name,
+		data.Description,
+		data.StrainType,
+		data.THCPercent,
+		data.CBDPercent,
+		data.IsPublished,
+	).Scan(&productID); err != nil {
+		return nil, err
+	}
+
+	if err = r.createProductRelatedEntities(ctx, tx, productID, data); err != nil {
+		return nil, err
+	}
+
+	if err = r.saveProductVariants(ctx, tx, productID, data.Variants); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.GetProductByID(ctx, productID)
+}
+
+func (r *repository) UpdateProduct(ctx context.Context, data product.Product) (*product.Product, error) {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE products
+		SET
+			market_section_id=$3,
+			market_sub_section_id=$4,
+			name=$5,
+			description=$6,
+			strain_type=$7,
+			thc_percent=$8,
+			cbd_percent=$9,
+			is_published=$10,
+			updated_at=NOW()
+		WHERE id=$1 AND brand_id=$2
+		RETURNING id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	var productID int
+	if err = tx.QueryRow(
+		ctx,
+		query,
+		data.ID,
+		data.Brand.ID,
+		data.MarketSection.ID,
+		subSectionID(data),
+		data.Name,
+		data.Description,
+		data.StrainType,
+		data.THCPercent,
+		data.CBDPercent,
+		data.IsPublished,
+	).Scan(&productID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	deletePicturesQuery := "DELETE FROM products_pictures WHERE product_id=$1"
+	logging.LogSQLQuery(ctx, r.logger, deletePicturesQuery)
+	if _, err = tx.Exec(ctx, deletePicturesQuery, productID); err != nil {
+		return nil, err
+	}
+
+	deleteLabTestsQuery := "DELETE FROM products_lab_tests WHERE product_id=$1"
+	logging.LogSQLQuery(ctx, r.logger, deleteLabTestsQuery)
+	if _, err = tx.Exec(ctx, deleteLabTestsQuery, productID); err != nil {
+		return nil, err
+	}
+
+	if err = r.createProductRelatedEntities(ctx, tx, productID, data); err != nil {
+		return nil, err
+	}
+
+	if err = r.saveProductVariants(ctx, tx, productID, data.Variants); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.GetProductByID(ctx, productID)
+}
+
+func (r *repository) DeleteProduct(ctx context.Context, productID int) error {
+	query := `
+		DELETE FROM products
+		WHERE id=$1
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	_, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, productID)
+
+	return err
+}
@@ -0,0 +1,293 @@
+package producthandler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	jwtmiddleware "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt/middleware"
+	"github.com/xw1nchester/kushfinds-backend/internal/handlers"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/product"
+	"go.uber.org/zap"
+)
+
+var validate = apperror.NewValidator()
+
+type Service interface {
+	CreateProduct(ctx context.Context, data product.Product) (*product.Product, error)
+	GetBrandProducts(ctx context.Context, brandID, userID int) ([]product.ProductSummary, error)
+	GetBrandProduct(ctx context.Context, productID, brandID, userID int) (*product.Product, error)
+	UpdateProduct(ctx context.Context, data product.Product) (*product.Product, error)
+	DeleteProduct(ctx context.Context, productID, brandID, userID int) error
+
+	GetProducts(ctx context.Context, filter product.Filter) ([]product.ProductSummary, error)
+	GetProduct(ctx context.Context, productID int) (*product.Product, error)
+}
+
+type handler struct {
+	service        Service
+	authMiddleware func(http.Handler) http.Handler
+	ageMiddleware  func(http.Handler) http.Handler
+	staticURL      string
+	logger         *zap.Logger
+}
+
+func New(
+	service Service,
+	authMiddleware func(http.Handler) http.Handler,
+	ageMiddleware func(http.Handler) http.Handler,
+	staticURL string,
+	logger *zap.Logger,
+) handlers.Handler {
+	return &handler{
+		service:        service,
+		authMiddleware: authMiddleware,
+		ageMiddleware:  ageMiddleware,
+		staticURL:      staticURL,
+		logger:         logger,
+	}
+}
+
+func (h *handler) Register(router chi.Router) {
+	router.Route("/products", func(productRouter chi.Router) {
+		productRouter.Use(h.authMiddleware, h.ageMiddleware)
+		productRouter.Get("/", apperror.Middleware(h.getProductsHandler))
+		productRouter.Get("/{id}", apperror.Middleware(h.getProductHandler))
+	})
+
+	router.Route("/me/brands/{brand_id}/products", func(privateProductRouter chi.Router) {
+		privateProductRouter.Use(h.authMiddleware)
+		privateProductRouter.Post("/", apperror.Middleware(h.createProductHandler))
+		privateProductRouter.Get("/", apperror.Middleware(h.getBrandProductsHandler))
+		privateProductRouter.Get("/{id}", apperror.Middleware(h.getBrandProductHandler))
+		privateProductRouter.Patch("/{id}", apperror.Middleware(h.updateProductHandler))
+		privateProductRouter.Delete("/{id}", apperror.Middleware(h.deleteProductHandler))
+	})
+}
+
+func parseFilter(r *http.Request) (product.Filter, error) {
+	var filter product.Filter
+
+	if brandID := r.URL.Query().Get("brandId"); brandID != "" {
+		value, err := strconv.Atoi(brandID)
+		if err != nil {
+			return filter, apperror.NewFieldError("brandId", "field.positive_integer")
+		}
+		filter.BrandID = value
+	}
+
+	if marketSectionID := r.URL.Query().Get("marketSectionId"); marketSectionID != "" {
+		value, err := strconv.Atoi(marketSectionID)
+		if err != nil {
+			return filter, apperror.NewFieldError("marketSectionId", "field.positive_integer")
+		}
+		filter.MarketSectionID = value
+	}
+
+	return filter, nil
+}
+
+func parseBrandID(r *http.Request) (int, error) {
+	brandID, err := strconv.Atoi(chi.URLParam(r, "brand_id"))
+	if err != nil {
+		return 0, apperror.NewFieldError("brand_id", "field.positive_integer")
+	}
+	return brandID, nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		request	body		ProductRequest	true	"request body"
+// @Success	200		{object}	ProductResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/brands/{brand_id}/products [post]
+func (h *handler) createProductHandler(w http.ResponseWriter, r *http.Request) error {
+	brandID, err := parseBrandID(r)
+	if err != nil {
+		return err
+	}
+
+	var dto ProductRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	createdProduct, err := h.service.CreateProduct(r.Context(), *dto.ToDomain(brandID, userID))
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewProductResponse(*createdProduct, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Success	200		{object}	ProductsSummaryResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/brands/{brand_id}/products [get]
+func (h *handler) getBrandProductsHandler(w http.ResponseWriter, r *http.Request) error {
+	brandID, err := parseBrandID(r)
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	products, err := h.service.GetBrandProducts(r.Context(), brandID, userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewProductsSummaryResponse(products, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Success	200		{object}	ProductResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/brands/{brand_id}/products/{id} [get]
+func (h *handler) getBrandProductHandler(w http.ResponseWriter, r *http.Request) error {
+	brandID, err := parseBrandID(r)
+	if err != nil {
+		return err
+	}
+
+	productID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return apperror.NewFieldError("id", "field.positive_integer")
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	product, err := h.service.GetBrandProduct(r.Context(), productID, brandID, userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewProductResponse(*product, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		request	body		ProductRequest	true	"request body"
+// @Success	200		{object}	ProductResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/brands/{brand_id}/products/{id} [patch]
+func (h *handler) updateProductHandler(w http.ResponseWriter, r *http.Request) error {
+	brandID, err := parseBrandID(r)
+	if err != nil {
+		return err
+	}
+
+	productID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return apperror.NewFieldError("id", "field.positive_integer")
+	}
+
+	var dto ProductRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	data := dto.ToDomain(brandID, userID)
+	data.ID = productID
+
+	updatedProduct, err := h.service.UpdateProduct(r.Context(), *data)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewProductResponse(*updatedProduct, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Success	200
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/brands/{brand_id}/products/{id} [delete]
+func (h *handler) deleteProductHandler(w http.ResponseWriter, r *http.Request) error {
+	brandID, err := parseBrandID(r)
+	if err != nil {
+		return err
+	}
+
+	productID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return apperror.NewFieldError("id", "field.positive_integer")
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	return h.service.DeleteProduct(r.Context(), productID, brandID, userID)
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		brandId			query		int	false	"brand id"
+// @Param		marketSectionId	query		int	false	"market section id"
+// @Success	200				{object}	ProductsSummaryResponse
+// @Failure	400,500			{object}	apperror.AppError
+// @Router		/products [get]
+func (h *handler) getProductsHandler(w http.ResponseWriter, r *http.Request) error {
+	filter, err := parseFilter(r)
+	if err != nil {
+		return err
+	}
+
+	products, err := h.service.GetProducts(r.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewProductsSummaryResponse(products, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Success	200		{object}	ProductResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/products/{id} [get]
+func (h *handler) getProductHandler(w http.ResponseWriter, r *http.Request) error {
+	productID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return apperror.NewFieldError("id", "field.positive_integer")
+	}
+
+	product, err := h.service.GetProduct(r.Context(), productID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewProductResponse(*product, h.staticURL))
+
+	return nil
+}
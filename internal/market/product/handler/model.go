@@ -0,0 +1,94 @@
+package producthandler
+
+import (
+	"github.com/xw1nchester/kushfinds-backend/internal/market/brand"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/product"
+	marketsection "github.com/xw1nchester/kushfinds-backend/internal/market/section"
+	"github.com/xw1nchester/kushfinds-backend/pkg/types"
+	"github.com/xw1nchester/kushfinds-backend/pkg/utils"
+)
+
+type VariantRequest struct {
+	ID     types.IntOrString `json:"id"`
+	Weight float64           `json:"weight" validate:"gt=0"`
+	Unit   string            `json:"unit" validate:"required,oneof=mg g oz ml pcs"`
+}
+
+type ProductRequest struct {
+	MarketSectionID    types.IntOrString `json:"marketSectionId" validate:"required"`
+	MarketSubSectionID types.IntOrString `json:"marketSubSectionId"`
+	Name               string            `json:"name" validate:"required"`
+	Description        string            `json:"description"`
+	StrainType         string            `json:"strainType" validate:"required,oneof=indica sativa hybrid cbd none"`
+	THCPercent         float64           `json:"thcPercent" validate:"min=0,max=100"`
+	CBDPercent         float64           `json:"cbdPercent" validate:"min=0,max=100"`
+	Pictures           []string          `json:"pictures"`
+	Variants           []VariantRequest  `json:"variants" validate:"dive"`
+	LabTests           []string          `json:"labTests"`
+	IsPublished        *bool             `json:"isPublished" validate:"required"`
+}
+
+func (pr *ProductRequest) ToDomain(brandID, userID int) *product.Product {
+	variants := make([]product.Variant, len(pr.Variants))
+	for i, v := range pr.Variants {
+		variants[i] = product.Variant{
+			ID:     int(v.ID),
+			Weight: v.Weight,
+			Unit:   v.Unit,
+		}
+	}
+
+	var marketSubSection *marketsection.MarketSection
+	if pr.MarketSubSectionID != 0 {
+		marketSubSection = &marketsection.MarketSection{ID: int(pr.MarketSubSectionID)}
+	}
+
+	return &product.Product{
+		UserID:           userID,
+		Brand:            brand.BrandSummary{ID: brandID},
+		MarketSection:    marketsection.MarketSection{ID: int(pr.MarketSectionID)},
+		MarketSubSection: marketSubSection,
+		Name:             pr.Name,
+		Description:      pr.Description,
+		StrainType:       pr.StrainType,
+		THCPercent:       pr.THCPercent,
+		CBDPercent:       pr.CBDPercent,
+		Pictures:         utils.RemoveDuplicates(pr.Pictures),
+		Variants:         variants,
+		LabTests:         utils.RemoveDuplicates(pr.LabTests),
+		IsPublished:      *pr.IsPublished,
+	}
+}
+
+type ProductResponse struct {
+	Product product.Product `json:"product"`
+}
+
+func NewProductResponse(p product.Product, staticURL string) ProductResponse {
+	if p.Brand.Logo != "" {
+		p.Brand.Logo = staticURL + "/" + p.Brand.Logo
+	}
+	for i := range p.Pictures {
+		p.Pictures[i] = staticURL + "/" + p.Pictures[i]
+	}
+	for i := range p.LabTests {
+		p.LabTests[i] = staticURL + "/" + p.LabTests[i]
+	}
+	return ProductResponse{Product: p}
+}
+
+type ProductsSummaryResponse struct {
+	Products []product.ProductSummary `json:"products"`
+}
+
+func NewProductsSummaryResponse(elements []product.ProductSummary, staticURL string) ProductsSummaryResponse {
+	for i := range elements {
+		if elements[i].Picture != "" {
+			elements[i].Picture = staticURL + "/" + elements[i].Picture
+		}
+		if elements[i].Brand.Logo != "" {
+			elements[i].Brand.Logo = staticURL + "/" + elements[i].Brand.Logo
+		}
+	}
+	return ProductsSummaryResponse{Products: elements}
+}
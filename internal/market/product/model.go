@@ -0,0 +1,67 @@
+package product
+
+import (
+	"time"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/market/brand"
+	marketsection "github.com/xw1nchester/kushfinds-backend/internal/market/section"
+)
+
+const (
+	StrainTypeIndica = "indica"
+	StrainTypeSativa = "sativa"
+	StrainTypeHybrid = "hybrid"
+	StrainTypeCBD    = "cbd"
+	StrainTypeNone   = "none"
+)
+
+const (
+	UnitMilligram  = "mg"
+	UnitGram       = "g"
+	UnitOunce      = "oz"
+	UnitMilliliter = "ml"
+	UnitPiece      = "pcs"
+)
+
+type Variant struct {
+	ID     int     `json:"id"`
+	Weight float64 `json:"weight"`
+	Unit   string  `json:"unit"`
+}
+
+type Product struct {
+	ID               int                          `json:"id"`
+	UserID           int                          `json:"-"`
+	Brand            brand.BrandSummary           `json:"brand"`
+	MarketSection    marketsection.MarketSection  `json:"marketSection"`
+	MarketSubSection *marketsection.MarketSection `json:"marketSubSection"`
+	Name             string                       `json:"name"`
+	Description      string                       `json:"description"`
+	StrainType       string                       `json:"strainType"`
+	THCPercent       float64                      `json:"thcPercent"`
+	CBDPercent       float64                      `json:"cbdPercent"`
+	Pictures         []string                     `json:"pictures"`
+	Variants         []Variant                    `json:"variants"`
+	LabTests         []string                     `json:"labTests"`
+	IsPublished      bool                         `json:"isPublished"`
+	IsBrandPublished bool                         `json:"-"`
+	CreatedAt        time.Time                    `json:"createdAt"`
+	UpdatedAt        time.Time                    `json:"updatedAt"`
+}
+
+type ProductSummary struct {
+	ID            int                         `json:"id"`
+	Name          string                      `json:"name"`
+	Picture       string                      `json:"picture"`
+	Brand         brand.BrandSummary          `json:"brand"`
+	MarketSection marketsection.MarketSection `json:"marketSection"`
+	StrainType    string                      `json:"strainType"`
+	THCPercent    float64                     `json:"thcPercent"`
+	CBDPercent    float64                     `json:"cbdPercent"`
+	IsPublished   bool                        `json:"isPublished"`
+}
+
+type Filter struct {
+	BrandID         int
+	MarketSectionID int
+}
@@ -0,0 +1,311 @@
+package productservice
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/audit"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/brand"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/product"
+	productdb "github.com/xw1nchester/kushfinds-backend/internal/market/product/db"
+	marketsection "github.com/xw1nchester/kushfinds-backend/internal/market/section"
+	"github.com/xw1nchester/kushfinds-backend/internal/team"
+	"github.com/xw1nchester/kushfinds-backend/internal/tracing"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor"
+	"go.uber.org/zap"
+)
+
+type Repository interface {
+	GetProductByID(ctx context.Context, id int) (*product.Product, error)
+	GetBrandProducts(ctx context.Context, brandID int) ([]product.ProductSummary, error)
+	GetPublishedProducts(ctx context.Context, filter product.Filter) ([]product.ProductSummary, error)
+	CreateProduct(ctx context.Context, data product.Product) (*product.Product, error)
+	UpdateProduct(ctx context.Context, data product.Product) (*product.Product, error)
+	DeleteProduct(ctx context.Context, productID int) error
+}
+
+var (
+	ErrDuplicateVariant   = apperror.NewAppError("product.duplicate_variant", "product variants should be unique")
+	ErrSubSectionMismatch = apperror.NewAppError("product.sub_section_mismatch", "the market subsection does not belong to the market section")
+)
+
+type BrandService interface {
+	CheckBrandPermission(ctx context.Context, brandID, userID int, permission team.Permission) (int, error)
+	GetManagedBrand(ctx context.Context, brandID, userID int) (*brand.Brand, error)
+}
+
+type MarketSectionService interface {
+	CheckMarketSectionsExist(ctx context.Context, IDs []int) error
+}
+
+type Notifier interface {
+	NotifyNewProduct(ctx context.Context, p product.Product)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, record audit.Record) error
+}
+
+type service struct {
+	repository           Repository
+	brandService         BrandService
+	marketSectionService MarketSectionService
+	notifier             Notifier
+	txManager            transactor.Manager
+	auditRecorder        AuditRecorder
+	logger               *zap.Logger
+}
+
+func New(
+	repository Repository,
+	brandService BrandService,
+	marketSectionService MarketSectionService,
+	notifier Notifier,
+	txManager transactor.Manager,
+	auditRecorder AuditRecorder,
+	logger *zap.Logger,
+) *service {
+	return &service{
+		repository:           repository,
+		brandService:         brandService,
+		marketSectionService: marketSectionService,
+		notifier:             notifier,
+		txManager:            txManager,
+		auditRecorder:        auditRecorder,
+		logger:               logger,
+	}
+}
+
+// validateProductData checks the product against the brand it is saved
+// under and returns that brand.
+func (s *service) validateProductData(ctx context.Context, data product.Product) (*brand.Brand, error) {
+	ctx, span := tracing.Start(ctx, "productservice.validateProductData")
+	defer span.End()
+
+	existingBrand, err := s.brandService.GetManagedBrand(ctx, data.Brand.ID, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	marketSectionIDs := []int{data.MarketSection.ID}
+	if data.MarketSubSection != nil {
+		marketSectionIDs = append(marketSectionIDs, data.MarketSubSection.ID)
+	}
+
+	if err := s.marketSectionService.CheckMarketSectionsExist(ctx, marketSectionIDs); err != nil {
+		return nil, err
+	}
+
+	// subsections only exist under a section through the brand that offers them
+	if data.MarketSubSection != nil {
+		if data.MarketSection.ID != existingBrand.MarketSection.ID {
+			return nil, ErrSubSectionMismatch
+		}
+
+		if !slices.ContainsFunc(existingBrand.MarketSubSections, func(ms marketsection.MarketSection) bool {
+			return ms.ID == data.MarketSubSection.ID
+		}) {
+			return nil, ErrSubSectionMismatch
+		}
+	}
+
+	seen := map[product.Variant]bool{}
+	for _, v := range data.Variants {
+		key := product.Variant{Weight: v.Weight, Unit: v.Unit}
+		if seen[key] {
+			return nil, ErrDuplicateVariant
+		}
+		seen[key] = true
+	}
+
+	return existingBrand, nil
+}
+
+// isBrandPublic reports whether followers can see the brand's products,
+// which is only the case for approved brands the owner has published.
+func isBrandPublic(b *brand.Brand) bool {
+	return b.IsPublished && b.ModerationStatus == brand.ModerationStatusApproved
+}
+
+func (s *service) getProductByID(ctx context.Context, productID int) (*product.Product, error) {
+	existingProduct, err := s.repository.GetProductByID(ctx, productID)
+	if err != nil {
+		if errors.Is(err, productdb.ErrProductNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching product by id", zap.Error(err))
+
+		return nil, err
+	}
+
+	return existingProduct, nil
+}
+
+func (s *service) CreateProduct(ctx context.Context, data product.Product) (*product.Product, error) {
+	ctx, span := tracing.Start(ctx, "productservice.CreateProduct")
+	defer span.End()
+
+	existingBrand, err := s.validateProductData(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range data.Variants {
+		data.Variants[i].ID = 0
+	}
+
+	createdProduct, err := s.repository.CreateProduct(ctx, data)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when creating product", zap.Error(err))
+		return nil, err
+	}
+
+	if createdProduct.IsPublished && isBrandPublic(existingBrand) {
+		s.notifier.NotifyNewProduct(ctx, *createdProduct)
+	}
+
+	return createdProduct, nil
+}
+
+func (s *service) GetBrandProducts(ctx context.Context, brandID, userID int) ([]product.ProductSummary, error) {
+	if _, err := s.brandService.CheckBrandPermission(ctx, brandID, userID, team.PermissionView); err != nil {
+		return nil, err
+	}
+
+	products, err := s.repository.GetBrandProducts(ctx, brandID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching brand products", zap.Error(err))
+
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// authorizeProduct returns the product of the brand if the user has
+// the permission in the team of the brand business.
+func (s *service) authorizeProduct(
+	ctx context.Context,
+	productID int,
+	brandID int,
+	userID int,
+	permission team.Permission,
+) (*product.Product, error) {
+	if _, err := s.brandService.CheckBrandPermission(ctx, brandID, userID, permission); err != nil {
+		return nil, err
+	}
+
+	existingProduct, err := s.getProductByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingProduct.Brand.ID != brandID {
+		return nil, apperror.ErrNotFound
+	}
+
+	return existingProduct, nil
+}
+
+func (s *service) GetBrandProduct(ctx context.Context, productID, brandID, userID int) (*product.Product, error) {
+	return s.authorizeProduct(ctx, productID, brandID, userID, team.PermissionView)
+}
+
+func (s *service) UpdateProduct(ctx context.Context, data product.Product) (*product.Product, error) {
+	ctx, span := tracing.Start(ctx, "productservice.UpdateProduct")
+	defer span.End()
+
+	existingProduct, err := s.authorizeProduct(ctx, data.ID, data.Brand.ID, data.UserID, team.PermissionManageBrands)
+	if err != nil {
+		return nil, err
+	}
+
+	existingBrand, err := s.validateProductData(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	existingVariants := map[int]bool{}
+	for _, v := range existingProduct.Variants {
+		existingVariants[v.ID] = true
+	}
+
+	for _, v := range data.Variants {
+		if v.ID != 0 && !existingVariants[v.ID] {
+			return nil, apperror.ErrNotFound
+		}
+	}
+
+	updatedProduct, err := s.repository.UpdateProduct(ctx, data)
+	if err != nil {
+		if errors.Is(err, productdb.ErrProductNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when updating product", zap.Error(err))
+
+		return nil, err
+	}
+
+	if !existingProduct.IsPublished && updatedProduct.IsPublished && isBrandPublic(existingBrand) {
+		s.notifier.NotifyNewProduct(ctx, *updatedProduct)
+	}
+
+	return updatedProduct, nil
+}
+
+func (s *service) DeleteProduct(ctx context.Context, productID, brandID, userID int) error {
+	existingProduct, err := s.authorizeProduct(ctx, productID, brandID, userID, team.PermissionManageBrands)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.DeleteProduct(ctx, productID); err != nil {
+			logging.FromContext(ctx, s.logger).Error("unexpected error when deleting product", zap.Error(err))
+			return err
+		}
+
+		return s.auditRecorder.Record(ctx, audit.Record{
+			ActorID:    userID,
+			Action:     audit.ActionProductDelete,
+			EntityType: audit.EntityProduct,
+			EntityID:   productID,
+			Before:     existingProduct,
+		})
+	})
+}
+
+func (s *service) GetProducts(ctx context.Context, filter product.Filter) ([]product.ProductSummary, error) {
+	ctx, span := tracing.Start(ctx, "productservice.GetProducts")
+	defer span.End()
+
+	products, err := s.repository.GetPublishedProducts(ctx, filter)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching published products", zap.Error(err))
+
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (s *service) GetProduct(ctx context.Context, productID int) (*product.Product, error) {
+	ctx, span := tracing.Start(ctx, "productservice.GetProduct")
+	defer span.End()
+
+	existingProduct, err := s.getProductByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existingProduct.IsPublished || !existingProduct.IsBrandPublished {
+		return nil, apperror.ErrNotFound
+	}
+
+	return existingProduct, nil
+}
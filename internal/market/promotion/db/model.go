@@ -0,0 +1,10 @@
+package promotiondb
+
+import "errors"
+
+var (
+	ErrPromotionNotFound   = errors.New("promotion not found")
+	ErrPromotionExhausted  = errors.New("promotion usage limit reached")
+	ErrCouponAlreadyExists = errors.New("coupon code already exists")
+	ErrTargetNotFound      = errors.New("promotion target not found")
+)
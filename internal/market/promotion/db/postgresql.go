@@ -0,0 +1,571 @@
+package promotiondb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/promotion"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor/postgresql"
+	"go.uber.org/zap"
+)
+
+const (
+	uniqueViolationCode     = "23505"
+	foreignKeyViolationCode = "23503"
+)
+
+type repository struct {
+	client *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func New(client *pgxpool.Pool, logger *zap.Logger) *repository {
+	return &repository{
+		client: client,
+		logger: logger,
+	}
+}
+
+func hasCode(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
+
+const promotionColumns = `
+	p.id,
+	p.store_id,
+	p.name,
+	p.description,
+	p.type,
+	p.value,
+	p.buy_quantity,
+	p.get_quantity,
+	ARRAY(
+		SELECT pms.market_section_id
+		FROM promotions_market_sections pms
+		WHERE pms.promotion_id = p.id
+		ORDER BY pms.market_section_id
+	),
+	ARRAY(
+		SELECT pb.brand_id
+		FROM promotions_brands pb
+		WHERE pb.promotion_id = p.id
+		ORDER BY pb.brand_id
+	),
+	p.starts_at,
+	p.ends_at,
+	p.coupon_code,
+	p.usage_limit,
+	p.per_user_limit,
+	p.usage_count,
+	p.is_active,
+	p.created_at,
+	p.updated_at
+`
+
+func scanPromotion(row pgx.Row, dest ...any) (*promotion.Promotion, error) {
+	var p promotion.Promotion
+
+	fields := []any{
+		&p.ID,
+		&p.StoreID,
+		&p.Name,
+		&p.Description,
+		&p.Type,
+		&p.Value,
+		&p.BuyQuantity,
+		&p.GetQuantity,
+		&p.MarketSectionIDs,
+		&p.BrandIDs,
+		&p.StartsAt,
+		&p.EndsAt,
+		&p.CouponCode,
+		&p.UsageLimit,
+		&p.PerUserLimit,
+		&p.UsageCount,
+		&p.IsActive,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	}
+
+	if err := row.Scan(append(fields, dest...)...); err != nil {
+		return nil, err
+	}
+
+	p.Windows = make([]promotion.Window, 0)
+
+	return &p, nil
+}
+
+// loadWindows attaches the weekly windows to the given promotions.
+func (r *repository) loadWindows(ctx context.Context, promotions []*promotion.Promotion) error {
+	if len(promotions) == 0 {
+		return nil
+	}
+
+	IDs := make([]int, len(promotions))
+	byID := make(map[int]*promotion.Promotion, len(promotions))
+	for i, p := range promotions {
+		IDs[i] = p.ID
+		byID[p.ID] = p
+	}
+
+	query := `
+		SELECT promotion_id, weekday, to_char(starts_at, 'HH24:MI'), to_char(ends_at, 'HH24:MI')
+		FROM promotions_windows
+		WHERE promotion_id = ANY($1)
+		ORDER BY weekday, starts_at, id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := postgresql.GetExecutor(ctx, r.client).Query(ctx, query, IDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var promotionID int
+		var w promotion.Window
+		if err := rows.Scan(&promotionID, &w.Weekday, &w.StartsAt, &w.EndsAt); err != nil {
+			return fmt.Errorf("failed to scan row: %v", err)
+		}
+		byID[promotionID].Windows = append(byID[promotionID].Windows, w)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("row error: %v", err)
+	}
+
+	return nil
+}
+
+func (r *repository) getPromotions(ctx context.Context, condition string, args ...any) ([]promotion.Promotion, error) {
+	query := "SELECT " + promotionColumns + " FROM promotions p WHERE " + condition + " ORDER BY p.created_at DESC, p.id DESC"
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := postgresql.GetExecutor(ctx, r.client).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promotions := make([]*promotion.Promotion, 0)
+	for rows.Next() {
+		p, err := scanPromotion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+		promotions = append(promotions, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	if err := r.loadWindows(ctx, promotions); err != nil {
+		return nil, err
+	}
+
+	res := make([]promotion.Promotion, len(promotions))
+	for i, p := range promotions {
+		res[i] = *p
+	}
+
+	return res, nil
+}
+
+func (r *repository) GetStorePromotions(ctx context.Context, storeID int) ([]promotion.Promotion, error) {
+	return r.getPromotions(ctx, "p.store_id=$1", storeID)
+}
+
+func (r *repository) GetStorePromotion(ctx context.Context, storeID, promotionID int) (*promotion.Promotion, error) {
+	promotions, err := r.getPromotions(ctx, "p.store_id=$1 AND p.id=$2", storeID, promotionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(promotions) == 0 {
+		return nil, ErrPromotionNotFound
+	}
+
+	return &promotions[0], nil
+}
+
+// GetCheckoutPromotions returns the enabled promotions of the store that
+// apply without a coupon along with the ones matching the coupon code.
+func (r *repository) GetCheckoutPromotions(ctx context.Context, storeID int, couponCode string) ([]promotion.Promotion, error) {
+	return r.getPromotions(
+		ctx,
+		"p.store_id=$1 AND p.is_active=true AND (p.coupon_code IS NULL OR lower(p.coupon_code)=lower($2))",
+		storeID,
+		couponCode,
+	)
+}
+
+func (r *repository) setPromotionRelatedEntities(ctx context.Context, promotionID int, data promotion.Promotion) error {
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	for _, table := range []string{"promotions_windows", "promotions_market_sections", "promotions_brands"} {
+		query := fmt.Sprintf("DELETE FROM %s WHERE promotion_id=$1", table)
+		logging.LogSQLQuery(ctx, r.logger, query)
+		if _, err := executor.Exec(ctx, query, promotionID); err != nil {
+			return err
+		}
+	}
+
+	if len(data.Windows) > 0 {
+		query := `
+			INSERT INTO promotions_windows (promotion_id, weekday, starts_at, ends_at)
+			VALUES ($1, $2, $3, $4)
+		`
+		for _, w := range data.Windows {
+			logging.LogSQLQuery(ctx, r.logger, query)
+			if _, err := executor.Exec(ctx, query, promotionID, w.Weekday, w.StartsAt, w.EndsAt); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(data.MarketSectionIDs) > 0 {
+		query := `
+			INSERT INTO promotions_market_sections (promotion_id, market_section_id)
+			SELECT $1, unnest($2::int[])
+			ON CONFLICT DO NOTHING
+		`
+		logging.LogSQLQuery(ctx, r.logger, query)
+		if _, err := executor.Exec(ctx, query, promotionID, data.MarketSectionIDs); err != nil {
+			return err
+		}
+	}
+
+	if len(data.BrandIDs) > 0 {
+		query := `
+			INSERT INTO promotions_brands (promotion_id, brand_id)
+			SELECT $1, unnest($2::int[])
+			ON CONFLICT DO NOTHING
+		`
+		logging.LogSQLQuery(ctx, r.logger, query)
+		if _, err := executor.Exec(ctx, query, promotionID, data.BrandIDs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func mapWriteError(err error) error {
+	if hasCode(err, uniqueViolationCode) {
+		return ErrCouponAlreadyExists
+	}
+	if hasCode(err, foreignKeyViolationCode) {
+		return ErrTargetNotFound
+	}
+	return err
+}
+
+// CreatePromotion has to run within a transaction together with its targets and windows.
+func (r *repository) CreatePromotion(ctx context.Context, data promotion.Promotion) (*promotion.Promotion, error) {
+	query := `
+		INSERT INTO promotions (
+			store_id,
+			name,
+			description,
+			type,
+			value,
+			buy_quantity,
+			get_quantity,
+			starts_at,
+			ends_at,
+			coupon_code,
+			usage_limit,
+			per_user_limit,
+			is_active
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	var promotionID int
+	if err := postgresql.GetExecutor(ctx, r.client).QueryRow(
+		ctx,
+		query,
+		data.StoreID,
+		data.Name,
+		data.Description,
+		data.Type,
+		data.Value,
+		data.BuyQuantity,
+		data.GetQuantity,
+		data.StartsAt,
+		data.EndsAt,
+		data.CouponCode,
+		data.UsageLimit,
+		data.PerUserLimit,
+		data.IsActive,
+	).Scan(&promotionID); err != nil {
+		return nil, mapWriteError(err)
+	}
+
+	if err := r.setPromotionRelatedEntities(ctx, promotionID, data); err != nil {
+		return nil, mapWriteError(err)
+	}
+
+	return r.GetStorePromotion(ctx, data.StoreID, promotionID)
+}
+
+// UpdatePromotion has to run within a transaction together with its targets and windows.
+// The usage count is kept.
+func (r *repository) UpdatePromotion(ctx context.Context, data promotion.Promotion) (*promotion.Promotion, error) {
+	query := `
+		UPDATE promotions
+		SET
+			name=$3,
+			description=$4,
+			type=$5,
+			value=$6,
+			buy_quantity=$7,
+			get_quantity=$8,
+			starts_at=$9,
+			ends_at=$10,
+			coupon_code=$11,
+			usage_limit=$12,
+			per_user_limit=$13,
+			is_active=$14,
+			updated_at=NOW()
+		WHERE store_id=$1 AND id=$2
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := postgresql.GetExecutor(ctx, r.client).Exec(
+		ctx,
+		query,
+		data.StoreID,
+		data.ID,
+		data.Name,
+		data.Description,
+		data.Type,
+		data.Value,
+		data.BuyQuantity,
+		data.GetQuantity,
+		data.StartsAt,
+		data.EndsAt,
+		data.CouponCode,
+		data.UsageLimit,
+		data.PerUserLimit,
+		data.IsActive,
+	)
+	if err != nil {
+		return nil, mapWriteError(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return nil, ErrPromotionNotFound
+	}
+
+	if err := r.setPromotionRelatedEntities(ctx, data.ID, data); err != nil {
+		return nil, mapWriteError(err)
+	}
+
+	return r.GetStorePromotion(ctx, data.StoreID, data.ID)
+}
+
+func (r *repository) DeletePromotion(ctx context.Context, storeID, promotionID int) error {
+	query := "DELETE FROM promotions WHERE store_id=$1 AND id=$2"
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, storeID, promotionID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrPromotionNotFound
+	}
+
+	return nil
+}
+
+// CountUserRedemptions returns how many orders of the user the promotion
+// was applied to. Cancelled orders have given their usage back.
+func (r *repository) CountUserRedemptions(ctx context.Context, promotionID, userID int) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM orders_promotions op
+		JOIN orders o ON op.order_id = o.id
+		WHERE op.promotion_id=$1 AND o.user_id=$2 AND o.status <> 'cancelled'
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	var count int
+	if err := postgresql.GetExecutor(ctx, r.client).QueryRow(ctx, query, promotionID, userID).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// RedeemPromotion counts one more use of the promotion by the user unless its
+// usage limit or the user's one is reached, in which case ErrPromotionExhausted
+// is returned. The update locks the promotion until the transaction ends, so
+// the user's orders are counted without racing parallel checkouts.
+func (r *repository) RedeemPromotion(ctx context.Context, promotionID, userID int) error {
+	query := `
+		UPDATE promotions
+		SET usage_count=usage_count+1
+		WHERE id=$1 AND (usage_limit IS NULL OR usage_count < usage_limit)
+		RETURNING per_user_limit
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	var perUserLimit *int
+	if err := postgresql.GetExecutor(ctx, r.client).QueryRow(ctx, query, promotionID).Scan(&perUserLimit); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPromotionExhausted
+		}
+		return err
+	}
+
+	if perUserLimit == nil {
+		return nil
+	}
+
+	count, err := r.CountUserRedemptions(ctx, promotionID, userID)
+	if err != nil {
+		return err
+	}
+
+	if count >= *perUserLimit {
+		return ErrPromotionExhausted
+	}
+
+	return nil
+}
+
+// ReleaseOrderPromotions gives back the uses of the promotions applied
+// to the order.
+func (r *repository) ReleaseOrderPromotions(ctx context.Context, orderID int) error {
+	query := `
+		UPDATE promotions p
+		SET usage_count = GREATEST(p.usage_count - op.uses, 0)
+		FROM (
+			SELECT promotion_id, COUNT(*) AS uses
+			FROM orders_promotions
+			WHERE order_id=$1 AND promotion_id IS NOT NULL
+			GROUP BY promotion_id
+		) op
+		WHERE p.id = op.promotion_id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	_, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, orderID)
+
+	return err
+}
+
+// GetDeals returns the enabled promotions without coupons of published stores
+// that have not ended or been used up.
+func (r *repository) GetDeals(ctx context.Context, filter promotion.DealFilter) ([]promotion.Deal, error) {
+	conditions := []string{
+		"p.is_active=true",
+		"p.coupon_code IS NULL",
+		"(p.ends_at IS NULL OR p.ends_at > NOW())",
+		"(p.usage_limit IS NULL OR p.usage_count < p.usage_limit)",
+		"s.is_published=true",
+		"b.is_published=true",
+	}
+	args := []any{}
+
+	if filter.CountryID != 0 {
+		args = append(args, filter.CountryID)
+		conditions = append(conditions, fmt.Sprintf("s.country_id=$%d", len(args)))
+	}
+
+	if filter.StateID != 0 {
+		args = append(args, filter.StateID)
+		conditions = append(conditions, fmt.Sprintf("s.state_id=$%d", len(args)))
+	}
+
+	if filter.RegionID != 0 {
+		args = append(args, filter.RegionID)
+		conditions = append(conditions, fmt.Sprintf("s.region_id=$%d", len(args)))
+	}
+
+	// promotions without targets of a kind apply to everything
+	if filter.MarketSectionID != 0 {
+		args = append(args, filter.MarketSectionID)
+		conditions = append(conditions, fmt.Sprintf(`(
+			NOT EXISTS (SELECT 1 FROM promotions_market_sections pms WHERE pms.promotion_id = p.id)
+			OR EXISTS (SELECT 1 FROM promotions_market_sections pms WHERE pms.promotion_id = p.id AND pms.market_section_id=$%d)
+		)`, len(args)))
+	}
+
+	if filter.BrandID != 0 {
+		args = append(args, filter.BrandID)
+		conditions = append(conditions, fmt.Sprintf(`(
+			NOT EXISTS (SELECT 1 FROM promotions_brands pb WHERE pb.promotion_id = p.id)
+			OR EXISTS (SELECT 1 FROM promotions_brands pb WHERE pb.promotion_id = p.id AND pb.brand_id=$%d)
+		)`, len(args)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s, s.id, s.name, s.banner, st.timezone
+		FROM promotions p
+		JOIN stores s ON p.store_id = s.id
+		JOIN brands b ON s.brand_id = b.id
+		JOIN states st ON s.state_id = st.id
+		WHERE %s
+		ORDER BY p.created_at DESC, p.id DESC
+	`, promotionColumns, strings.Join(conditions, " AND "))
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deals := make([]promotion.Deal, 0)
+	for rows.Next() {
+		var d promotion.Deal
+
+		p, err := scanPromotion(rows, &d.Store.ID, &d.Store.Name, &d.Store.Banner, &d.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+
+		d.Promotion = *p
+		deals = append(deals, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	promotions := make([]*promotion.Promotion, len(deals))
+	for i := range deals {
+		promotions[i] = &deals[i].Promotion
+	}
+
+	if err := r.loadWindows(ctx, promotions); err != nil {
+		return nil, err
+	}
+
+	return deals, nil
+}
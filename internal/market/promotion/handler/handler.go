@@ -0,0 +1,303 @@
+package promotionhandler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	jwtmiddleware "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt/middleware"
+	"github.com/xw1nchester/kushfinds-backend/internal/handlers"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/promotion"
+	"go.uber.org/zap"
+)
+
+var validate = apperror.NewValidator()
+
+type Service interface {
+	GetStorePromotions(ctx context.Context, storeID, userID int) ([]promotion.Promotion, error)
+	GetStorePromotion(ctx context.Context, storeID, promotionID, userID int) (*promotion.Promotion, error)
+	CreatePromotion(ctx context.Context, data promotion.Promotion, userID int) (*promotion.Promotion, error)
+	UpdatePromotion(ctx context.Context, data promotion.Promotion, userID int) (*promotion.Promotion, error)
+	DeletePromotion(ctx context.Context, storeID, promotionID, userID int) error
+
+	GetDeals(ctx context.Context, filter promotion.DealFilter) ([]promotion.Deal, error)
+}
+
+type handler struct {
+	service        Service
+	authMiddleware func(http.Handler) http.Handler
+	ageMiddleware  func(http.Handler) http.Handler
+	staticURL      string
+	logger         *zap.Logger
+}
+
+func New(
+	service Service,
+	authMiddleware func(http.Handler) http.Handler,
+	ageMiddleware func(http.Handler) http.Handler,
+	staticURL string,
+	logger *zap.Logger,
+) handlers.Handler {
+	return &handler{
+		service:        service,
+		authMiddleware: authMiddleware,
+		ageMiddleware:  ageMiddleware,
+		staticURL:      staticURL,
+		logger:         logger,
+	}
+}
+
+func (h *handler) Register(router chi.Router) {
+	router.Route("/deals", func(dealRouter chi.Router) {
+		dealRouter.Use(h.authMiddleware, h.ageMiddleware)
+		dealRouter.Get("/", apperror.Middleware(h.getDealsHandler))
+	})
+
+	router.Route("/me/stores/{store_id}/promotions", func(privatePromotionRouter chi.Router) {
+		privatePromotionRouter.Use(h.authMiddleware)
+		privatePromotionRouter.Get("/", apperror.Middleware(h.getStorePromotionsHandler))
+		privatePromotionRouter.Post("/", apperror.Middleware(h.createPromotionHandler))
+		privatePromotionRouter.Get("/{id}", apperror.Middleware(h.getStorePromotionHandler))
+		privatePromotionRouter.Put("/{id}", apperror.Middleware(h.updatePromotionHandler))
+		privatePromotionRouter.Delete("/{id}", apperror.Middleware(h.deletePromotionHandler))
+	})
+}
+
+func parseIntParam(r *http.Request, name string) (int, error) {
+	value, err := strconv.Atoi(chi.URLParam(r, name))
+	if err != nil {
+		return 0, apperror.NewFieldError(name, "field.positive_integer")
+	}
+	return value, nil
+}
+
+func parseIntQuery(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, apperror.NewFieldError(name, "field.positive_integer")
+	}
+
+	return parsed, nil
+}
+
+func parseDealFilter(r *http.Request) (promotion.DealFilter, error) {
+	var filter promotion.DealFilter
+
+	params := []struct {
+		name  string
+		value *int
+	}{
+		{"countryId", &filter.CountryID},
+		{"stateId", &filter.StateID},
+		{"regionId", &filter.RegionID},
+		{"marketSectionId", &filter.MarketSectionID},
+		{"brandId", &filter.BrandID},
+	}
+
+	for _, p := range params {
+		value, err := parseIntQuery(r, p.name)
+		if err != nil {
+			return filter, err
+		}
+		*p.value = value
+	}
+
+	if activeNow := r.URL.Query().Get("activeNow"); activeNow != "" {
+		value, err := strconv.ParseBool(activeNow)
+		if err != nil {
+			return filter, apperror.NewFieldError("activeNow", "field.boolean")
+		}
+		filter.ActiveNow = value
+	}
+
+	return filter, nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		countryId		query		int		false	"country id"
+// @Param		stateId			query		int		false	"state id"
+// @Param		regionId		query		int		false	"region id"
+// @Param		marketSectionId	query		int		false	"market section id"
+// @Param		brandId			query		int		false	"brand id"
+// @Param		activeNow		query		bool	false	"only deals valid at the moment"
+// @Success	200				{object}	DealsResponse
+// @Failure	400,403,500		{object}	apperror.AppError
+// @Router		/deals [get]
+func (h *handler) getDealsHandler(w http.ResponseWriter, r *http.Request) error {
+	filter, err := parseDealFilter(r)
+	if err != nil {
+		return err
+	}
+
+	deals, err := h.service.GetDeals(r.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewDealsResponse(deals, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Success	200		{object}	PromotionsResponse
+// @Failure	400,404,500	{object}	apperror.AppError
+// @Router		/me/stores/{store_id}/promotions [get]
+func (h *handler) getStorePromotionsHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "store_id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	promotions, err := h.service.GetStorePromotions(r.Context(), storeID, userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, PromotionsResponse{Promotions: promotions})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Success	200		{object}	PromotionResponse
+// @Failure	400,404,500	{object}	apperror.AppError
+// @Router		/me/stores/{store_id}/promotions/{id} [get]
+func (h *handler) getStorePromotionHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "store_id")
+	if err != nil {
+		return err
+	}
+
+	promotionID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	existingPromotion, err := h.service.GetStorePromotion(r.Context(), storeID, promotionID, userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, PromotionResponse{Promotion: *existingPromotion})
+
+	return nil
+}
+
+func (h *handler) decodePromotion(r *http.Request) (*PromotionRequest, error) {
+	var dto PromotionRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return nil, apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return nil, apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	return &dto, nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		request	body		PromotionRequest	true	"request body"
+// @Success	200		{object}	PromotionResponse
+// @Failure	400,404,409,500	{object}	apperror.AppError
+// @Router		/me/stores/{store_id}/promotions [post]
+func (h *handler) createPromotionHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "store_id")
+	if err != nil {
+		return err
+	}
+
+	dto, err := h.decodePromotion(r)
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	createdPromotion, err := h.service.CreatePromotion(r.Context(), dto.ToDomain(storeID), userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, PromotionResponse{Promotion: *createdPromotion})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		request	body		PromotionRequest	true	"request body"
+// @Success	200		{object}	PromotionResponse
+// @Failure	400,404,409,500	{object}	apperror.AppError
+// @Router		/me/stores/{store_id}/promotions/{id} [put]
+func (h *handler) updatePromotionHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "store_id")
+	if err != nil {
+		return err
+	}
+
+	promotionID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	dto, err := h.decodePromotion(r)
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	data := dto.ToDomain(storeID)
+	data.ID = promotionID
+
+	updatedPromotion, err := h.service.UpdatePromotion(r.Context(), data, userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, PromotionResponse{Promotion: *updatedPromotion})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Success	200
+// @Failure	400,404,500	{object}	apperror.AppError
+// @Router		/me/stores/{store_id}/promotions/{id} [delete]
+func (h *handler) deletePromotionHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "store_id")
+	if err != nil {
+		return err
+	}
+
+	promotionID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	return h.service.DeletePromotion(r.Context(), storeID, promotionID, userID)
+}
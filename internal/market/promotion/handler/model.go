@@ -0,0 +1,112 @@
+package promotionhandler
+
+import (
+	"strings"
+	"time"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/market/promotion"
+	"github.com/xw1nchester/kushfinds-backend/pkg/types"
+)
+
+type WindowRequest struct {
+	Weekday  types.IntOrString `json:"weekday" validate:"min=0,max=6"`
+	StartsAt string            `json:"startsAt" validate:"required,datetime=15:04"`
+	EndsAt   string            `json:"endsAt" validate:"required,datetime=15:04"`
+}
+
+type PromotionRequest struct {
+	Name             string              `json:"name" validate:"required,max=255"`
+	Description      string              `json:"description" validate:"max=1000"`
+	Type             string              `json:"type" validate:"required,oneof=percentage fixed bogo"`
+	Value            types.IntOrString   `json:"value" validate:"min=0"`
+	BuyQuantity      types.IntOrString   `json:"buyQuantity" validate:"min=0,max=100"`
+	GetQuantity      types.IntOrString   `json:"getQuantity" validate:"min=0,max=100"`
+	MarketSectionIDs []types.IntOrString `json:"marketSectionIds" validate:"dive,gt=0"`
+	BrandIDs         []types.IntOrString `json:"brandIds" validate:"dive,gt=0"`
+	StartsAt         *time.Time          `json:"startsAt"`
+	EndsAt           *time.Time          `json:"endsAt"`
+	Windows          []WindowRequest     `json:"windows" validate:"dive"`
+	CouponCode       string              `json:"couponCode" validate:"omitempty,alphanum,min=3,max=32"`
+	UsageLimit       *types.IntOrString  `json:"usageLimit" validate:"omitempty,gt=0"`
+	PerUserLimit     *types.IntOrString  `json:"perUserLimit" validate:"omitempty,gt=0"`
+	IsActive         *bool               `json:"isActive" validate:"required"`
+}
+
+func toInts(ids []types.IntOrString) []int {
+	res := make([]int, len(ids))
+	for i, id := range ids {
+		res[i] = int(id)
+	}
+	return res
+}
+
+func toIntPtr(value *types.IntOrString) *int {
+	if value == nil {
+		return nil
+	}
+	res := int(*value)
+	return &res
+}
+
+func (pr *PromotionRequest) ToDomain(storeID int) promotion.Promotion {
+	windows := make([]promotion.Window, len(pr.Windows))
+	for i, w := range pr.Windows {
+		windows[i] = promotion.Window{
+			Weekday:  int(w.Weekday),
+			StartsAt: w.StartsAt,
+			EndsAt:   w.EndsAt,
+		}
+	}
+
+	value := int(pr.Value)
+	// items got with BOGO promotions are free unless stated otherwise
+	if pr.Type == promotion.TypeBOGO && value == 0 {
+		value = 100
+	}
+
+	var couponCode *string
+	if pr.CouponCode != "" {
+		code := strings.ToUpper(pr.CouponCode)
+		couponCode = &code
+	}
+
+	return promotion.Promotion{
+		StoreID:          storeID,
+		Name:             pr.Name,
+		Description:      pr.Description,
+		Type:             pr.Type,
+		Value:            value,
+		BuyQuantity:      int(pr.BuyQuantity),
+		GetQuantity:      int(pr.GetQuantity),
+		MarketSectionIDs: toInts(pr.MarketSectionIDs),
+		BrandIDs:         toInts(pr.BrandIDs),
+		StartsAt:         pr.StartsAt,
+		EndsAt:           pr.EndsAt,
+		Windows:          windows,
+		CouponCode:       couponCode,
+		UsageLimit:       toIntPtr(pr.UsageLimit),
+		PerUserLimit:     toIntPtr(pr.PerUserLimit),
+		IsActive:         *pr.IsActive,
+	}
+}
+
+type PromotionResponse struct {
+	Promotion promotion.Promotion `json:"promotion"`
+}
+
+type PromotionsResponse struct {
+	Promotions []promotion.Promotion `json:"promotions"`
+}
+
+type DealsResponse struct {
+	Deals []promotion.Deal `json:"deals"`
+}
+
+func NewDealsResponse(elements []promotion.Deal, staticURL string) DealsResponse {
+	for i := range elements {
+		if elements[i].Store.Banner != "" {
+			elements[i].Store.Banner = staticURL + "/" + elements[i].Store.Banner
+		}
+	}
+	return DealsResponse{Deals: elements}
+}
@@ -0,0 +1,77 @@
+package promotion
+
+import "time"
+
+const (
+	TypePercentage = "percentage"
+	TypeFixed      = "fixed"
+	TypeBOGO       = "bogo"
+)
+
+const TimeLayout = "15:04"
+
+// Window is a recurring weekly time span in the timezone of the store.
+// If EndsAt is not after StartsAt the window ends on the next day.
+type Window struct {
+	Weekday  int    `json:"weekday"`
+	StartsAt string `json:"startsAt"`
+	EndsAt   string `json:"endsAt"`
+}
+
+// Promotion is a discount of a store. Value is a percentage for percentage
+// promotions, an amount off the eligible items for fixed ones and a percentage
+// off the free items for BOGO ones: buy BuyQuantity, get GetQuantity.
+// Promotions without targets apply to the whole menu and without windows
+// are valid all day within their period.
+type Promotion struct {
+	ID               int        `json:"id"`
+	StoreID          int        `json:"-"`
+	Name             string     `json:"name"`
+	Description      string     `json:"description"`
+	Type             string     `json:"type"`
+	Value            int        `json:"value"`
+	BuyQuantity      int        `json:"buyQuantity"`
+	GetQuantity      int        `json:"getQuantity"`
+	MarketSectionIDs []int      `json:"marketSectionIds"`
+	BrandIDs         []int      `json:"brandIds"`
+	StartsAt         *time.Time `json:"startsAt"`
+	EndsAt           *time.Time `json:"endsAt"`
+	Windows          []Window   `json:"windows"`
+	CouponCode       *string    `json:"couponCode"`
+	UsageLimit       *int       `json:"usageLimit"`
+	PerUserLimit     *int       `json:"perUserLimit"`
+	UsageCount       int        `json:"usageCount"`
+	IsActive         bool       `json:"isActive"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+}
+
+type StoreInfo struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Banner string `json:"banner"`
+}
+
+// Deal is a public promotion of a published store.
+type Deal struct {
+	Promotion   Promotion `json:"promotion"`
+	Store       StoreInfo `json:"store"`
+	Timezone    string    `json:"-"`
+	IsActiveNow bool      `json:"isActiveNow"`
+}
+
+type DealFilter struct {
+	CountryID       int
+	StateID         int
+	RegionID        int
+	MarketSectionID int
+	BrandID         int
+	ActiveNow       bool
+}
+
+// Applied is a promotion applied to an order with the amount it saved.
+type Applied struct {
+	PromotionID int    `json:"promotionId"`
+	Name        string `json:"name"`
+	Amount      int    `json:"amount"`
+}
@@ -0,0 +1,219 @@
+package promotion
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+// Line is an order line promotions are applied to.
+type Line struct {
+	BrandID         int
+	MarketSectionID int
+	UnitPrice       int
+	Quantity        int
+}
+
+type Pricing struct {
+	Discount int       `json:"discount"`
+	Applied  []Applied `json:"applied"`
+}
+
+// IsExhausted reports whether the promotion has reached its usage limit.
+func (p Promotion) IsExhausted() bool {
+	return p.UsageLimit != nil && p.UsageCount >= *p.UsageLimit
+}
+
+func atClock(date time.Time, clock string, loc *time.Location) (time.Time, bool) {
+	t, err := time.Parse(TimeLayout, clock)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, loc), true
+}
+
+func (w Window) contains(date, now time.Time, loc *time.Location) bool {
+	if w.Weekday != int(date.Weekday()) {
+		return false
+	}
+
+	start, ok := atClock(date, w.StartsAt, loc)
+	if !ok {
+		return false
+	}
+
+	end, ok := atClock(date, w.EndsAt, loc)
+	if !ok {
+		return false
+	}
+
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	return !now.Before(start) && now.Before(end)
+}
+
+// IsActiveAt reports whether the promotion is valid at the given moment
+// in the store located in loc. Usage limits are not taken into account.
+func (p Promotion) IsActiveAt(now time.Time, loc *time.Location) bool {
+	if !p.IsActive {
+		return false
+	}
+	if p.StartsAt != nil && now.Before(*p.StartsAt) {
+		return false
+	}
+	if p.EndsAt != nil && !now.Before(*p.EndsAt) {
+		return false
+	}
+	if len(p.Windows) == 0 {
+		return true
+	}
+
+	local := now.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	// windows of the previous day may last past midnight
+	for _, date := range []time.Time{today.AddDate(0, 0, -1), today} {
+		for _, w := range p.Windows {
+			if w.contains(date, now, loc) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Targets reports whether the line is eligible for the promotion.
+func (p Promotion) Targets(line Line) bool {
+	if len(p.MarketSectionIDs) > 0 && !slices.Contains(p.MarketSectionIDs, line.MarketSectionID) {
+		return false
+	}
+	if len(p.BrandIDs) > 0 && !slices.Contains(p.BrandIDs, line.BrandID) {
+		return false
+	}
+	return true
+}
+
+type unit struct {
+	line  int
+	price int
+}
+
+// discount returns the amount the promotion takes off the eligible units
+// and the units it uses up.
+func (p Promotion) discount(units []unit) (int, []unit) {
+	total := 0
+	for _, u := range units {
+		total += u.price
+	}
+
+	switch p.Type {
+	case TypePercentage:
+		return total * p.Value / 100, units
+	case TypeFixed:
+		return min(p.Value, total), units
+	case TypeBOGO:
+		size := p.BuyQuantity + p.GetQuantity
+		if p.BuyQuantity <= 0 || p.GetQuantity <= 0 || len(units) < size {
+			return 0, nil
+		}
+
+		// the cheapest units of each group are discounted
+		sorted := slices.Clone(units)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].price > sorted[j].price })
+
+		used := sorted[:len(sorted)/size*size]
+		amount := 0
+		for i := 0; i < len(used); i += size {
+			for _, u := range used[i+p.BuyQuantity : i+size] {
+				amount += u.price * p.Value / 100
+			}
+		}
+
+		return amount, used
+	}
+
+	return 0, nil
+}
+
+// Apply prices the lines with the promotions valid at the given moment.
+// Promotions don't stack: every unit is discounted by one promotion at most.
+// A coupon the customer entered is applied first, then the promotion saving
+// the most, so automatic promotions never take the units the coupon needs.
+func Apply(promotions []Promotion, lines []Line, now time.Time, loc *time.Location) Pricing {
+	pricing := Pricing{Applied: make([]Applied, 0)}
+
+	candidates := make([]Promotion, 0, len(promotions))
+	for _, p := range promotions {
+		if p.IsActiveAt(now, loc) && !p.IsExhausted() {
+			candidates = append(candidates, p)
+		}
+	}
+
+	units := make([]unit, 0)
+	for i, line := range lines {
+		for range line.Quantity {
+			units = append(units, unit{line: i, price: line.UnitPrice})
+		}
+	}
+
+	consumed := make([]bool, len(units))
+	applied := make([]bool, len(candidates))
+
+	for {
+		best, bestAmount, bestIsCoupon := -1, 0, false
+		var bestUnits []int
+
+		for i, p := range candidates {
+			if applied[i] {
+				continue
+			}
+
+			isCoupon := p.CouponCode != nil
+			if bestIsCoupon && !isCoupon {
+				continue
+			}
+
+			eligible := make([]unit, 0)
+			indexes := make(map[unit][]int)
+			for j, u := range units {
+				if !consumed[j] && p.Targets(lines[u.line]) {
+					eligible = append(eligible, u)
+					indexes[u] = append(indexes[u], j)
+				}
+			}
+
+			amount, used := p.discount(eligible)
+			if amount == 0 || (amount <= bestAmount && isCoupon == bestIsCoupon) {
+				continue
+			}
+
+			best, bestAmount, bestIsCoupon = i, amount, isCoupon
+			bestUnits = make([]int, 0, len(used))
+			for _, u := range used {
+				bestUnits = append(bestUnits, indexes[u][0])
+				indexes[u] = indexes[u][1:]
+			}
+		}
+
+		if best == -1 {
+			break
+		}
+
+		applied[best] = true
+		for _, j := range bestUnits {
+			consumed[j] = true
+		}
+
+		pricing.Discount += bestAmount
+		pricing.Applied = append(pricing.Applied, Applied{
+			PromotionID: candidates[best].ID,
+			Name:        candidates[best].Name,
+			Amount:      bestAmount,
+		})
+	}
+
+	return pricing
+}
@@ -0,0 +1,237 @@
+package promotion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func strPtr(v string) *string {
+	return &v
+}
+
+func TestIsActiveAt(t *testing.T) {
+	loc := time.FixedZone("UTC-7", -7*60*60)
+	// Wednesday
+	now := time.Date(2026, 10, 21, 15, 30, 0, 0, loc)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		promotion Promotion
+		now       time.Time
+		expected  bool
+	}{
+		{
+			name:      "always",
+			promotion: Promotion{IsActive: true},
+			now:       now,
+			expected:  true,
+		},
+		{
+			name:      "disabled",
+			promotion: Promotion{},
+			now:       now,
+		},
+		{
+			name:      "not started",
+			promotion: Promotion{IsActive: true, StartsAt: &future},
+			now:       now,
+		},
+		{
+			name:      "ended",
+			promotion: Promotion{IsActive: true, EndsAt: &past},
+			now:       now,
+		},
+		{
+			name:      "within period",
+			promotion: Promotion{IsActive: true, StartsAt: &past, EndsAt: &future},
+			now:       now,
+			expected:  true,
+		},
+		{
+			name:      "weekday window",
+			promotion: Promotion{IsActive: true, Windows: []Window{{Weekday: 3, StartsAt: "00:00", EndsAt: "23:59"}}},
+			now:       now,
+			expected:  true,
+		},
+		{
+			name:      "window of another weekday",
+			promotion: Promotion{IsActive: true, Windows: []Window{{Weekday: 4, StartsAt: "00:00", EndsAt: "23:59"}}},
+			now:       now,
+		},
+		{
+			name:      "outside time window",
+			promotion: Promotion{IsActive: true, Windows: []Window{{Weekday: 3, StartsAt: "16:00", EndsAt: "18:00"}}},
+			now:       now,
+		},
+		{
+			name:      "window in store timezone",
+			promotion: Promotion{IsActive: true, Windows: []Window{{Weekday: 3, StartsAt: "15:00", EndsAt: "16:00"}}},
+			now:       now.UTC(),
+			expected:  true,
+		},
+		{
+			name:      "overnight window of previous day",
+			promotion: Promotion{IsActive: true, Windows: []Window{{Weekday: 2, StartsAt: "22:00", EndsAt: "02:00"}}},
+			now:       time.Date(2026, 10, 21, 1, 0, 0, 0, loc),
+			expected:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.promotion.IsActiveAt(tt.now, loc))
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	now := time.Date(2026, 10, 21, 12, 0, 0, 0, time.UTC)
+
+	edibles := Line{BrandID: 1, MarketSectionID: 10, UnitPrice: 1000, Quantity: 2}
+	flower := Line{BrandID: 2, MarketSectionID: 20, UnitPrice: 3000, Quantity: 1}
+	vapes := Line{BrandID: 2, MarketSectionID: 30, UnitPrice: 500, Quantity: 3}
+
+	tests := []struct {
+		name       string
+		promotions []Promotion
+		lines      []Line
+		expected   Pricing
+	}{
+		{
+			name:     "no promotions",
+			lines:    []Line{edibles},
+			expected: Pricing{Applied: []Applied{}},
+		},
+		{
+			name: "percentage off a market section",
+			promotions: []Promotion{
+				{ID: 1, Name: "20% off edibles", Type: TypePercentage, Value: 20, MarketSectionIDs: []int{10}, IsActive: true},
+			},
+			lines: []Line{edibles, flower},
+			expected: Pricing{
+				Discount: 400,
+				Applied:  []Applied{{PromotionID: 1, Name: "20% off edibles", Amount: 400}},
+			},
+		},
+		{
+			name: "fixed amount off a brand",
+			promotions: []Promotion{
+				{ID: 1, Name: "5 off", Type: TypeFixed, Value: 500, BrandIDs: []int{2}, IsActive: true},
+			},
+			lines: []Line{edibles, flower},
+			expected: Pricing{
+				Discount: 500,
+				Applied:  []Applied{{PromotionID: 1, Name: "5 off", Amount: 500}},
+			},
+		},
+		{
+			name: "fixed amount is capped by eligible items",
+			promotions: []Promotion{
+				{ID: 1, Name: "50 off", Type: TypeFixed, Value: 5000, BrandIDs: []int{1}, IsActive: true},
+			},
+			lines: []Line{edibles, flower},
+			expected: Pricing{
+				Discount: 2000,
+				Applied:  []Applied{{PromotionID: 1, Name: "50 off", Amount: 2000}},
+			},
+		},
+		{
+			name: "buy one get one free",
+			promotions: []Promotion{
+				{ID: 1, Name: "BOGO", Type: TypeBOGO, Value: 100, BuyQuantity: 1, GetQuantity: 1, IsActive: true},
+			},
+			lines: []Line{flower, vapes},
+			expected: Pricing{
+				Discount: 1000,
+				Applied:  []Applied{{PromotionID: 1, Name: "BOGO", Amount: 1000}},
+			},
+		},
+		{
+			name: "buy two get one half off",
+			promotions: []Promotion{
+				{ID: 1, Name: "B2G1", Type: TypeBOGO, Value: 50, BuyQuantity: 2, GetQuantity: 1, IsActive: true},
+			},
+			lines: []Line{vapes},
+			expected: Pricing{
+				Discount: 250,
+				Applied:  []Applied{{PromotionID: 1, Name: "B2G1", Amount: 250}},
+			},
+		},
+		{
+			name: "bogo needs a full group",
+			promotions: []Promotion{
+				{ID: 1, Name: "B3G1", Type: TypeBOGO, Value: 100, BuyQuantity: 3, GetQuantity: 1, IsActive: true},
+			},
+			lines:    []Line{vapes},
+			expected: Pricing{Applied: []Applied{}},
+		},
+		{
+			name: "promotions don't stack on the same items",
+			promotions: []Promotion{
+				{ID: 1, Name: "10% off", Type: TypePercentage, Value: 10, IsActive: true},
+				{ID: 2, Name: "30% off flower", Type: TypePercentage, Value: 30, MarketSectionIDs: []int{20}, IsActive: true},
+			},
+			lines: []Line{edibles, flower},
+			expected: Pricing{
+				Discount: 1100,
+				Applied: []Applied{
+					{PromotionID: 2, Name: "30% off flower", Amount: 900},
+					{PromotionID: 1, Name: "10% off", Amount: 200},
+				},
+			},
+		},
+		{
+			name: "coupon is applied before a bigger automatic promotion",
+			promotions: []Promotion{
+				{ID: 1, Name: "30% off", Type: TypePercentage, Value: 30, IsActive: true},
+				{ID: 2, Name: "coupon", Type: TypePercentage, Value: 10, MarketSectionIDs: []int{20}, CouponCode: strPtr("FLOWER10"), IsActive: true},
+			},
+			lines: []Line{edibles, flower},
+			expected: Pricing{
+				Discount: 900,
+				Applied: []Applied{
+					{PromotionID: 2, Name: "coupon", Amount: 300},
+					{PromotionID: 1, Name: "30% off", Amount: 600},
+				},
+			},
+		},
+		{
+			name: "inactive and exhausted promotions are skipped",
+			promotions: []Promotion{
+				{ID: 1, Name: "disabled", Type: TypePercentage, Value: 10},
+				{ID: 2, Name: "exhausted", Type: TypePercentage, Value: 10, IsActive: true, UsageLimit: intPtr(5), UsageCount: 5},
+				{ID: 3, Name: "limited", Type: TypePercentage, Value: 5, IsActive: true, UsageLimit: intPtr(5), UsageCount: 4},
+			},
+			lines: []Line{flower},
+			expected: Pricing{
+				Discount: 150,
+				Applied:  []Applied{{PromotionID: 3, Name: "limited", Amount: 150}},
+			},
+		},
+		{
+			name: "market section and brand targets are combined",
+			promotions: []Promotion{
+				{ID: 1, Name: "brand vapes", Type: TypePercentage, Value: 10, BrandIDs: []int{2}, MarketSectionIDs: []int{30}, IsActive: true},
+			},
+			lines: []Line{flower, vapes},
+			expected: Pricing{
+				Discount: 150,
+				Applied:  []Applied{{PromotionID: 1, Name: "brand vapes", Amount: 150}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, Apply(tt.promotions, tt.lines, now, time.UTC))
+		})
+	}
+}
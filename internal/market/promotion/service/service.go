@@ -0,0 +1,331 @@
+package promotionservice
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/promotion"
+	promotiondb "github.com/xw1nchester/kushfinds-backend/internal/market/promotion/db"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/store"
+	"github.com/xw1nchester/kushfinds-backend/internal/tracing"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor"
+	"go.uber.org/zap"
+)
+
+type Repository interface {
+	GetStorePromotions(ctx context.Context, storeID int) ([]promotion.Promotion, error)
+	GetStorePromotion(ctx context.Context, storeID, promotionID int) (*promotion.Promotion, error)
+	GetCheckoutPromotions(ctx context.Context, storeID int, couponCode string) ([]promotion.Promotion, error)
+	CreatePromotion(ctx context.Context, data promotion.Promotion) (*promotion.Promotion, error)
+	UpdatePromotion(ctx context.Context, data promotion.Promotion) (*promotion.Promotion, error)
+	DeletePromotion(ctx context.Context, storeID, promotionID int) error
+	CountUserRedemptions(ctx context.Context, promotionID, userID int) (int, error)
+	RedeemPromotion(ctx context.Context, promotionID, userID int) error
+	ReleaseOrderPromotions(ctx context.Context, orderID int) error
+	GetDeals(ctx context.Context, filter promotion.DealFilter) ([]promotion.Deal, error)
+}
+
+var (
+	ErrInvalidValue        = apperror.NewAppError("promotion.invalid_value", "the discount value does not match the promotion type")
+	ErrInvalidPeriod       = apperror.NewAppError("promotion.invalid_period", "the promotion should end after it starts")
+	ErrInvalidWindow       = apperror.NewAppError("promotion.invalid_window", "promotion time windows are not valid")
+	ErrCouponAlreadyExists = apperror.NewConflictError("promotion.coupon_already_exists", "the store already has a promotion with this coupon code")
+	ErrCouponNotFound      = apperror.NewAppError("promotion.coupon_not_found", "the coupon code is not valid")
+	ErrCouponNotApplicable = apperror.NewAppError("promotion.coupon_not_applicable", "the coupon does not apply to the order")
+	ErrCouponExhausted     = apperror.NewAppError("promotion.coupon_exhausted", "the coupon has been used up")
+	ErrPromotionExhausted  = apperror.NewConflictError("promotion.exhausted", "a promotion has just been used up, review the order")
+)
+
+type StoreService interface {
+	GetUserStore(ctx context.Context, storeID, userID int) (*store.Store, error)
+}
+
+type service struct {
+	repository   Repository
+	storeService StoreService
+	txManager    transactor.Manager
+	logger       *zap.Logger
+}
+
+func New(
+	repository Repository,
+	storeService StoreService,
+	txManager transactor.Manager,
+	logger *zap.Logger,
+) *service {
+	return &service{
+		repository:   repository,
+		storeService: storeService,
+		txManager:    txManager,
+		logger:       logger,
+	}
+}
+
+func validatePromotion(data promotion.Promotion) error {
+	switch data.Type {
+	case promotion.TypePercentage:
+		if data.Value < 1 || data.Value > 100 {
+			return ErrInvalidValue
+		}
+	case promotion.TypeFixed:
+		if data.Value < 1 {
+			return ErrInvalidValue
+		}
+	case promotion.TypeBOGO:
+		if data.Value < 1 || data.Value > 100 || data.BuyQuantity < 1 || data.GetQuantity < 1 {
+			return ErrInvalidValue
+		}
+	default:
+		return ErrInvalidValue
+	}
+
+	if data.StartsAt != nil && data.EndsAt != nil && !data.EndsAt.After(*data.StartsAt) {
+		return ErrInvalidPeriod
+	}
+
+	for _, w := range data.Windows {
+		if w.Weekday < 0 || w.Weekday > 6 || w.StartsAt == w.EndsAt {
+			return ErrInvalidWindow
+		}
+		if _, err := time.Parse(promotion.TimeLayout, w.StartsAt); err != nil {
+			return ErrInvalidWindow
+		}
+		if _, err := time.Parse(promotion.TimeLayout, w.EndsAt); err != nil {
+			return ErrInvalidWindow
+		}
+	}
+
+	return nil
+}
+
+func (s *service) GetStorePromotions(ctx context.Context, storeID, userID int) ([]promotion.Promotion, error) {
+	if _, err := s.storeService.GetUserStore(ctx, storeID, userID); err != nil {
+		return nil, err
+	}
+
+	promotions, err := s.repository.GetStorePromotions(ctx, storeID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching store promotions", zap.Error(err))
+
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+func (s *service) getStorePromotion(ctx context.Context, storeID, promotionID int) (*promotion.Promotion, error) {
+	existingPromotion, err := s.repository.GetStorePromotion(ctx, storeID, promotionID)
+	if err != nil {
+		if errors.Is(err, promotiondb.ErrPromotionNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching store promotion", zap.Error(err))
+
+		return nil, err
+	}
+
+	return existingPromotion, nil
+}
+
+func (s *service) GetStorePromotion(ctx context.Context, storeID, promotionID, userID int) (*promotion.Promotion, error) {
+	if _, err := s.storeService.GetUserStore(ctx, storeID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.getStorePromotion(ctx, storeID, promotionID)
+}
+
+func (s *service) savePromotion(
+	ctx context.Context,
+	data promotion.Promotion,
+	userID int,
+	save func(ctx context.Context, data promotion.Promotion) (*promotion.Promotion, error),
+) (*promotion.Promotion, error) {
+	if _, err := s.storeService.GetUserStore(ctx, data.StoreID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := validatePromotion(data); err != nil {
+		return nil, err
+	}
+
+	var savedPromotion *promotion.Promotion
+
+	if err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		savedPromotion, err = save(ctx, data)
+		return err
+	}); err != nil {
+		switch {
+		case errors.Is(err, promotiondb.ErrPromotionNotFound), errors.Is(err, promotiondb.ErrTargetNotFound):
+			return nil, apperror.ErrNotFound
+		case errors.Is(err, promotiondb.ErrCouponAlreadyExists):
+			return nil, ErrCouponAlreadyExists
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when saving promotion", zap.Error(err))
+
+		return nil, err
+	}
+
+	return savedPromotion, nil
+}
+
+func (s *service) CreatePromotion(ctx context.Context, data promotion.Promotion, userID int) (*promotion.Promotion, error) {
+	ctx, span := tracing.Start(ctx, "promotionservice.CreatePromotion")
+	defer span.End()
+
+	return s.savePromotion(ctx, data, userID, s.repository.CreatePromotion)
+}
+
+func (s *service) UpdatePromotion(ctx context.Context, data promotion.Promotion, userID int) (*promotion.Promotion, error) {
+	ctx, span := tracing.Start(ctx, "promotionservice.UpdatePromotion")
+	defer span.End()
+
+	return s.savePromotion(ctx, data, userID, s.repository.UpdatePromotion)
+}
+
+func (s *service) DeletePromotion(ctx context.Context, storeID, promotionID, userID int) error {
+	if _, err := s.storeService.GetUserStore(ctx, storeID, userID); err != nil {
+		return err
+	}
+
+	if err := s.repository.DeletePromotion(ctx, storeID, promotionID); err != nil {
+		if errors.Is(err, promotiondb.ErrPromotionNotFound) {
+			return apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when deleting promotion", zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) GetDeals(ctx context.Context, filter promotion.DealFilter) ([]promotion.Deal, error) {
+	ctx, span := tracing.Start(ctx, "promotionservice.GetDeals")
+	defer span.End()
+
+	deals, err := s.repository.GetDeals(ctx, filter)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching deals", zap.Error(err))
+
+		return nil, err
+	}
+
+	now := time.Now()
+	res := make([]promotion.Deal, 0, len(deals))
+	for _, d := range deals {
+		d.IsActiveNow = d.Promotion.IsActiveAt(now, store.Schedule{Timezone: d.Timezone}.Location())
+		if filter.ActiveNow && !d.IsActiveNow {
+			continue
+		}
+		// usage is visible to the store team only
+		d.Promotion.UsageCount = 0
+		res = append(res, d)
+	}
+
+	return res, nil
+}
+
+// PriceOrder returns the discounts the user gets on the order lines at the store.
+// Promotions the user has used up are skipped, the coupon has to apply if given.
+// Limits are checked again by RedeemPromotions when the order is created.
+func (s *service) PriceOrder(
+	ctx context.Context,
+	st *store.Store,
+	userID int,
+	lines []promotion.Line,
+	couponCode string,
+) (*promotion.Pricing, error) {
+	ctx, span := tracing.Start(ctx, "promotionservice.PriceOrder")
+	defer span.End()
+
+	promotions, err := s.repository.GetCheckoutPromotions(ctx, st.ID, couponCode)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching checkout promotions", zap.Error(err))
+
+		return nil, err
+	}
+
+	couponID := 0
+	candidates := make([]promotion.Promotion, 0, len(promotions))
+	for _, p := range promotions {
+		isCoupon := p.CouponCode != nil
+		if isCoupon {
+			couponID = p.ID
+		}
+
+		if p.PerUserLimit != nil {
+			count, err := s.repository.CountUserRedemptions(ctx, p.ID, userID)
+			if err != nil {
+				logging.FromContext(ctx, s.logger).Error("unexpected error when counting promotion redemptions", zap.Error(err))
+
+				return nil, err
+			}
+
+			if count >= *p.PerUserLimit {
+				if isCoupon {
+					return nil, ErrCouponExhausted
+				}
+				continue
+			}
+		}
+
+		if isCoupon && p.IsExhausted() {
+			return nil, ErrCouponExhausted
+		}
+
+		candidates = append(candidates, p)
+	}
+
+	if couponCode != "" && couponID == 0 {
+		return nil, ErrCouponNotFound
+	}
+
+	pricing := promotion.Apply(candidates, lines, time.Now(), st.Schedule.Location())
+
+	if couponID != 0 && !slices.ContainsFunc(pricing.Applied, func(a promotion.Applied) bool {
+		return a.PromotionID == couponID
+	}) {
+		return nil, ErrCouponNotApplicable
+	}
+
+	return &pricing, nil
+}
+
+// RedeemPromotions counts the use of the applied promotions by the user and
+// checks their limits once more. Run it within the transaction creating the order.
+func (s *service) RedeemPromotions(ctx context.Context, userID int, applied []promotion.Applied) error {
+	for _, a := range applied {
+		if err := s.repository.RedeemPromotion(ctx, a.PromotionID, userID); err != nil {
+			if errors.Is(err, promotiondb.ErrPromotionExhausted) {
+				return ErrPromotionExhausted
+			}
+
+			logging.FromContext(ctx, s.logger).Error("unexpected error when redeeming promotion", zap.Error(err))
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReleasePromotions gives back the promotion uses of a cancelled order.
+// Run it within the transaction cancelling the order.
+func (s *service) ReleasePromotions(ctx context.Context, orderID int) error {
+	if err := s.repository.ReleaseOrderPromotions(ctx, orderID); err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when releasing order promotions", zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
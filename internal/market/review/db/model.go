@@ -0,0 +1,11 @@
+package reviewdb
+
+import "errors"
+
+var (
+	ErrReviewNotFound      = errors.New("review not found")
+	ErrReviewAlreadyExists = errors.New("review already exists")
+	ErrReplyAlreadyExists  = errors.New("reply already exists")
+	ErrReportNotFound      = errors.New("report not found")
+	ErrReportAlreadyExists = errors.New("report already exists")
+)
@@ -0,0 +1,547 @@
+package reviewdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/review"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor/postgresql"
+	"go.uber.org/zap"
+)
+
+const uniqueViolationCode = "23505"
+
+type repository struct {
+	client *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func New(client *pgxpool.Pool, logger *zap.Logger) *repository {
+	return &repository{
+		client: client,
+		logger: logger,
+	}
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+const reviewColumns = `
+	rv.id,
+	rv.store_id,
+	s.brand_id,
+	u.id,
+	u.username,
+	u.first_name,
+	u.avatar,
+	rv.rating,
+	rv.text,
+	rv.reply,
+	rv.replied_at,
+	rv.is_hidden,
+	rv.created_at,
+	rv.updated_at
+`
+
+// reviewDest returns scan destinations matching reviewColumns.
+// The reply is set by the caller once the row is scanned.
+func reviewDest(rv *review.Review, replyText **string, repliedAt **time.Time) []any {
+	return []any{
+		&rv.ID,
+		&rv.StoreID,
+		&rv.BrandID,
+		&rv.Author.ID,
+		&rv.Author.Username,
+		&rv.Author.FirstName,
+		&rv.Author.Avatar,
+		&rv.Rating,
+		&rv.Text,
+		replyText,
+		repliedAt,
+		&rv.IsHidden,
+		&rv.CreatedAt,
+		&rv.UpdatedAt,
+	}
+}
+
+func setReply(rv *review.Review, replyText *string, repliedAt *time.Time) {
+	if replyText != nil && repliedAt != nil {
+		rv.Reply = &review.Reply{Text: *replyText, CreatedAt: *repliedAt}
+	}
+}
+
+func (r *repository) loadPictures(ctx context.Context, reviews []*review.Review) error {
+	if len(reviews) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(reviews))
+	indexes := make(map[int][]int, len(reviews))
+	for i, rv := range reviews {
+		rv.Pictures = make([]string, 0)
+		ids[i] = rv.ID
+		indexes[rv.ID] = append(indexes[rv.ID], i)
+	}
+
+	query := `
+		SELECT review_id, url
+		FROM reviews_pictures
+		WHERE review_id = ANY($1)
+		ORDER BY url
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reviewID int
+		var url string
+		if err := rows.Scan(&reviewID, &url); err != nil {
+			return fmt.Errorf("failed to scan row: %v", err)
+		}
+		for _, i := range indexes[reviewID] {
+			reviews[i].Pictures = append(reviews[i].Pictures, url)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("row error: %v", err)
+	}
+
+	return nil
+}
+
+func (r *repository) getReviews(ctx context.Context, condition string, args ...any) ([]review.Review, error) {
+	query := `
+		SELECT ` + reviewColumns + `
+		FROM reviews rv
+		JOIN stores s ON rv.store_id = s.id
+		JOIN users u ON rv.user_id = u.id
+		WHERE ` + condition + `
+		ORDER BY rv.created_at DESC, rv.id DESC
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := make([]review.Review, 0)
+	for rows.Next() {
+		var rv review.Review
+		var replyText *string
+		var repliedAt *time.Time
+
+		if err := rows.Scan(reviewDest(&rv, &replyText, &repliedAt)...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+		setReply(&rv, replyText, repliedAt)
+
+		reviews = append(reviews, rv)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	pointers := make([]*review.Review, len(reviews))
+	for i := range reviews {
+		pointers[i] = &reviews[i]
+	}
+
+	if err := r.loadPictures(ctx, pointers); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r *repository) GetReviewByID(ctx context.Context, id int) (*review.Review, error) {
+	reviews, err := r.getReviews(ctx, "rv.id=$1", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reviews) == 0 {
+		return nil, ErrReviewNotFound
+	}
+
+	return &reviews[0], nil
+}
+
+func (r *repository) GetStoreReviews(ctx context.Context, storeID int) ([]review.Review, error) {
+	return r.getReviews(ctx, "rv.store_id=$1 AND rv.is_hidden=false", storeID)
+}
+
+func (r *repository) GetUserReviews(ctx context.Context, userID int) ([]review.Review, error) {
+	return r.getReviews(ctx, "rv.user_id=$1", userID)
+}
+
+func (r *repository) HasCompletedOrder(ctx context.Context, userID, storeID int) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM orders
+			WHERE user_id=$1 AND store_id=$2 AND status='completed'
+		)
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	var exists bool
+	if err := r.client.QueryRow(ctx, query, userID, storeID).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// updateRating shifts the store and brand rating aggregates by the given deltas.
+func (r *repository) updateRating(ctx context.Context, storeID, ratingDelta, countDelta int) error {
+	if ratingDelta == 0 && countDelta == 0 {
+		return nil
+	}
+
+	query := `
+		WITH updated_store AS (
+			UPDATE stores
+			SET rating_sum=rating_sum+$2, reviews_count=reviews_count+$3
+			WHERE id=$1
+			RETURNING brand_id
+		)
+		UPDATE brands
+		SET rating_sum=rating_sum+$2, reviews_count=reviews_count+$3
+		WHERE id=(SELECT brand_id FROM updated_store)
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	_, err := executor.Exec(ctx, query, storeID, ratingDelta, countDelta)
+
+	return err
+}
+
+func (r *repository) savePictures(ctx context.Context, reviewID int, pictures []string) error {
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	deleteQuery := `DELETE FROM reviews_pictures WHERE review_id=$1`
+
+	logging.LogSQLQuery(ctx, r.logger, deleteQuery)
+
+	if _, err := executor.Exec(ctx, deleteQuery, reviewID); err != nil {
+		return err
+	}
+
+	if len(pictures) == 0 {
+		return nil
+	}
+
+	insertQuery := `
+		INSERT INTO reviews_pictures (review_id, url)
+		SELECT $1, unnest($2::text[])
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, insertQuery)
+
+	_, err := executor.Exec(ctx, insertQuery, reviewID, pictures)
+
+	return err
+}
+
+func (r *repository) CreateReview(ctx context.Context, data review.Review) (int, error) {
+	query := `
+		INSERT INTO reviews (store_id, user_id, rating, text)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	var id int
+	if err := executor.QueryRow(
+		ctx,
+		query,
+		data.StoreID,
+		data.Author.ID,
+		data.Rating,
+		data.Text,
+	).Scan(&id); err != nil {
+		if isUniqueViolation(err) {
+			return 0, ErrReviewAlreadyExists
+		}
+		return 0, err
+	}
+
+	if err := r.savePictures(ctx, id, data.Pictures); err != nil {
+		return 0, err
+	}
+
+	if err := r.updateRating(ctx, data.StoreID, data.Rating, 1); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// UpdateReview saves the new rating, text and pictures of the review.
+// The rating column is locked so concurrent edits shift the aggregates consistently.
+func (r *repository) UpdateReview(ctx context.Context, data review.Review) error {
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	lockQuery := `SELECT rating, is_hidden FROM reviews WHERE id=$1 FOR UPDATE`
+
+	logging.LogSQLQuery(ctx, r.logger, lockQuery)
+
+	var oldRating int
+	var isHidden bool
+	if err := executor.QueryRow(ctx, lockQuery, data.ID).Scan(&oldRating, &isHidden); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrReviewNotFound
+		}
+		return err
+	}
+
+	query := `
+		UPDATE reviews
+		SET rating=$2, text=$3, updated_at=NOW()
+		WHERE id=$1
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	if _, err := executor.Exec(ctx, query, data.ID, data.Rating, data.Text); err != nil {
+		return err
+	}
+
+	if err := r.savePictures(ctx, data.ID, data.Pictures); err != nil {
+		return err
+	}
+
+	if isHidden {
+		return nil
+	}
+
+	return r.updateRating(ctx, data.StoreID, data.Rating-oldRating, 0)
+}
+
+func (r *repository) DeleteReview(ctx context.Context, id int) error {
+	query := `
+		DELETE FROM reviews
+		WHERE id=$1
+		RETURNING store_id, rating, is_hidden
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	var storeID, rating int
+	var isHidden bool
+	if err := executor.QueryRow(ctx, query, id).Scan(&storeID, &rating, &isHidden); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrReviewNotFound
+		}
+		return err
+	}
+
+	if isHidden {
+		return nil
+	}
+
+	return r.updateRating(ctx, storeID, -rating, -1)
+}
+
+func (r *repository) SetReply(ctx context.Context, reviewID int, text string) error {
+	query := `
+		UPDATE reviews
+		SET reply=$2, replied_at=NOW()
+		WHERE id=$1 AND reply IS NULL
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := r.client.Exec(ctx, query, reviewID, text)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrReplyAlreadyExists
+	}
+
+	return nil
+}
+
+// SetReviewHidden hides or restores the review and moves its rating out of
+// or back into the aggregates. Nothing changes when the flag already matches.
+func (r *repository) SetReviewHidden(ctx context.Context, id int, hidden bool) error {
+	query := `
+		UPDATE reviews
+		SET is_hidden=$2
+		WHERE id=$1 AND is_hidden<>$2
+		RETURNING store_id, rating
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	var storeID, rating int
+	if err := executor.QueryRow(ctx, query, id, hidden).Scan(&storeID, &rating); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	if hidden {
+		return r.updateRating(ctx, storeID, -rating, -1)
+	}
+
+	return r.updateRating(ctx, storeID, rating, 1)
+}
+
+func (r *repository) CreateReport(ctx context.Context, reviewID, userID int, reason string) (int, error) {
+	query := `
+		INSERT INTO reviews_reports (review_id, user_id, reason)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	var id int
+	if err := r.client.QueryRow(ctx, query, reviewID, userID, reason).Scan(&id); err != nil {
+		if isUniqueViolation(err) {
+			return 0, ErrReportAlreadyExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *repository) getReports(ctx context.Context, condition string, args ...any) ([]review.Report, error) {
+	query := `
+		SELECT
+			rr.id,
+			rr.user_id,
+			rr.reason,
+			rr.status,
+			rr.created_at,
+			rr.resolved_at,
+			` + reviewColumns + `
+		FROM reviews_reports rr
+		JOIN reviews rv ON rr.review_id = rv.id
+		JOIN stores s ON rv.store_id = s.id
+		JOIN users u ON rv.user_id = u.id
+		WHERE ` + condition + `
+		ORDER BY rr.created_at, rr.id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reports := make([]review.Report, 0)
+	for rows.Next() {
+		var report review.Report
+		var replyText *string
+		var repliedAt *time.Time
+
+		dest := append(
+			[]any{
+				&report.ID,
+				&report.ReporterID,
+				&report.Reason,
+				&report.Status,
+				&report.CreatedAt,
+				&report.ResolvedAt,
+			},
+			reviewDest(&report.Review, &replyText, &repliedAt)...,
+		)
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+		setReply(&report.Review, replyText, repliedAt)
+
+		reports = append(reports, report)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	pointers := make([]*review.Review, len(reports))
+	for i := range reports {
+		pointers[i] = &reports[i].Review
+	}
+
+	if err := r.loadPictures(ctx, pointers); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+func (r *repository) GetReports(ctx context.Context, filter review.ReportFilter) ([]review.Report, error) {
+	if filter.Status == "" {
+		return r.getReports(ctx, "TRUE")
+	}
+
+	return r.getReports(ctx, "rr.status=$1", filter.Status)
+}
+
+func (r *repository) GetReportByID(ctx context.Context, id int) (*review.Report, error) {
+	reports, err := r.getReports(ctx, "rr.id=$1", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reports) == 0 {
+		return nil, ErrReportNotFound
+	}
+
+	return &reports[0], nil
+}
+
+// ResolveReports closes every pending report of the review with the given status.
+func (r *repository) ResolveReports(ctx context.Context, reviewID int, status string, moderatorID int) error {
+	query := `
+		UPDATE reviews_reports
+		SET status=$2, moderator_id=$3, resolved_at=NOW()
+		WHERE review_id=$1 AND status='pending'
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	_, err := executor.Exec(ctx, query, reviewID, status, moderatorID)
+
+	return err
+}
@@ -0,0 +1,346 @@
+package reviewhandler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	jwtmiddleware "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt/middleware"
+	"github.com/xw1nchester/kushfinds-backend/internal/handlers"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/review"
+	"go.uber.org/zap"
+)
+
+var validate = apperror.NewValidator()
+
+type Service interface {
+	GetStoreReviews(ctx context.Context, storeID int) ([]review.Review, error)
+	GetUserReviews(ctx context.Context, userID int) ([]review.Review, error)
+	CreateReview(ctx context.Context, data review.Review) (*review.Review, error)
+	UpdateReview(ctx context.Context, data review.Review) (*review.Review, error)
+	DeleteReview(ctx context.Context, reviewID, userID int) error
+	ReplyToReview(ctx context.Context, storeID, reviewID, userID int, text string) (*review.Review, error)
+	ReportReview(ctx context.Context, reviewID, userID int, reason string) error
+	GetReports(ctx context.Context, adminID int, filter review.ReportFilter) ([]review.Report, error)
+	ModerateReport(ctx context.Context, adminID, reportID int, action string) (*review.Report, error)
+}
+
+type handler struct {
+	service        Service
+	authMiddleware func(http.Handler) http.Handler
+	staticURL      string
+	logger         *zap.Logger
+}
+
+func New(
+	service Service,
+	authMiddleware func(http.Handler) http.Handler,
+	staticURL string,
+	logger *zap.Logger,
+) handlers.Handler {
+	return &handler{
+		service:        service,
+		authMiddleware: authMiddleware,
+		staticURL:      staticURL,
+		logger:         logger,
+	}
+}
+
+func (h *handler) Register(router chi.Router) {
+	router.Route("/stores/{store_id}/reviews", func(reviewRouter chi.Router) {
+		reviewRouter.Get("/", apperror.Middleware(h.getStoreReviewsHandler))
+
+		reviewRouter.Group(func(privateReviewRouter chi.Router) {
+			privateReviewRouter.Use(h.authMiddleware)
+			privateReviewRouter.Post("/", apperror.Middleware(h.createReviewHandler))
+		})
+	})
+
+	router.Route("/reviews/{id}/report", func(reportRouter chi.Router) {
+		reportRouter.Use(h.authMiddleware)
+		reportRouter.Post("/", apperror.Middleware(h.reportReviewHandler))
+	})
+
+	router.Route("/me/reviews", func(userReviewRouter chi.Router) {
+		userReviewRouter.Use(h.authMiddleware)
+		userReviewRouter.Get("/", apperror.Middleware(h.getUserReviewsHandler))
+		userReviewRouter.Patch("/{id}", apperror.Middleware(h.updateReviewHandler))
+		userReviewRouter.Delete("/{id}", apperror.Middleware(h.deleteReviewHandler))
+	})
+
+	router.Route("/me/stores/{store_id}/reviews", func(storeReviewRouter chi.Router) {
+		storeReviewRouter.Use(h.authMiddleware)
+		storeReviewRouter.Post("/{id}/reply", apperror.Middleware(h.replyToReviewHandler))
+	})
+
+	router.Route("/admin/reviews/reports", func(adminReportRouter chi.Router) {
+		adminReportRouter.Use(h.authMiddleware)
+		adminReportRouter.Get("/", apperror.Middleware(h.getReportsHandler))
+		adminReportRouter.Patch("/{id}", apperror.Middleware(h.moderateReportHandler))
+	})
+}
+
+func parseIntParam(r *http.Request, name string) (int, error) {
+	value, err := strconv.Atoi(chi.URLParam(r, name))
+	if err != nil {
+		return 0, apperror.NewFieldError(name, "field.positive_integer")
+	}
+	return value, nil
+}
+
+// @Tags		reviews
+// @Success	200		{object}	ReviewsResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/stores/{store_id}/reviews [get]
+func (h *handler) getStoreReviewsHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "store_id")
+	if err != nil {
+		return err
+	}
+
+	reviews, err := h.service.GetStoreReviews(r.Context(), storeID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewReviewsResponse(reviews, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		reviews
+// @Param		request	body		ReviewRequest	true	"request body"
+// @Success	200		{object}	ReviewResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/stores/{store_id}/reviews [post]
+func (h *handler) createReviewHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "store_id")
+	if err != nil {
+		return err
+	}
+
+	var dto ReviewRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	createdReview, err := h.service.CreateReview(r.Context(), dto.ToDomain(0, storeID, userID))
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewReviewResponse(*createdReview, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		reviews
+// @Param		request	body	ReportRequest	true	"request body"
+// @Success	200
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/reviews/{id}/report [post]
+func (h *handler) reportReviewHandler(w http.ResponseWriter, r *http.Request) error {
+	reviewID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var dto ReportRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	return h.service.ReportReview(r.Context(), reviewID, userID, dto.Reason)
+}
+
+// @Security	ApiKeyAuth
+// @Tags		reviews
+// @Success	200		{object}	ReviewsResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/reviews [get]
+func (h *handler) getUserReviewsHandler(w http.ResponseWriter, r *http.Request) error {
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	reviews, err := h.service.GetUserReviews(r.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewReviewsResponse(reviews, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		reviews
+// @Param		request	body		ReviewRequest	true	"request body"
+// @Success	200		{object}	ReviewResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/reviews/{id} [patch]
+func (h *handler) updateReviewHandler(w http.ResponseWriter, r *http.Request) error {
+	reviewID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var dto ReviewRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	updatedReview, err := h.service.UpdateReview(r.Context(), dto.ToDomain(reviewID, 0, userID))
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewReviewResponse(*updatedReview, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		reviews
+// @Success	200
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/reviews/{id} [delete]
+func (h *handler) deleteReviewHandler(w http.ResponseWriter, r *http.Request) error {
+	reviewID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	return h.service.DeleteReview(r.Context(), reviewID, userID)
+}
+
+// @Security	ApiKeyAuth
+// @Tags		reviews
+// @Param		request	body		ReplyRequest	true	"request body"
+// @Success	200		{object}	ReviewResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/stores/{store_id}/reviews/{id}/reply [post]
+func (h *handler) replyToReviewHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "store_id")
+	if err != nil {
+		return err
+	}
+
+	reviewID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var dto ReplyRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	repliedReview, err := h.service.ReplyToReview(r.Context(), storeID, reviewID, userID, dto.Text)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewReviewResponse(*repliedReview, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		admin reviews
+// @Param		status	query		string	false	"report status, pending by default"
+// @Success	200		{object}	ReportsResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/admin/reviews/reports [get]
+func (h *handler) getReportsHandler(w http.ResponseWriter, r *http.Request) error {
+	filter := review.ReportFilter{Status: r.URL.Query().Get("status")}
+	switch filter.Status {
+	case "":
+		filter.Status = review.ReportStatusPending
+	case "all":
+		filter.Status = ""
+	case review.ReportStatusPending, review.ReportStatusHidden, review.ReportStatusDismissed:
+	default:
+		return apperror.NewFieldError("status", "field.one_of", "pending, hidden, dismissed, all")
+	}
+
+	adminID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	reports, err := h.service.GetReports(r.Context(), adminID, filter)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewReportsResponse(reports, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		admin reviews
+// @Param		request	body		ModerationRequest	true	"request body"
+// @Success	200		{object}	ReportResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/admin/reviews/reports/{id} [patch]
+func (h *handler) moderateReportHandler(w http.ResponseWriter, r *http.Request) error {
+	reportID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var dto ModerationRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	adminID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	report, err := h.service.ModerateReport(r.Context(), adminID, reportID, dto.Action)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewReportResponse(*report, h.staticURL))
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package reviewhandler
+
+import (
+	"github.com/xw1nchester/kushfinds-backend/internal/market/review"
+	"github.com/xw1nchester/kushfinds-backend/pkg/utils"
+)
+
+type ReviewRequest struct {
+	Rating   int      `json:"rating" validate:"min=1,max=5"`
+	Text     string   `json:"text" validate:"max=5000"`
+	Pictures []string `json:"pictures" validate:"max=10"`
+}
+
+func (rr *ReviewRequest) ToDomain(reviewID, storeID, userID int) review.Review {
+	return review.Review{
+		ID:       reviewID,
+		StoreID:  storeID,
+		Author:   review.Author{ID: userID},
+		Rating:   rr.Rating,
+		Text:     rr.Text,
+		Pictures: utils.RemoveDuplicates(rr.Pictures),
+	}
+}
+
+type ReplyRequest struct {
+	Text string `json:"text" validate:"required,max=5000"`
+}
+
+type ReportRequest struct {
+	Reason string `json:"reason" validate:"required,max=1000"`
+}
+
+type ModerationRequest struct {
+	Action string `json:"action" validate:"required,oneof=hide dismiss"`
+}
+
+func prefixReview(rv *review.Review, staticURL string) {
+	for i := range rv.Pictures {
+		rv.Pictures[i] = staticURL + "/" + rv.Pictures[i]
+	}
+	if rv.Author.Avatar != nil {
+		avatar := staticURL + "/" + *rv.Author.Avatar
+		rv.Author.Avatar = &avatar
+	}
+}
+
+type ReviewResponse struct {
+	Review review.Review `json:"review"`
+}
+
+func NewReviewResponse(rv review.Review, staticURL string) ReviewResponse {
+	prefixReview(&rv, staticURL)
+	return ReviewResponse{Review: rv}
+}
+
+type ReviewsResponse struct {
+	Reviews []review.Review `json:"reviews"`
+}
+
+func NewReviewsResponse(elements []review.Review, staticURL string) ReviewsResponse {
+	for i := range elements {
+		prefixReview(&elements[i], staticURL)
+	}
+	return ReviewsResponse{Reviews: elements}
+}
+
+type ReportResponse struct {
+	Report review.Report `json:"report"`
+}
+
+func NewReportResponse(report review.Report, staticURL string) ReportResponse {
+	prefixReview(&report.Review, staticURL)
+	return ReportResponse{Report: report}
+}
+
+type ReportsResponse struct {
+	Reports []review.Report `json:"reports"`
+}
+
+func NewReportsResponse(elements []review.Report, staticURL string) ReportsResponse {
+	for i := range elements {
+		prefixReview(&elements[i].Review, staticURL)
+	}
+	return ReportsResponse{Reports: elements}
+}
@@ -0,0 +1,49 @@
+package review
+
+import "time"
+
+const (
+	ReportStatusPending   = "pending"
+	ReportStatusHidden    = "hidden"
+	ReportStatusDismissed = "dismissed"
+)
+
+type Author struct {
+	ID        int     `json:"id"`
+	Username  *string `json:"username"`
+	FirstName *string `json:"firstName"`
+	Avatar    *string `json:"avatar"`
+}
+
+type Reply struct {
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Review struct {
+	ID        int       `json:"id"`
+	StoreID   int       `json:"storeId"`
+	BrandID   int       `json:"-"`
+	Author    Author    `json:"author"`
+	Rating    int       `json:"rating"`
+	Text      string    `json:"text"`
+	Pictures  []string  `json:"pictures"`
+	Reply     *Reply    `json:"reply"`
+	IsHidden  bool      `json:"isHidden"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type Report struct {
+	ID         int        `json:"id"`
+	Review     Review     `json:"review"`
+	ReporterID int        `json:"reporterId"`
+	Reason     string     `json:"reason"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ResolvedAt *time.Time `json:"resolvedAt"`
+}
+
+type ReportFilter struct {
+	Status string
+}
@@ -0,0 +1,381 @@
+package reviewservice
+
+import (
+	"context"
+	"errors"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/audit"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/review"
+	reviewdb "github.com/xw1nchester/kushfinds-backend/internal/market/review/db"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/store"
+	"github.com/xw1nchester/kushfinds-backend/internal/user"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor"
+	"go.uber.org/zap"
+)
+
+const (
+	ModerationActionHide    = "hide"
+	ModerationActionDismiss = "dismiss"
+)
+
+var (
+	ErrNoCompletedOrder    = apperror.NewAppError("review.no_completed_order", "only customers with a completed order can review this store")
+	ErrReviewAlreadyExists = apperror.NewConflictError("review.already_exists", "you have already reviewed this store")
+	ErrReplyAlreadyExists  = apperror.NewConflictError("review.reply_already_exists", "review already has a reply")
+	ErrReportAlreadyExists = apperror.NewConflictError("review.report_already_exists", "you have already reported this review")
+	ErrOwnReviewReport     = apperror.NewAppError("review.own_review_report", "you can not report your own review")
+	ErrReportResolved      = apperror.NewAppError("review.report_resolved", "report is already resolved")
+	ErrInvalidAction       = apperror.NewAppError("review.invalid_action", "unknown moderation action")
+)
+
+type Repository interface {
+	GetReviewByID(ctx context.Context, id int) (*review.Review, error)
+	GetStoreReviews(ctx context.Context, storeID int) ([]review.Review, error)
+	GetUserReviews(ctx context.Context, userID int) ([]review.Review, error)
+	HasCompletedOrder(ctx context.Context, userID, storeID int) (bool, error)
+	CreateReview(ctx context.Context, data review.Review) (int, error)
+	UpdateReview(ctx context.Context, data review.Review) error
+	DeleteReview(ctx context.Context, id int) error
+	SetReply(ctx context.Context, reviewID int, text string) error
+	SetReviewHidden(ctx context.Context, id int, hidden bool) error
+
+	CreateReport(ctx context.Context, reviewID, userID int, reason string) (int, error)
+	GetReports(ctx context.Context, filter review.ReportFilter) ([]review.Report, error)
+	GetReportByID(ctx context.Context, id int) (*review.Report, error)
+	ResolveReports(ctx context.Context, reviewID int, status string, moderatorID int) error
+}
+
+type StoreService interface {
+	GetStore(ctx context.Context, storeID int) (*store.Store, error)
+	GetUserStore(ctx context.Context, storeID, userID int) (*store.Store, error)
+}
+
+type UserService interface {
+	GetByID(ctx context.Context, id int) (*user.User, error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, record audit.Record) error
+}
+
+type service struct {
+	repository    Repository
+	storeService  StoreService
+	userService   UserService
+	txManager     transactor.Manager
+	auditRecorder AuditRecorder
+	logger        *zap.Logger
+}
+
+func New(
+	repository Repository,
+	storeService StoreService,
+	userService UserService,
+	txManager transactor.Manager,
+	auditRecorder AuditRecorder,
+	logger *zap.Logger,
+) *service {
+	return &service{
+		repository:    repository,
+		storeService:  storeService,
+		userService:   userService,
+		txManager:     txManager,
+		auditRecorder: auditRecorder,
+		logger:        logger,
+	}
+}
+
+func (s *service) checkAdmin(ctx context.Context, userID int) error {
+	existingUser, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !existingUser.IsAdmin {
+		return apperror.ErrForbidden
+	}
+
+	return nil
+}
+
+func (s *service) getReviewByID(ctx context.Context, id int) (*review.Review, error) {
+	existingReview, err := s.repository.GetReviewByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, reviewdb.ErrReviewNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching review", zap.Error(err))
+
+		return nil, err
+	}
+
+	return existingReview, nil
+}
+
+func (s *service) getUserReview(ctx context.Context, reviewID, userID int) (*review.Review, error) {
+	existingReview, err := s.getReviewByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingReview.Author.ID != userID {
+		return nil, apperror.ErrNotFound
+	}
+
+	return existingReview, nil
+}
+
+func (s *service) GetStoreReviews(ctx context.Context, storeID int) ([]review.Review, error) {
+	if _, err := s.storeService.GetStore(ctx, storeID); err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.repository.GetStoreReviews(ctx, storeID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching store reviews", zap.Error(err))
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (s *service) GetUserReviews(ctx context.Context, userID int) ([]review.Review, error) {
+	reviews, err := s.repository.GetUserReviews(ctx, userID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching user reviews", zap.Error(err))
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (s *service) CreateReview(ctx context.Context, data review.Review) (*review.Review, error) {
+	if _, err := s.storeService.GetStore(ctx, data.StoreID); err != nil {
+		return nil, err
+	}
+
+	hasOrder, err := s.repository.HasCompletedOrder(ctx, data.Author.ID, data.StoreID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when checking completed orders", zap.Error(err))
+		return nil, err
+	}
+
+	if !hasOrder {
+		return nil, ErrNoCompletedOrder
+	}
+
+	var reviewID int
+	if err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		reviewID, err = s.repository.CreateReview(txCtx, data)
+		return err
+	}); err != nil {
+		if errors.Is(err, reviewdb.ErrReviewAlreadyExists) {
+			return nil, ErrReviewAlreadyExists
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when creating review", zap.Error(err))
+
+		return nil, err
+	}
+
+	return s.getReviewByID(ctx, reviewID)
+}
+
+func (s *service) UpdateReview(ctx context.Context, data review.Review) (*review.Review, error) {
+	existingReview, err := s.getUserReview(ctx, data.ID, data.Author.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	data.StoreID = existingReview.StoreID
+
+	if err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		return s.repository.UpdateReview(txCtx, data)
+	}); err != nil {
+		if errors.Is(err, reviewdb.ErrReviewNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when updating review", zap.Error(err))
+
+		return nil, err
+	}
+
+	return s.getReviewByID(ctx, data.ID)
+}
+
+func (s *service) DeleteReview(ctx context.Context, reviewID, userID int) error {
+	existingReview, err := s.getUserReview(ctx, reviewID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repository.DeleteReview(txCtx, reviewID); err != nil {
+			return err
+		}
+
+		return s.auditRecorder.Record(txCtx, audit.Record{
+			ActorID:    userID,
+			Action:     audit.ActionReviewDelete,
+			EntityType: audit.EntityReview,
+			EntityID:   reviewID,
+			Before:     existingReview,
+		})
+	}); err != nil {
+		if errors.Is(err, reviewdb.ErrReviewNotFound) {
+			return apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when deleting review", zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) ReplyToReview(ctx context.Context, storeID, reviewID, userID int, text string) (*review.Review, error) {
+	if _, err := s.storeService.GetUserStore(ctx, storeID, userID); err != nil {
+		return nil, err
+	}
+
+	existingReview, err := s.getReviewByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingReview.StoreID != storeID {
+		return nil, apperror.ErrNotFound
+	}
+
+	if existingReview.Reply != nil {
+		return nil, ErrReplyAlreadyExists
+	}
+
+	if err := s.repository.SetReply(ctx, reviewID, text); err != nil {
+		if errors.Is(err, reviewdb.ErrReplyAlreadyExists) {
+			return nil, ErrReplyAlreadyExists
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when replying to review", zap.Error(err))
+
+		return nil, err
+	}
+
+	return s.getReviewByID(ctx, reviewID)
+}
+
+func (s *service) ReportReview(ctx context.Context, reviewID, userID int, reason string) error {
+	existingReview, err := s.getReviewByID(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+
+	if existingReview.IsHidden {
+		return apperror.ErrNotFound
+	}
+
+	if existingReview.Author.ID == userID {
+		return ErrOwnReviewReport
+	}
+
+	if _, err := s.repository.CreateReport(ctx, reviewID, userID, reason); err != nil {
+		if errors.Is(err, reviewdb.ErrReportAlreadyExists) {
+			return ErrReportAlreadyExists
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when reporting review", zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) GetReports(ctx context.Context, adminID int, filter review.ReportFilter) ([]review.Report, error) {
+	if err := s.checkAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	reports, err := s.repository.GetReports(ctx, filter)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching review reports", zap.Error(err))
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+func (s *service) getReportByID(ctx context.Context, id int) (*review.Report, error) {
+	report, err := s.repository.GetReportByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, reviewdb.ErrReportNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching review report", zap.Error(err))
+
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// ModerateReport resolves the report and every other pending report of the same review.
+// Hiding removes the review from the public list and from the store and brand rating.
+func (s *service) ModerateReport(ctx context.Context, adminID, reportID int, action string) (*review.Report, error) {
+	if err := s.checkAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	report, err := s.getReportByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.Status != review.ReportStatusPending {
+		return nil, ErrReportResolved
+	}
+
+	var status string
+	switch action {
+	case ModerationActionHide:
+		status = review.ReportStatusHidden
+	case ModerationActionDismiss:
+		status = review.ReportStatusDismissed
+	default:
+		return nil, ErrInvalidAction
+	}
+
+	if err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repository.ResolveReports(txCtx, report.Review.ID, status, adminID); err != nil {
+			return err
+		}
+
+		if status != review.ReportStatusHidden {
+			return nil
+		}
+
+		if err := s.repository.SetReviewHidden(txCtx, report.Review.ID, true); err != nil {
+			return err
+		}
+
+		return s.auditRecorder.Record(txCtx, audit.Record{
+			ActorID:    adminID,
+			Action:     audit.ActionReviewHide,
+			EntityType: audit.EntityReview,
+			EntityID:   report.Review.ID,
+			Before:     map[string]any{"isHidden": false},
+			After:      map[string]any{"isHidden": true, "reportId": reportID},
+		})
+	}); err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when moderating review report", zap.Error(err))
+		return nil, err
+	}
+
+	return s.getReportByID(ctx, reportID)
+}
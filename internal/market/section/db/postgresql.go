@@ -26,9 +26,9 @@ func New(client *pgxpool.Pool, logger *zap.Logger) *repository {
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]marketsection.MarketSection, error) {
-	query := `SELECT id, name FROM market_sections`
+	query := `SELECT id, name FROM market_sections WHERE is_active=true ORDER BY position, id`
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	rows, err := r.client.Query(ctx, query)
 	if err != nil {
@@ -61,10 +61,10 @@ func (r *repository) GetAll(ctx context.Context) ([]marketsection.MarketSection,
 func (r *repository) GetByID(ctx context.Context, id int) (*marketsection.MarketSection, error) {
 	query := `
         SELECT id, name FROM market_sections
-		WHERE id=$1
+		WHERE id=$1 AND is_active=true
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var marketSection marketsection.MarketSection
 	err := r.client.QueryRow(ctx, query, id).Scan(&marketSection.ID, &marketSection.Name)
@@ -94,11 +94,11 @@ func (r *repository) CheckMarketSectionsExist(
 	}
 
 	query := fmt.Sprintf(
-		`SELECT COUNT(id) FROM market_sections WHERE id IN (%s)`,
+		`SELECT COUNT(id) FROM market_sections WHERE is_active=true AND id IN (%s)`,
 		strings.Join(placeholders, ", "),
 	)
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var count int
 	err := r.client.QueryRow(ctx, query, args...).Scan(&count)
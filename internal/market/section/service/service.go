@@ -5,8 +5,10 @@ import (
 	"errors"
 
 	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
 	marketsection "github.com/xw1nchester/kushfinds-backend/internal/market/section"
 	"github.com/xw1nchester/kushfinds-backend/internal/market/section/db"
+	"github.com/xw1nchester/kushfinds-backend/internal/tracing"
 	"go.uber.org/zap"
 )
 
@@ -34,7 +36,7 @@ func New(
 func (s *service) GetAll(ctx context.Context) ([]marketsection.MarketSection, error) {
 	marketSections, err := s.repository.GetAll(ctx)
 	if err != nil {
-		s.logger.Error("unexpected error when fetching all market sections", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching all market sections", zap.Error(err))
 
 		return nil, err
 	}
@@ -49,7 +51,7 @@ func (s *service) GetByID(ctx context.Context, id int) (*marketsection.MarketSec
 			return nil, apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when fetching market section by id", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching market section by id", zap.Error(err))
 
 		return nil, err
 	}
@@ -61,13 +63,16 @@ func (s *service) GetByID(ctx context.Context, id int) (*marketsection.MarketSec
 }
 
 func (s *service) CheckMarketSectionsExist(ctx context.Context, marketSectionIDs []int) error {
+	ctx, span := tracing.Start(ctx, "marketsectionservice.CheckMarketSectionsExist")
+	defer span.End()
+
 	err := s.repository.CheckMarketSectionsExist(ctx, marketSectionIDs)
 	if err != nil {
 		if errors.Is(err, db.ErrMarketSectionNotFound) {
 			return apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when check market sections exists", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when check market sections exists", zap.Error(err))
 	}
 
 	return err
@@ -26,9 +26,9 @@ func New(client *pgxpool.Pool, logger *zap.Logger) *repository {
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]social.Social, error) {
-	query := `SELECT id, name, icon FROM socials`
+	query := `SELECT id, name, icon FROM socials WHERE is_active=true ORDER BY position, id`
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	rows, err := r.client.Query(ctx, query)
 	if err != nil {
@@ -62,10 +62,10 @@ func (r *repository) GetAll(ctx context.Context) ([]social.Social, error) {
 func (r *repository) GetByID(ctx context.Context, id int) (*social.Social, error) {
 	query := `
         SELECT id, name, icon FROM socials
-		WHERE id=$1
+		WHERE id=$1 AND is_active=true
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var social social.Social
 	err := r.client.QueryRow(ctx, query, id).Scan(&social.ID, &social.Name, &social.Icon)
@@ -92,11 +92,11 @@ func (r *repository) CheckSocialsExist(ctx context.Context, IDs []int) error {
 	}
 
 	query := fmt.Sprintf(
-		`SELECT COUNT(id) FROM socials WHERE id IN (%s)`,
+		`SELECT COUNT(id) FROM socials WHERE is_active=true AND id IN (%s)`,
 		strings.Join(placeholders, ", "),
 	)
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var count int
 	err := r.client.QueryRow(ctx, query, args...).Scan(&count)
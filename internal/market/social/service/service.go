@@ -5,8 +5,10 @@ import (
 	"errors"
 
 	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
 	"github.com/xw1nchester/kushfinds-backend/internal/market/social"
 	socialdb "github.com/xw1nchester/kushfinds-backend/internal/market/social/db"
+	"github.com/xw1nchester/kushfinds-backend/internal/tracing"
 	"go.uber.org/zap"
 )
 
@@ -34,7 +36,7 @@ func New(
 func (s *service) GetAll(ctx context.Context) ([]social.Social, error) {
 	socials, err := s.repository.GetAll(ctx)
 	if err != nil {
-		s.logger.Error("unexpected error when fetching all socials", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching all socials", zap.Error(err))
 
 		return nil, err
 	}
@@ -49,7 +51,7 @@ func (s *service) GetByID(ctx context.Context, id int) (*social.Social, error) {
 			return nil, apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when fetching social by id", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching social by id", zap.Error(err))
 
 		return nil, err
 	}
@@ -58,13 +60,16 @@ func (s *service) GetByID(ctx context.Context, id int) (*social.Social, error) {
 }
 
 func (s *service) CheckSocialsExist(ctx context.Context, IDs []int) error {
+	ctx, span := tracing.Start(ctx, "socialservice.CheckSocialsExist")
+	defer span.End()
+
 	err := s.repository.CheckSocialsExist(ctx, IDs)
 	if err != nil {
 		if errors.Is(err, socialdb.ErrSocialNotFound) {
 			return apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when check socials exists", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when check socials exists", zap.Error(err))
 	}
 
 	return err
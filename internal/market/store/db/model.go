@@ -3,6 +3,11 @@ package storedb
 import "errors"
 
 var (
-	ErrStoreTypeNotFound = errors.New("store type not found")
-	ErrStoreNotFound     = errors.New("store not found")
+	ErrStoreTypeNotFound    = errors.New("store type not found")
+	ErrStoreNotFound        = errors.New("store not found")
+	ErrStoreVersionMismatch = errors.New("store version mismatch")
+	ErrPictureNotFound      = errors.New("store picture not found")
+	ErrPictureAlreadyExists = errors.New("store picture already exists")
+	ErrSocialNotFound       = errors.New("store social not found")
+	ErrLicenseNotFound      = errors.New("store license not found")
 )
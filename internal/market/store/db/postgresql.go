@@ -6,10 +6,12 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/xw1nchester/kushfinds-backend/internal/logging"
 	"github.com/xw1nchester/kushfinds-backend/internal/market/social"
 	"github.com/xw1nchester/kushfinds-backend/internal/market/store"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor/postgresql"
 	"go.uber.org/zap"
 )
 
@@ -25,10 +27,17 @@ func New(client *pgxpool.Pool, logger *zap.Logger) *repository {
 	}
 }
 
+const uniqueViolationCode = "23505"
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
 func (r *repository) GetAllStoreTypes(ctx context.Context) ([]store.StoreType, error) {
-	query := `SELECT id, name FROM store_types`
+	query := `SELECT id, name FROM store_types WHERE is_active=true ORDER BY position, id`
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	rows, err := r.client.Query(ctx, query)
 	if err != nil {
@@ -61,10 +70,10 @@ func (r *repository) GetAllStoreTypes(ctx context.Context) ([]store.StoreType, e
 func (r *repository) GetStoreTypeByID(ctx context.Context, id int) (*store.StoreType, error) {
 	query := `
         SELECT id, name FROM store_types
-		WHERE id=$1
+		WHERE id=$1 AND is_active=true
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var storeType store.StoreType
 	err := r.client.QueryRow(ctx, query, id).Scan(&storeType.ID, &storeType.Name)
@@ -79,6 +88,8 @@ func (r *repository) GetStoreTypeByID(ctx context.Context, id int) (*store.Store
 }
 
 func (r *repository) GetStoreByID(ctx context.Context, id int) (*store.Store, error) {
+	executor := postgresql.GetExecutor(ctx, r.client)
+
 	query := `
 		SELECT
 			s.id,
@@ -93,6 +104,7 @@ func (r *repository) GetStoreByID(ctx context.Context, id int) (*store.Store, er
 			c.name,
 			st.id,
 			st.name,
+			st.timezone,
 			r.id,
 			r.name,
 			s.street,
@@ -105,7 +117,13 @@ func (r *repository) GetStoreByID(ctx context.Context, id int) (*store.Store, er
 			s.delivery_price,
 			s.minimal_order_price,
 			s.delivery_distance,
+			s.latitude,
+			s.longitude,
+			s.followers_count,
+			ROUND(COALESCE(s.rating_sum::numeric / NULLIF(s.reviews_count, 0), 0), 2)::float8,
+			s.reviews_count,
 			s.is_published,
+			s.version,
 			s.created_at,
 			s.updated_at
 		FROM stores s
@@ -117,10 +135,10 @@ func (r *repository) GetStoreByID(ctx context.Context, id int) (*store.Store, er
 		WHERE s.id=$1
 	`
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var store store.Store
-	if err := r.client.QueryRow(ctx, query, id).Scan(
+	if err := executor.QueryRow(ctx, query, id).Scan(
 		&store.ID,
 		&store.UserID,
 		&store.Brand.ID,
@@ -133,6 +151,7 @@ func (r *repository) GetStoreByID(ctx context.Context, id int) (*store.Store, er
 		&store.Country.Name,
 		&store.State.ID,
 		&store.State.Name,
+		&store.Schedule.Timezone,
 		&store.Region.ID,
 		&store.Region.Name,
 		&store.Street,
@@ -145,7 +164,13 @@ func (r *repository) GetStoreByID(ctx context.Context, id int) (*store.Store, er
 		&store.DeliveryPrice,
 		&store.MinimalOrderPrice,
 		&store.DeliveryDistance,
+		&store.Latitude,
+		&store.Longitude,
+		&store.FollowersCount,
+		&store.Rating,
+		&store.ReviewsCount,
 		&store.IsPublished,
+		&store.Version,
 		&store.CreatedAt,
 		&store.UpdatedAt,
 	); err != nil {
@@ -159,11 +184,12 @@ func (r *repository) GetStoreByID(ctx context.Context, id int) (*store.Store, er
 		SELECT url
 		FROM stores_pictures
 		WHERE store_id = $1
+		ORDER BY position, id
 	`
 
-	logging.LogSQLQuery(r.logger, picsQuery)
+	logging.LogSQLQuery(ctx, r.logger, picsQuery)
 
-	rows, err := r.client.Query(ctx, picsQuery, id)
+	rows, err := executor.Query(ctx, picsQuery, id)
 	if err != nil {
 		return nil, err
 	}
@@ -181,39 +207,217 @@ func (r *repository) GetStoreByID(ctx context.Context, id int) (*store.Store, er
 		return nil, err
 	}
 
-	socialsQuery := `
-		SELECT s.id, s.name, s.icon, ss.url
-		FROM stores_socials ss
-		JOIN socials s ON ss.social_id = s.id
-		WHERE ss.store_id = $1
+	if store.Socials, err = r.GetStoreSocials(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err = r.loadSchedule(ctx, store.ID, &store.Schedule); err != nil {
+		return nil, err
+	}
+
+	return &store, nil
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func (r *repository) loadSchedule(ctx context.Context, storeID int, schedule *store.Schedule) error {
+	return r.loadSchedules(ctx, map[int]*store.Schedule{storeID: schedule})
+}
+
+func (r *repository) loadSchedules(ctx context.Context, schedules map[int]*store.Schedule) error {
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	storeIDs := make([]int, 0, len(schedules))
+	for id, schedule := range schedules {
+		storeIDs = append(storeIDs, id)
+		schedule.OpeningHours = make([]store.OpeningHours, 0)
+		schedule.Exceptions = make([]store.HoursException, 0)
+	}
+
+	hoursQuery := `
+		SELECT store_id, weekday, to_char(opens_at, 'HH24:MI'), to_char(closes_at, 'HH24:MI')
+		FROM stores_hours
+		WHERE store_id = ANY($1)
+		ORDER BY weekday, opens_at
 	`
 
-	logging.LogSQLQuery(r.logger, socialsQuery)
+	logging.LogSQLQuery(ctx, r.logger, hoursQuery)
 
-	rows, err = r.client.Query(ctx, socialsQuery, id)
+	rows, err := executor.Query(ctx, hoursQuery, storeIDs)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
-	store.Socials = make([]social.EntitySocial, 0)
 	for rows.Next() {
-		var social social.EntitySocial
+		var storeID int
+		var hours store.OpeningHours
+		if err := rows.Scan(&storeID, &hours.Weekday, &hours.OpensAt, &hours.ClosesAt); err != nil {
+			return err
+		}
+		schedules[storeID].OpeningHours = append(schedules[storeID].OpeningHours, hours)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	// the server date may be a day ahead of or behind the store one, two days
+	// back still cover the exceptions of yesterday in every timezone
+	exceptionsQuery := `
+		SELECT
+			store_id,
+			to_char(date, 'YYYY-MM-DD'),
+			is_closed,
+			to_char(opens_at, 'HH24:MI'),
+			to_char(closes_at, 'HH24:MI'),
+			note
+		FROM stores_hours_exceptions
+		WHERE store_id = ANY($1) AND date >= CURRENT_DATE - 2
+		ORDER BY date, opens_at
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, exceptionsQuery)
+
+	rows, err = executor.Query(ctx, exceptionsQuery, storeIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var storeID int
+		var exception store.HoursException
+		var opensAt, closesAt *string
 		if err := rows.Scan(
-			&social.ID,
-			&social.Name,
-			&social.Icon,
-			&social.Url,
+			&storeID,
+			&exception.Date,
+			&exception.IsClosed,
+			&opensAt,
+			&closesAt,
+			&exception.Note,
 		); err != nil {
-			return nil, err
+			return err
 		}
-		store.Socials = append(store.Socials, social)
+		if opensAt != nil {
+			exception.OpensAt = *opensAt
+		}
+		if closesAt != nil {
+			exception.ClosesAt = *closesAt
+		}
+		schedules[storeID].Exceptions = append(schedules[storeID].Exceptions, exception)
 	}
-	if err = rows.Err(); err != nil {
-		return nil, err
+
+	return rows.Err()
+}
+
+func (r *repository) createStoreSchedule(
+	ctx context.Context,
+	tx pgx.Tx,
+	storeID int,
+	schedule store.Schedule,
+) error {
+	if len(schedule.OpeningHours) > 0 {
+		insertHoursQuery := `
+			INSERT INTO stores_hours (store_id, weekday, opens_at, closes_at)
+			VALUES ($1, $2, $3, $4)
+		`
+		batch := &pgx.Batch{}
+		for _, h := range schedule.OpeningHours {
+			logging.LogSQLQuery(ctx, r.logger, insertHoursQuery)
+			batch.Queue(insertHoursQuery, storeID, h.Weekday, h.OpensAt, h.ClosesAt)
+		}
+		br := tx.SendBatch(ctx, batch)
+		if err := br.Close(); err != nil {
+			return err
+		}
 	}
 
-	return &store, nil
+	if len(schedule.Exceptions) > 0 {
+		insertExceptionsQuery := `
+			INSERT INTO stores_hours_exceptions (store_id, date, is_closed, opens_at, closes_at, note)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`
+		batch := &pgx.Batch{}
+		for _, e := range schedule.Exceptions {
+			logging.LogSQLQuery(ctx, r.logger, insertExceptionsQuery)
+			batch.Queue(
+				insertExceptionsQuery,
+				storeID,
+				e.Date,
+				e.IsClosed,
+				nullableString(e.OpensAt),
+				nullableString(e.ClosesAt),
+				e.Note,
+			)
+		}
+		br := tx.SendBatch(ctx, batch)
+		if err := br.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateStoreSchedule replaces the store opening hours. When versions is not
+// nil the schedule is replaced only if the current store version is listed,
+// ErrStoreVersionMismatch is returned otherwise.
+func (r *repository) UpdateStoreSchedule(
+	ctx context.Context,
+	storeID int,
+	schedule store.Schedule,
+	versions []int,
+) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// bumping the version first locks the row against concurrent edits
+	updateQuery := `
+		UPDATE stores
+		SET version=version+1, updated_at=NOW()
+		WHERE id=$1 AND ($2::int[] IS NULL OR version=ANY($2))
+	`
+	logging.LogSQLQuery(ctx, r.logger, updateQuery)
+	tag, err := tx.Exec(ctx, updateQuery, storeID, versions)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		if versions != nil {
+			return ErrStoreVersionMismatch
+		}
+		return ErrStoreNotFound
+	}
+
+	deleteHoursQuery := "DELETE FROM stores_hours WHERE store_id=$1"
+	logging.LogSQLQuery(ctx, r.logger, deleteHoursQuery)
+	if _, err = tx.Exec(ctx, deleteHoursQuery, storeID); err != nil {
+		return err
+	}
+
+	deleteExceptionsQuery := "DELETE FROM stores_hours_exceptions WHERE store_id=$1"
+	logging.LogSQLQuery(ctx, r.logger, deleteExceptionsQuery)
+	if _, err = tx.Exec(ctx, deleteExceptionsQuery, storeID); err != nil {
+		return err
+	}
+
+	if err = r.createStoreSchedule(ctx, tx, storeID, schedule); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (r *repository) createStoreRelatedEnitities(
@@ -224,13 +428,13 @@ func (r *repository) createStoreRelatedEnitities(
 ) error {
 	if len(data.Pictures) > 0 {
 		query := `
-		INSERT INTO stores_pictures (store_id, url)
-		VALUES ($1, $2)
+		INSERT INTO stores_pictures (store_id, url, position)
+		VALUES ($1, $2, $3)
 	`
 		batch := &pgx.Batch{}
-		for _, url := range data.Pictures {
-			logging.LogSQLQuery(r.logger, query)
-			batch.Queue(query, storeID, url)
+		for i, url := range data.Pictures {
+			logging.LogSQLQuery(ctx, r.logger, query)
+			batch.Queue(query, storeID, url, i)
 		}
 		br := tx.SendBatch(ctx, batch)
 		if err := br.Close(); err != nil {
@@ -245,7 +449,7 @@ func (r *repository) createStoreRelatedEnitities(
         `
 		batch := &pgx.Batch{}
 		for _, s := range data.Socials {
-			logging.LogSQLQuery(r.logger, insertSocialsQuery)
+			logging.LogSQLQuery(ctx, r.logger, insertSocialsQuery)
 			batch.Queue(insertSocialsQuery, storeID, s.ID, s.Url)
 		}
 		br := tx.SendBatch(ctx, batch)
@@ -254,23 +458,23 @@ func (r *repository) createStoreRelatedEnitities(
 		}
 	}
 
-	return nil
+	return r.createStoreSchedule(ctx, tx, storeID, data.Schedule)
 }
 
 func (r *repository) CreateStore(ctx context.Context, data store.Store) (*store.Store, error) {
-	tx, err := r.client.Begin(ctx)
+	tx, err := postgresql.Begin(ctx, r.client)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
 
 	query := `
-        INSERT INTO stores (brand_id, name, banner, description, country_id, state_id, region_id, street, house, post_code, email, phone_number, store_type_id, delivery_price, minimal_order_price, delivery_distance, is_published)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+        INSERT INTO stores (brand_id, name, banner, description, country_id, state_id, region_id, street, house, post_code, email, phone_number, store_type_id, delivery_price, minimal_order_price, delivery_distance, latitude, longitude, is_published)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
         RETURNING id
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var id int
 	if err = tx.QueryRow(
@@ -292,15 +496,15 @@ func (r *repository) CreateStore(ctx context.Context, data store.Store) (*store.
 		data.DeliveryPrice,
 		data.MinimalOrderPrice,
 		data.DeliveryDistance,
+		data.Latitude,
+		data.Longitude,
 		data.IsPublished,
 	).Scan(&id); err != nil {
 		return nil, err
 	}
 
-	if len(data.Pictures) > 0 {
-		if err = r.createStoreRelatedEnitities(ctx, tx, id, data); err != nil {
-			return nil, err
-		}
+	if err = r.createStoreRelatedEnitities(ctx, tx, id, data); err != nil {
+		return nil, err
 	}
 
 	if err = tx.Commit(ctx); err != nil {
@@ -310,17 +514,28 @@ func (r *repository) CreateStore(ctx context.Context, data store.Store) (*store.
 	return r.GetStoreByID(ctx, id)
 }
 
-func (r *repository) GetUserStores(ctx context.Context, userID int) ([]store.StoreSummary, error) {
+func (r *repository) getStoresSummary(ctx context.Context, condition string, args ...any) ([]store.StoreSummary, error) {
 	query := `
-		SELECT s.id, s.name, s.banner, b.id, b.name, b.logo
+		SELECT
+			s.id,
+			s.name,
+			s.banner,
+			b.id,
+			b.name,
+			b.logo,
+			st.timezone,
+			ROUND(COALESCE(s.rating_sum::numeric / NULLIF(s.reviews_count, 0), 0), 2)::float8,
+			s.reviews_count
 		FROM stores s
 		LEFT JOIN brands b ON s.brand_id = b.id
-		WHERE b.user_id=$1
+		LEFT JOIN states st ON s.state_id = st.id
+		WHERE ` + condition + `
+		ORDER BY s.id
 	`
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
-	rows, err := r.client.Query(ctx, query, userID)
+	rows, err := r.client.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -337,6 +552,9 @@ func (r *repository) GetUserStores(ctx context.Context, userID int) ([]store.Sto
 			&store.Brand.ID,
 			&store.Brand.Name,
 			&store.Brand.Logo,
+			&store.Schedule.Timezone,
+			&store.Rating,
+			&store.ReviewsCount,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %v", err)
@@ -349,5 +567,546 @@ func (r *repository) GetUserStores(ctx context.Context, userID int) ([]store.Sto
 		return nil, fmt.Errorf("row error: %v", err)
 	}
 
+	schedules := make(map[int]*store.Schedule, len(stores))
+	for i := range stores {
+		schedules[stores[i].ID] = &stores[i].Schedule
+	}
+
+	if err := r.loadSchedules(ctx, schedules); err != nil {
+		return nil, err
+	}
+
 	return stores, nil
 }
+
+// GetUserStores returns the stores of businesses the user is a team member of,
+// staff gets the stores assigned to them only.
+func (r *repository) GetUserStores(ctx context.Context, userID int) ([]store.StoreSummary, error) {
+	return r.getStoresSummary(
+		ctx,
+		`EXISTS (
+			SELECT 1
+			FROM team_members tm
+			WHERE tm.business_id = b.user_id AND tm.user_id=$1
+			AND (tm.role<>'staff' OR EXISTS (
+				SELECT 1
+				FROM team_members_stores tms
+				WHERE tms.business_id = tm.business_id AND tms.user_id = tm.user_id AND tms.store_id = s.id
+			))
+		)`,
+		userID,
+	)
+}
+
+func (r *repository) GetPublishedStores(ctx context.Context) ([]store.StoreSummary, error) {
+	return r.getStoresSummary(ctx, "s.is_published=true AND b.is_published=true")
+}
+
+func (r *repository) GetFavoriteStores(ctx context.Context, userID int) ([]store.StoreSummary, error) {
+	return r.getStoresSummary(
+		ctx,
+		`s.id IN (SELECT store_id FROM favorites_stores WHERE user_id=$1)
+		AND s.is_published=true AND b.is_published=true`,
+		userID,
+	)
+}
+
+func (r *repository) SetStoreLocation(ctx context.Context, storeID int, latitude, longitude float64) error {
+	query := "UPDATE stores SET latitude=$2, longitude=$3 WHERE id=$1"
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	_, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, storeID, latitude, longitude)
+
+	return err
+}
+
+// BumpStoreVersion increments the store version before an edit of its
+// related entities, which also locks the store row within the transaction.
+// Versions work as in UpdateStoreSchedule.
+func (r *repository) BumpStoreVersion(ctx context.Context, storeID int, versions []int) error {
+	query := `
+		UPDATE stores
+		SET version=version+1, updated_at=NOW()
+		WHERE id=$1 AND ($2::int[] IS NULL OR version=ANY($2))
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, storeID, versions)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		if versions != nil {
+			return ErrStoreVersionMismatch
+		}
+		return ErrStoreNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) GetStorePictures(ctx context.Context, storeID int) ([]store.Picture, error) {
+	query := `
+		SELECT id, url, position
+		FROM stores_pictures
+		WHERE store_id=$1
+		ORDER BY position, id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := postgresql.GetExecutor(ctx, r.client).Query(ctx, query, storeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pictures := make([]store.Picture, 0)
+	for rows.Next() {
+		var picture store.Picture
+		if err := rows.Scan(&picture.ID, &picture.Url, &picture.Position); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+		pictures = append(pictures, picture)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return pictures, nil
+}
+
+// AddStorePicture inserts the picture at the position shifting the following
+// ones, nil or too large position appends it. Has to run within a transaction.
+func (r *repository) AddStorePicture(ctx context.Context, storeID int, url string, position *int) error {
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	if position != nil {
+		shiftQuery := `
+			UPDATE stores_pictures
+			SET position=position+1
+			WHERE store_id=$1 AND position>=$2
+		`
+		logging.LogSQLQuery(ctx, r.logger, shiftQuery)
+		if _, err := executor.Exec(ctx, shiftQuery, storeID, *position); err != nil {
+			return err
+		}
+	}
+
+	insertQuery := `
+		INSERT INTO stores_pictures (store_id, url, position)
+		SELECT $1, $2, LEAST(COALESCE($3, n.count), n.count)
+		FROM (SELECT COUNT(*)::int AS count FROM stores_pictures WHERE store_id=$1) n
+	`
+	logging.LogSQLQuery(ctx, r.logger, insertQuery)
+	if _, err := executor.Exec(ctx, insertQuery, storeID, url, position); err != nil {
+		if isUniqueViolation(err) {
+			return ErrPictureAlreadyExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteStorePicture has to run within a transaction, the following
+// pictures are shifted to keep positions contiguous.
+func (r *repository) DeleteStorePicture(ctx context.Context, storeID, pictureID int) error {
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	deleteQuery := `
+		DELETE FROM stores_pictures
+		WHERE store_id=$1 AND id=$2
+		RETURNING position
+	`
+	logging.LogSQLQuery(ctx, r.logger, deleteQuery)
+
+	var position int
+	if err := executor.QueryRow(ctx, deleteQuery, storeID, pictureID).Scan(&position); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPictureNotFound
+		}
+		return err
+	}
+
+	shiftQuery := `
+		UPDATE stores_pictures
+		SET position=position-1
+		WHERE store_id=$1 AND position>$2
+	`
+	logging.LogSQLQuery(ctx, r.logger, shiftQuery)
+	_, err := executor.Exec(ctx, shiftQuery, storeID, position)
+
+	return err
+}
+
+// ReorderStorePictures sets positions of the store pictures
+// by their index in pictureIDs.
+func (r *repository) ReorderStorePictures(ctx context.Context, storeID int, pictureIDs []int) error {
+	query := `
+		UPDATE stores_pictures sp
+		SET position=k.idx-1
+		FROM unnest($2::int[]) WITH ORDINALITY AS k(id, idx)
+		WHERE sp.store_id=$1 AND sp.id=k.id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	_, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, storeID, pictureIDs)
+
+	return err
+}
+
+func (r *repository) GetStoreSocials(ctx context.Context, storeID int) ([]social.EntitySocial, error) {
+	query := `
+		SELECT s.id, s.name, s.icon, ss.url
+		FROM stores_socials ss
+		JOIN socials s ON ss.social_id = s.id
+		WHERE ss.store_id = $1
+		ORDER BY s.id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := postgresql.GetExecutor(ctx, r.client).Query(ctx, query, storeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	socials := make([]social.EntitySocial, 0)
+	for rows.Next() {
+		var social social.EntitySocial
+		if err := rows.Scan(
+			&social.ID,
+			&social.Name,
+			&social.Icon,
+			&social.Url,
+		); err != nil {
+			return nil, err
+		}
+		socials = append(socials, social)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return socials, nil
+}
+
+func (r *repository) SetStoreSocial(ctx context.Context, storeID, socialID int, url string) error {
+	query := `
+		INSERT INTO stores_socials (store_id, social_id, url)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (store_id, social_id) DO UPDATE SET url=EXCLUDED.url
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	_, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, storeID, socialID, url)
+
+	return err
+}
+
+func (r *repository) DeleteStoreSocial(ctx context.Context, storeID, socialID int) error {
+	query := "DELETE FROM stores_socials WHERE store_id=$1 AND social_id=$2"
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, storeID, socialID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrSocialNotFound
+	}
+
+	return nil
+}
+
+const licenseQuery = `
+	SELECT
+		id,
+		store_id,
+		number,
+		url,
+		to_char(expires_at, 'YYYY-MM-DD'),
+		expires_at < CURRENT_DATE,
+		reminded_at,
+		created_at,
+		updated_at
+	FROM stores_licenses
+`
+
+func scanLicense(row pgx.Row) (*store.License, error) {
+	var l store.License
+
+	if err := row.Scan(
+		&l.ID,
+		&l.StoreID,
+		&l.Number,
+		&l.Url,
+		&l.ExpiresAt,
+		&l.IsExpired,
+		&l.RemindedAt,
+		&l.CreatedAt,
+		&l.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
+
+func (r *repository) GetStoreLicenses(ctx context.Context, storeID int) ([]store.License, error) {
+	query := licenseQuery + " WHERE store_id=$1 ORDER BY expires_at, id"
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, storeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	licenses := make([]store.License, 0)
+	for rows.Next() {
+		license, err := scanLicense(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+		licenses = append(licenses, *license)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return licenses, nil
+}
+
+func (r *repository) GetStoreLicense(ctx context.Context, storeID, licenseID int) (*store.License, error) {
+	query := licenseQuery + " WHERE store_id=$1 AND id=$2"
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	license, err := scanLicense(postgresql.GetExecutor(ctx, r.client).QueryRow(ctx, query, storeID, licenseID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrLicenseNotFound
+		}
+		return nil, err
+	}
+
+	return license, nil
+}
+
+func (r *repository) CreateStoreLicense(ctx context.Context, data store.License) (*store.License, error) {
+	query := `
+		INSERT INTO stores_licenses (store_id, number, url, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	var id int
+	if err := postgresql.GetExecutor(ctx, r.client).QueryRow(
+		ctx,
+		query,
+		data.StoreID,
+		data.Number,
+		data.Url,
+		data.ExpiresAt,
+	).Scan(&id); err != nil {
+		return nil, err
+	}
+
+	return r.GetStoreLicense(ctx, data.StoreID, id)
+}
+
+// UpdateStoreLicense overwrites the license, a new expiry date
+// makes it eligible for another reminder.
+func (r *repository) UpdateStoreLicense(ctx context.Context, data store.License) (*store.License, error) {
+	query := `
+		UPDATE stores_licenses
+		SET
+			number=$3,
+			url=$4,
+			reminded_at=CASE WHEN expires_at<>$5::date THEN NULL ELSE reminded_at END,
+			expires_at=$5,
+			updated_at=NOW()
+		WHERE store_id=$1 AND id=$2
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := postgresql.GetExecutor(ctx, r.client).Exec(
+		ctx,
+		query,
+		data.StoreID,
+		data.ID,
+		data.Number,
+		data.Url,
+		data.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return nil, ErrLicenseNotFound
+	}
+
+	return r.GetStoreLicense(ctx, data.StoreID, data.ID)
+}
+
+func (r *repository) DeleteStoreLicense(ctx context.Context, storeID, licenseID int) error {
+	query := "DELETE FROM stores_licenses WHERE store_id=$1 AND id=$2"
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, storeID, licenseID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrLicenseNotFound
+	}
+
+	return nil
+}
+
+// ClaimLicenseReminders marks licenses expiring until the date as reminded
+// and returns them with the owners of their businesses. Claiming in one
+// statement keeps several instances from reminding about the same license,
+// owners are loaded in the same transaction so a failure leaves no claim.
+func (r *repository) ClaimLicenseReminders(ctx context.Context, until string) ([]store.LicenseReminder, error) {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE stores_licenses sl
+		SET reminded_at=NOW()
+		FROM stores s
+		JOIN brands b ON s.brand_id = b.id
+		WHERE sl.store_id = s.id AND sl.reminded_at IS NULL AND sl.expires_at <= $1::date
+		RETURNING
+			sl.id,
+			sl.store_id,
+			sl.number,
+			sl.url,
+			to_char(sl.expires_at, 'YYYY-MM-DD'),
+			sl.expires_at < CURRENT_DATE,
+			s.name,
+			b.user_id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := tx.Query(ctx, query, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reminders := make([]store.LicenseReminder, 0)
+	businessIDs := make([]int, 0)
+	for rows.Next() {
+		var (
+			reminder   store.LicenseReminder
+			businessID int
+		)
+		if err := rows.Scan(
+			&reminder.License.ID,
+			&reminder.License.StoreID,
+			&reminder.License.Number,
+			&reminder.License.Url,
+			&reminder.License.ExpiresAt,
+			&reminder.License.IsExpired,
+			&reminder.StoreName,
+			&businessID,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+		reminders = append(reminders, reminder)
+		businessIDs = append(businessIDs, businessID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	if len(reminders) == 0 {
+		return reminders, tx.Commit(ctx)
+	}
+
+	ownersQuery := `
+		SELECT tm.business_id, u.email, u.locale
+		FROM team_members tm
+		JOIN users u ON tm.user_id = u.id
+		WHERE tm.business_id = ANY($1) AND tm.role='owner' AND u.is_verified=true
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, ownersQuery)
+
+	rows.Close()
+
+	rows, err = tx.Query(ctx, ownersQuery, businessIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	owners := make(map[int][]store.Recipient)
+	for rows.Next() {
+		var (
+			businessID int
+			recipient  store.Recipient
+		)
+		if err := rows.Scan(&businessID, &recipient.Email, &recipient.Locale); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+		owners[businessID] = append(owners[businessID], recipient)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	for i := range reminders {
+		reminders[i].Recipients = owners[businessIDs[i]]
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+// ReleaseLicenseReminder clears the claim of the license, so the reminder
+// is sent again on the next run.
+func (r *repository) ReleaseLicenseReminder(ctx context.Context, licenseID int) error {
+	query := `
+		UPDATE stores_licenses
+		SET reminded_at=NULL
+		WHERE id=$1
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	_, err := r.client.Exec(ctx, query, licenseID)
+
+	return err
+}
@@ -10,39 +10,71 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
 	jwtmiddleware "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt/middleware"
+	"github.com/xw1nchester/kushfinds-backend/internal/etag"
 	"github.com/xw1nchester/kushfinds-backend/internal/handlers"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/social"
 	"github.com/xw1nchester/kushfinds-backend/internal/market/store"
 	"go.uber.org/zap"
 )
 
-var validate = validator.New()
+var validate = apperror.NewValidator()
 
 type Service interface {
 	GetAllStoreTypes(ctx context.Context) ([]store.StoreType, error)
 
 	CreateStore(ctx context.Context, data store.Store) (*store.Store, error)
-	GetUserStores(ctx context.Context, userID int) ([]store.StoreSummary, error)
+	GetUserStores(ctx context.Context, userID int, filter store.Filter) ([]store.StoreSummary, error)
 	GetUserStore(ctx context.Context, storeID, userID int) (*store.Store, error)
+	UpdateStoreSchedule(ctx context.Context, storeID, userID int, schedule store.Schedule, versions []int) (*store.Store, error)
+	UpdateStoreLocation(
+		ctx context.Context,
+		storeID int,
+		userID int,
+		latitude float64,
+		longitude float64,
+		versions []int,
+	) (*store.Store, error)
+
+	GetStorePictures(ctx context.Context, storeID, userID int) ([]store.Picture, error)
+	AddStorePicture(ctx context.Context, storeID, userID int, url string, position *int, versions []int) ([]store.Picture, error)
+	DeleteStorePicture(ctx context.Context, storeID, pictureID, userID int, versions []int) ([]store.Picture, error)
+	ReorderStorePictures(ctx context.Context, storeID, userID int, pictureIDs []int, versions []int) ([]store.Picture, error)
+
+	GetStoreSocials(ctx context.Context, storeID, userID int) ([]social.EntitySocial, error)
+	SetStoreSocial(ctx context.Context, storeID, socialID, userID int, url string, versions []int) ([]social.EntitySocial, error)
+	DeleteStoreSocial(ctx context.Context, storeID, socialID, userID int, versions []int) ([]social.EntitySocial, error)
+
+	GetStoreLicenses(ctx context.Context, storeID, userID int) ([]store.License, error)
+	CreateStoreLicense(ctx context.Context, data store.License, userID int) (*store.License, error)
+	UpdateStoreLicense(ctx context.Context, data store.License, userID int) (*store.License, error)
+	DeleteStoreLicense(ctx context.Context, storeID, licenseID, userID int) error
+
+	GetStores(ctx context.Context, filter store.Filter) ([]store.StoreSummary, error)
+	GetStore(ctx context.Context, storeID int) (*store.Store, error)
 }
 
 type handler struct {
-	service        Service
-	authMiddleware func(http.Handler) http.Handler
-	staticURL      string
-	logger         *zap.Logger
+	service               Service
+	authMiddleware        func(http.Handler) http.Handler
+	idempotencyMiddleware func(http.Handler) http.Handler
+	staticURL             string
+	logger                *zap.Logger
 }
 
 func New(
 	service Service,
 	authMiddleware func(http.Handler) http.Handler,
+	idempotencyMiddleware func(http.Handler) http.Handler,
 	staticURL string,
 	logger *zap.Logger,
 ) handlers.Handler {
 	return &handler{
-		service:        service,
-		authMiddleware: authMiddleware,
-		staticURL:      staticURL,
-		logger:         logger,
+		service:               service,
+		authMiddleware:        authMiddleware,
+		idempotencyMiddleware: idempotencyMiddleware,
+		staticURL:             staticURL,
+		logger:                logger,
 	}
 }
 
@@ -51,14 +83,57 @@ func (h *handler) Register(router chi.Router) {
 		storeTypeRouter.Get("/", apperror.Middleware(h.GetAllStoreTypes))
 	})
 
+	router.Route("/stores", func(storeRouter chi.Router) {
+		storeRouter.Get("/", apperror.Middleware(h.getStoresHandler))
+		storeRouter.Get("/{id}", apperror.Middleware(h.getStoreHandler))
+	})
+
 	router.Route("/me/stores", func(privateStoreHandler chi.Router) {
 		privateStoreHandler.Use(h.authMiddleware)
-		privateStoreHandler.Post("/", apperror.Middleware(h.createStoreHandler))
+		privateStoreHandler.With(h.idempotencyMiddleware).Post("/", apperror.Middleware(h.createStoreHandler))
 		privateStoreHandler.Get("/", apperror.Middleware(h.getUserStoresHandler))
 		privateStoreHandler.Get("/{id}", apperror.Middleware(h.getUserStoreHandler))
+		privateStoreHandler.Put("/{id}/hours", apperror.Middleware(h.updateStoreScheduleHandler))
+		privateStoreHandler.Put("/{id}/location", apperror.Middleware(h.updateStoreLocationHandler))
+
+		privateStoreHandler.Get("/{id}/pictures", apperror.Middleware(h.getStorePicturesHandler))
+		privateStoreHandler.Post("/{id}/pictures", apperror.Middleware(h.addStorePictureHandler))
+		privateStoreHandler.Put("/{id}/pictures/order", apperror.Middleware(h.reorderStorePicturesHandler))
+		privateStoreHandler.Delete("/{id}/pictures/{picture_id}", apperror.Middleware(h.deleteStorePictureHandler))
+
+		privateStoreHandler.Get("/{id}/socials", apperror.Middleware(h.getStoreSocialsHandler))
+		privateStoreHandler.Put("/{id}/socials/{social_id}", apperror.Middleware(h.setStoreSocialHandler))
+		privateStoreHandler.Delete("/{id}/socials/{social_id}", apperror.Middleware(h.deleteStoreSocialHandler))
+
+		privateStoreHandler.Get("/{id}/licenses", apperror.Middleware(h.getStoreLicensesHandler))
+		privateStoreHandler.Post("/{id}/licenses", apperror.Middleware(h.createStoreLicenseHandler))
+		privateStoreHandler.Put("/{id}/licenses/{license_id}", apperror.Middleware(h.updateStoreLicenseHandler))
+		privateStoreHandler.Delete("/{id}/licenses/{license_id}", apperror.Middleware(h.deleteStoreLicenseHandler))
 	})
 }
 
+func parseIntParam(r *http.Request, name string) (int, error) {
+	value, err := strconv.Atoi(chi.URLParam(r, name))
+	if err != nil {
+		return 0, apperror.NewFieldError(name, "field.positive_integer")
+	}
+	return value, nil
+}
+
+func parseFilter(r *http.Request) (store.Filter, error) {
+	var filter store.Filter
+
+	if openNow := r.URL.Query().Get("openNow"); openNow != "" {
+		value, err := strconv.ParseBool(openNow)
+		if err != nil {
+			return filter, apperror.NewFieldError("openNow", "field.boolean")
+		}
+		filter.OpenNow = value
+	}
+
+	return filter, nil
+}
+
 // @Security	ApiKeyAuth
 // @Tags		market
 // @Success	200		{object}	StoreTypesResponse
@@ -78,13 +153,14 @@ func (h *handler) GetAllStoreTypes(w http.ResponseWriter, r *http.Request) error
 // @Security	ApiKeyAuth
 // @Tags		market
 // @Param		request	body		StoreRequest	true	"request body"
+// @Param		Idempotency-Key	header		string	false	"replays the first response to retries with the same key"
 // @Success	200		{object}	StoreResponse
-// @Failure	400,500	{object}	apperror.AppError
+// @Failure	400,409,422,500	{object}	apperror.AppError
 // @Router		/me/stores [post]
 func (h *handler) createStoreHandler(w http.ResponseWriter, r *http.Request) error {
 	var dto StoreRequest
 	if err := render.DecodeJSON(r.Body, &dto); err != nil {
-		h.logger.Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
 		return apperror.ErrDecodeBody
 	}
 
@@ -99,20 +175,68 @@ func (h *handler) createStoreHandler(w http.ResponseWriter, r *http.Request) err
 		return err
 	}
 
-	render.JSON(w, r, NewStoreResponse(*createdStore, h.staticURL))
+	etag.Write(w, r, createdStore.Version, NewStoreResponse(*createdStore, h.staticURL))
 
 	return nil
 }
 
 // @Security	ApiKeyAuth
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		request	body		LocationRequest	true	"request body"
+// @Param		If-Match	header		string	false	"ETag the edit is based on"
+// @Success	200		{object}	StoreResponse
+// @Failure	400,403,404,412,500	{object}	apperror.AppError
+// @Router		/me/stores/{id}/location [put]
+func (h *handler) updateStoreLocationHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var dto LocationRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	store, err := h.service.UpdateStoreLocation(
+		r.Context(),
+		storeID,
+		userID,
+		*dto.Latitude,
+		*dto.Longitude,
+		etag.IfMatch(r),
+	)
+	if err != nil {
+		return err
+	}
+
+	etag.Write(w, r, store.Version, NewStoreResponse(*store, h.staticURL))
+
+	return nil
+}
+
 // @Tags		market
+// @Param		openNow	query		bool	false	"only stores that are open now"
 // @Success	200		{object}	StoresSummaryResponse
 // @Failure	400,500	{object}	apperror.AppError
 // @Router		/me/stores [get]
 func (h *handler) getUserStoresHandler(w http.ResponseWriter, r *http.Request) error {
+	filter, err := parseFilter(r)
+	if err != nil {
+		return err
+	}
+
 	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
 
-	stores, err := h.service.GetUserStores(r.Context(), userID)
+	stores, err := h.service.GetUserStores(r.Context(), userID, filter)
 	if err != nil {
 		return err
 	}
@@ -124,13 +248,15 @@ func (h *handler) getUserStoresHandler(w http.ResponseWriter, r *http.Request) e
 
 // @Security	ApiKeyAuth
 // @Tags		market
+// @Param		If-None-Match	header		string	false	"ETag of the cached store"
 // @Success	200		{object}	StoreResponse
+// @Success	304
 // @Failure	400,500	{object}	apperror.AppError
 // @Router		/me/stores/{id} [get]
 func (h *handler) getUserStoreHandler(w http.ResponseWriter, r *http.Request) error {
 	storeID, err := strconv.Atoi(chi.URLParam(r, "id"))
 	if err != nil {
-		return apperror.NewAppError("id should be positive integer")
+		return apperror.NewFieldError("id", "field.positive_integer")
 	}
 
 	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
@@ -140,7 +266,426 @@ func (h *handler) getUserStoreHandler(w http.ResponseWriter, r *http.Request) er
 		return err
 	}
 
-	render.JSON(w, r, NewStoreResponse(*store, h.staticURL))
+	etag.Write(w, r, store.Version, NewStoreResponse(*store, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		request	body		ScheduleRequest	true	"request body"
+// @Param		If-Match	header		string	false	"ETag the edit is based on"
+// @Success	200		{object}	StoreResponse
+// @Failure	400,412,500	{object}	apperror.AppError
+// @Router		/me/stores/{id}/hours [put]
+func (h *handler) updateStoreScheduleHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return apperror.NewFieldError("id", "field.positive_integer")
+	}
+
+	var dto ScheduleRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	store, err := h.service.UpdateStoreSchedule(r.Context(), storeID, userID, dto.ToDomain(), etag.IfMatch(r))
+	if err != nil {
+		return err
+	}
+
+	etag.Write(w, r, store.Version, NewStoreResponse(*store, h.staticURL))
+
+	return nil
+}
+
+// @Tags		market
+// @Param		openNow	query		bool	false	"only stores that are open now"
+// @Success	200		{object}	StoresSummaryResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/stores [get]
+func (h *handler) getStoresHandler(w http.ResponseWriter, r *http.Request) error {
+	filter, err := parseFilter(r)
+	if err != nil {
+		return err
+	}
+
+	stores, err := h.service.GetStores(r.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewStoresSummaryResponse(stores, h.staticURL))
+
+	return nil
+}
+
+// @Tags		market
+// @Param		If-None-Match	header		string	false	"ETag of the cached store"
+// @Success	200		{object}	StoreResponse
+// @Success	304
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/stores/{id} [get]
+func (h *handler) getStoreHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return apperror.NewFieldError("id", "field.positive_integer")
+	}
+
+	store, err := h.service.GetStore(r.Context(), storeID)
+	if err != nil {
+		return err
+	}
+
+	etag.Write(w, r, store.Version, NewStoreResponse(*store, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Success	200		{object}	PicturesResponse
+// @Failure	400,403,404,500	{object}	apperror.AppError
+// @Router		/me/stores/{id}/pictures [get]
+func (h *handler) getStorePicturesHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	pictures, err := h.service.GetStorePictures(r.Context(), storeID, userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewPicturesResponse(pictures, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		request	body		PictureRequest	true	"request body"
+// @Param		If-Match	header		string	false	"ETag of the store the edit is based on"
+// @Success	200		{object}	PicturesResponse
+// @Failure	400,403,404,409,412,500	{object}	apperror.AppError
+// @Router		/me/stores/{id}/pictures [post]
+func (h *handler) addStorePictureHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var dto PictureRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	pictures, err := h.service.AddStorePicture(
+		r.Context(),
+		storeID,
+		userID,
+		dto.Url,
+		dto.PositionValue(),
+		etag.IfMatch(r),
+	)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewPicturesResponse(pictures, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		request	body		PictureOrderRequest	true	"request body"
+// @Param		If-Match	header		string	false	"ETag of the store the edit is based on"
+// @Success	200		{object}	PicturesResponse
+// @Failure	400,403,404,412,500	{object}	apperror.AppError
+// @Router		/me/stores/{id}/pictures/order [put]
+func (h *handler) reorderStorePicturesHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var dto PictureOrderRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	pictures, err := h.service.ReorderStorePictures(r.Context(), storeID, userID, dto.ToDomain(), etag.IfMatch(r))
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewPicturesResponse(pictures, h.staticURL))
 
 	return nil
 }
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		If-Match	header		string	false	"ETag of the store the edit is based on"
+// @Success	200		{object}	PicturesResponse
+// @Failure	400,403,404,412,500	{object}	apperror.AppError
+// @Router		/me/stores/{id}/pictures/{picture_id} [delete]
+func (h *handler) deleteStorePictureHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	pictureID, err := parseIntParam(r, "picture_id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	pictures, err := h.service.DeleteStorePicture(r.Context(), storeID, pictureID, userID, etag.IfMatch(r))
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewPicturesResponse(pictures, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Success	200		{object}	SocialsResponse
+// @Failure	400,403,404,500	{object}	apperror.AppError
+// @Router		/me/stores/{id}/socials [get]
+func (h *handler) getStoreSocialsHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	socials, err := h.service.GetStoreSocials(r.Context(), storeID, userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, SocialsResponse{Socials: socials})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		request	body		SocialRequest	true	"request body"
+// @Param		If-Match	header		string	false	"ETag of the store the edit is based on"
+// @Success	200		{object}	SocialsResponse
+// @Failure	400,403,404,412,500	{object}	apperror.AppError
+// @Router		/me/stores/{id}/socials/{social_id} [put]
+func (h *handler) setStoreSocialHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	socialID, err := parseIntParam(r, "social_id")
+	if err != nil {
+		return err
+	}
+
+	var dto SocialRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	socials, err := h.service.SetStoreSocial(r.Context(), storeID, socialID, userID, dto.Url, etag.IfMatch(r))
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, SocialsResponse{Socials: socials})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		If-Match	header		string	false	"ETag of the store the edit is based on"
+// @Success	200		{object}	SocialsResponse
+// @Failure	400,403,404,412,500	{object}	apperror.AppError
+// @Router		/me/stores/{id}/socials/{social_id} [delete]
+func (h *handler) deleteStoreSocialHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	socialID, err := parseIntParam(r, "social_id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	socials, err := h.service.DeleteStoreSocial(r.Context(), storeID, socialID, userID, etag.IfMatch(r))
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, SocialsResponse{Socials: socials})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Success	200		{object}	LicensesResponse
+// @Failure	400,403,404,500	{object}	apperror.AppError
+// @Router		/me/stores/{id}/licenses [get]
+func (h *handler) getStoreLicensesHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	licenses, err := h.service.GetStoreLicenses(r.Context(), storeID, userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewLicensesResponse(licenses, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		request	body		LicenseRequest	true	"request body"
+// @Success	200		{object}	LicenseResponse
+// @Failure	400,403,404,500	{object}	apperror.AppError
+// @Router		/me/stores/{id}/licenses [post]
+func (h *handler) createStoreLicenseHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var dto LicenseRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	license, err := h.service.CreateStoreLicense(r.Context(), dto.ToDomain(storeID), userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewLicenseResponse(*license, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Param		request	body		LicenseRequest	true	"request body"
+// @Success	200		{object}	LicenseResponse
+// @Failure	400,403,404,500	{object}	apperror.AppError
+// @Router		/me/stores/{id}/licenses/{license_id} [put]
+func (h *handler) updateStoreLicenseHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	licenseID, err := parseIntParam(r, "license_id")
+	if err != nil {
+		return err
+	}
+
+	var dto LicenseRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	data := dto.ToDomain(storeID)
+	data.ID = licenseID
+
+	license, err := h.service.UpdateStoreLicense(r.Context(), data, userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewLicenseResponse(*license, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		market
+// @Success	200
+// @Failure	400,403,404,500	{object}	apperror.AppError
+// @Router		/me/stores/{id}/licenses/{license_id} [delete]
+func (h *handler) deleteStoreLicenseHandler(w http.ResponseWriter, r *http.Request) error {
+	storeID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	licenseID, err := parseIntParam(r, "license_id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	return h.service.DeleteStoreLicense(r.Context(), storeID, licenseID, userID)
+}
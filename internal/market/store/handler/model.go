@@ -19,6 +19,59 @@ type Social struct {
 	Url string            `json:"url" validate:"required,url"`
 }
 
+type OpeningHoursRequest struct {
+	Weekday  types.IntOrString `json:"weekday" validate:"min=0,max=6"`
+	OpensAt  string            `json:"opensAt" validate:"required,datetime=15:04"`
+	ClosesAt string            `json:"closesAt" validate:"required,datetime=15:04"`
+}
+
+type HoursExceptionRequest struct {
+	Date     string `json:"date" validate:"required,datetime=2006-01-02"`
+	IsClosed bool   `json:"isClosed"`
+	OpensAt  string `json:"opensAt" validate:"required_without=IsClosed,omitempty,datetime=15:04"`
+	ClosesAt string `json:"closesAt" validate:"required_without=IsClosed,omitempty,datetime=15:04"`
+	Note     string `json:"note" validate:"max=255"`
+}
+
+type ScheduleRequest struct {
+	OpeningHours []OpeningHoursRequest   `json:"openingHours" validate:"dive"`
+	Exceptions   []HoursExceptionRequest `json:"exceptions" validate:"dive"`
+}
+
+func (sr *ScheduleRequest) ToDomain() store.Schedule {
+	hours := make([]store.OpeningHours, len(sr.OpeningHours))
+	for i, h := range sr.OpeningHours {
+		hours[i] = store.OpeningHours{
+			Weekday:  int(h.Weekday),
+			OpensAt:  h.OpensAt,
+			ClosesAt: h.ClosesAt,
+		}
+	}
+
+	exceptions := make([]store.HoursException, len(sr.Exceptions))
+	for i, e := range sr.Exceptions {
+		exceptions[i] = store.HoursException{
+			Date:     e.Date,
+			IsClosed: e.IsClosed,
+			Note:     e.Note,
+		}
+		if !e.IsClosed {
+			exceptions[i].OpensAt = e.OpensAt
+			exceptions[i].ClosesAt = e.ClosesAt
+		}
+	}
+
+	return store.Schedule{
+		OpeningHours: hours,
+		Exceptions:   exceptions,
+	}
+}
+
+type LocationRequest struct {
+	Latitude  *float64 `json:"latitude" validate:"required,latitude"`
+	Longitude *float64 `json:"longitude" validate:"required,longitude"`
+}
+
 type StoreRequest struct {
 	BrandID           types.IntOrString `json:"brandId" validate:"required"`
 	Name              string            `json:"name" validate:"required"`
@@ -36,8 +89,11 @@ type StoreRequest struct {
 	DeliveryPrice     types.IntOrString `json:"deliveryPrice"`
 	MinimalOrderPrice types.IntOrString `json:"minimalOrderPrice"`
 	DeliveryDistance  types.IntOrString `json:"deliveryDistance"`
+	Latitude          *float64          `json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+	Longitude         *float64          `json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
 	Pictures          []string          `json:"pictures"`
 	Socials           []Social          `json:"socials" validate:"dive"`
+	Schedule          ScheduleRequest   `json:"schedule"`
 	IsPublished       *bool             `json:"isPublished" validate:"required"`
 }
 
@@ -72,8 +128,11 @@ func (sr *StoreRequest) ToDomain(userID int) *store.Store {
 		DeliveryPrice:     int(sr.DeliveryPrice),
 		MinimalOrderPrice: int(sr.MinimalOrderPrice),
 		DeliveryDistance:  int(sr.DeliveryDistance),
+		Latitude:          sr.Latitude,
+		Longitude:         sr.Longitude,
 		Pictures:          sr.Pictures,
 		Socials:           socials,
+		Schedule:          sr.Schedule.ToDomain(),
 		IsPublished:       *sr.IsPublished,
 	}
 }
@@ -102,3 +161,82 @@ func NewStoresSummaryResponse(elements []store.StoreSummary, staticURL string) S
 	}
 	return StoresSummaryResponse{Stores: elements}
 }
+
+type PictureRequest struct {
+	Url      string             `json:"url" validate:"required"`
+	Position *types.IntOrString `json:"position" validate:"omitempty,min=0"`
+}
+
+func (pr *PictureRequest) PositionValue() *int {
+	if pr.Position == nil {
+		return nil
+	}
+	position := int(*pr.Position)
+	return &position
+}
+
+type PictureOrderRequest struct {
+	PictureIDs []types.IntOrString `json:"pictureIds" validate:"required,dive,gt=0"`
+}
+
+func (por *PictureOrderRequest) ToDomain() []int {
+	res := make([]int, len(por.PictureIDs))
+	for i, id := range por.PictureIDs {
+		res[i] = int(id)
+	}
+	return res
+}
+
+type PicturesResponse struct {
+	Pictures []store.Picture `json:"pictures"`
+}
+
+func NewPicturesResponse(elements []store.Picture, staticURL string) PicturesResponse {
+	for i := range elements {
+		elements[i].Url = staticURL + "/" + elements[i].Url
+	}
+	return PicturesResponse{Pictures: elements}
+}
+
+type SocialRequest struct {
+	Url string `json:"url" validate:"required,url"`
+}
+
+type SocialsResponse struct {
+	Socials []social.EntitySocial `json:"socials"`
+}
+
+type LicenseRequest struct {
+	Number    string `json:"number" validate:"required,max=255"`
+	Url       string `json:"url" validate:"required"`
+	ExpiresAt string `json:"expiresAt" validate:"required,datetime=2006-01-02"`
+}
+
+func (lr *LicenseRequest) ToDomain(storeID int) store.License {
+	return store.License{
+		StoreID:   storeID,
+		Number:    lr.Number,
+		Url:       lr.Url,
+		ExpiresAt: lr.ExpiresAt,
+	}
+}
+
+type LicenseResponse struct {
+	License store.License `json:"license"`
+}
+
+func NewLicenseResponse(l store.License, staticURL string) LicenseResponse {
+	l.Url = staticURL + "/" + l.Url
+	return LicenseResponse{License: l}
+}
+
+type LicensesResponse struct {
+	Licenses []store.License `json:"licenses"`
+}
+
+func NewLicensesResponse(elements []store.License, staticURL string) LicensesResponse {
+	for i := range elements {
+		elements[i].Url = staticURL + "/" + elements[i].Url
+	}
+	return LicensesResponse{Licenses: elements}
+}
@@ -34,16 +34,66 @@ type Store struct {
 	DeliveryPrice     int                   `json:"deliveryPrice"`
 	MinimalOrderPrice int                   `json:"minimalOrderPrice"`
 	DeliveryDistance  int                   `json:"deliveryDistance"`
+	Latitude          *float64              `json:"latitude"`
+	Longitude         *float64              `json:"longitude"`
 	Pictures          []string              `json:"pictures"`
 	Socials           []social.EntitySocial `json:"socials"`
+	Schedule          Schedule              `json:"schedule"`
+	IsOpenNow         bool                  `json:"isOpenNow"`
+	NextOpenAt        *time.Time            `json:"nextOpenAt"`
+	FollowersCount    int                   `json:"followersCount"`
+	Rating            float64               `json:"rating"`
+	ReviewsCount      int                   `json:"reviewsCount"`
 	IsPublished       bool                  `json:"isPublished"`
+	Version           int                   `json:"version"`
 	CreatedAt         time.Time             `json:"createdAt"`
 	UpdatedAt         time.Time             `json:"updatedAt"`
 }
 
 type StoreSummary struct {
-	ID     int                `json:"id"`
-	Name   string             `json:"name"`
-	Banner string             `json:"banner"`
-	Brand  brand.BrandSummary `json:"brand"`
+	ID           int                `json:"id"`
+	Name         string             `json:"name"`
+	Banner       string             `json:"banner"`
+	Brand        brand.BrandSummary `json:"brand"`
+	Schedule     Schedule           `json:"-"`
+	IsOpenNow    bool               `json:"isOpenNow"`
+	NextOpenAt   *time.Time         `json:"nextOpenAt"`
+	Rating       float64            `json:"rating"`
+	ReviewsCount int                `json:"reviewsCount"`
+}
+
+type Filter struct {
+	OpenNow bool
+}
+
+type Picture struct {
+	ID       int    `json:"id"`
+	Url      string `json:"url"`
+	Position int    `json:"position"`
+}
+
+// License is a license document of the store, visible to its team only.
+type License struct {
+	ID         int        `json:"id"`
+	StoreID    int        `json:"-"`
+	Number     string     `json:"number"`
+	Url        string     `json:"url"`
+	ExpiresAt  string     `json:"expiresAt"`
+	IsExpired  bool       `json:"isExpired"`
+	RemindedAt *time.Time `json:"-"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+// LicenseReminder tells the owners of a business that a license
+// of its store expires soon.
+type LicenseReminder struct {
+	License    License
+	StoreName  string
+	Recipients []Recipient
+}
+
+type Recipient struct {
+	Email  string
+	Locale *string
 }
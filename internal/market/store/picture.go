@@ -0,0 +1,22 @@
+package store
+
+// IsPictureOrder reports whether pictureIDs lists every one of the pictures exactly once.
+func IsPictureOrder(pictures []Picture, pictureIDs []int) bool {
+	if len(pictures) != len(pictureIDs) {
+		return false
+	}
+
+	pending := make(map[int]bool, len(pictures))
+	for _, p := range pictures {
+		pending[p.ID] = true
+	}
+
+	for _, id := range pictureIDs {
+		if !pending[id] {
+			return false
+		}
+		delete(pending, id)
+	}
+
+	return true
+}
@@ -0,0 +1,31 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPictureOrder(t *testing.T) {
+	pictures := []Picture{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	tests := []struct {
+		name       string
+		pictureIDs []int
+		expected   bool
+	}{
+		{name: "same order", pictureIDs: []int{1, 2, 3}, expected: true},
+		{name: "new order", pictureIDs: []int{3, 1, 2}, expected: true},
+		{name: "missing picture", pictureIDs: []int{1, 2}, expected: false},
+		{name: "duplicated picture", pictureIDs: []int{1, 1, 2}, expected: false},
+		{name: "foreign picture", pictureIDs: []int{1, 2, 4}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, IsPictureOrder(pictures, tt.pictureIDs))
+		})
+	}
+
+	require.True(t, IsPictureOrder(nil, []int{}))
+}
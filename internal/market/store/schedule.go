@@ -0,0 +1,141 @@
+package store
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	TimeLayout = "15:04"
+	DateLayout = "2006-01-02"
+)
+
+// OpeningHours is a single opening interval within a weekday.
+// If ClosesAt is not after OpensAt the interval ends on the next day.
+type OpeningHours struct {
+	Weekday  int    `json:"weekday"`
+	OpensAt  string `json:"opensAt"`
+	ClosesAt string `json:"closesAt"`
+}
+
+// HoursException overrides the weekly hours for a specific date.
+// All exceptions of a date replace the weekly intervals of that date.
+type HoursException struct {
+	Date     string `json:"date"`
+	IsClosed bool   `json:"isClosed"`
+	OpensAt  string `json:"opensAt,omitempty"`
+	ClosesAt string `json:"closesAt,omitempty"`
+	Note     string `json:"note"`
+}
+
+type Schedule struct {
+	Timezone     string           `json:"timezone"`
+	OpeningHours []OpeningHours   `json:"openingHours"`
+	Exceptions   []HoursException `json:"exceptions"`
+}
+
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// Location returns the timezone of the store, UTC if it is not known.
+func (s Schedule) Location() *time.Location {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func atClock(date time.Time, clock string, loc *time.Location) (time.Time, bool) {
+	t, err := time.Parse(TimeLayout, clock)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, loc), true
+}
+
+func newInterval(date time.Time, opensAt, closesAt string, loc *time.Location) (interval, bool) {
+	start, ok := atClock(date, opensAt, loc)
+	if !ok {
+		return interval{}, false
+	}
+
+	end, ok := atClock(date, closesAt, loc)
+	if !ok {
+		return interval{}, false
+	}
+
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	return interval{start: start, end: end}, true
+}
+
+func (s Schedule) intervals(date time.Time, loc *time.Location) []interval {
+	day := date.Format(DateLayout)
+
+	var exceptions []HoursException
+	for _, e := range s.Exceptions {
+		if e.Date == day {
+			exceptions = append(exceptions, e)
+		}
+	}
+
+	res := make([]interval, 0)
+
+	if len(exceptions) > 0 {
+		for _, e := range exceptions {
+			if e.IsClosed {
+				return nil
+			}
+			if i, ok := newInterval(date, e.OpensAt, e.ClosesAt, loc); ok {
+				res = append(res, i)
+			}
+		}
+		return res
+	}
+
+	for _, h := range s.OpeningHours {
+		if h.Weekday != int(date.Weekday()) {
+			continue
+		}
+		if i, ok := newInterval(date, h.OpensAt, h.ClosesAt, loc); ok {
+			res = append(res, i)
+		}
+	}
+
+	return res
+}
+
+// OpenStatus reports whether the store is open at the given moment
+// and, if it is closed, when it opens next within the coming week.
+func (s Schedule) OpenStatus(now time.Time) (bool, *time.Time) {
+	loc := s.Location()
+	local := now.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	var starts []time.Time
+
+	// the previous day is included because its intervals may last past midnight
+	for offset := -1; offset <= 7; offset++ {
+		for _, i := range s.intervals(today.AddDate(0, 0, offset), loc) {
+			if !now.Before(i.start) && now.Before(i.end) {
+				return true, nil
+			}
+			if i.start.After(now) {
+				starts = append(starts, i.start)
+			}
+		}
+	}
+
+	if len(starts) == 0 {
+		return false, nil
+	}
+
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	return false, &starts[0]
+}
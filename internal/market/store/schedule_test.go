@@ -0,0 +1,123 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleOpenStatus(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// 2025-08-20 is Wednesday
+	weekly := []OpeningHours{
+		{Weekday: 3, OpensAt: "09:00", ClosesAt: "13:00"},
+		{Weekday: 3, OpensAt: "14:00", ClosesAt: "20:00"},
+		{Weekday: 4, OpensAt: "09:00", ClosesAt: "20:00"},
+		{Weekday: 5, OpensAt: "18:00", ClosesAt: "02:00"},
+	}
+
+	tests := []struct {
+		name           string
+		schedule       Schedule
+		now            time.Time
+		expectedIsOpen bool
+		expectedNext   *time.Time
+	}{
+		{
+			name:           "open within first interval",
+			schedule:       Schedule{Timezone: "UTC", OpeningHours: weekly},
+			now:            time.Date(2025, 8, 20, 10, 0, 0, 0, time.UTC),
+			expectedIsOpen: true,
+		},
+		{
+			name:           "closed during lunch break",
+			schedule:       Schedule{Timezone: "UTC", OpeningHours: weekly},
+			now:            time.Date(2025, 8, 20, 13, 30, 0, 0, time.UTC),
+			expectedIsOpen: false,
+			expectedNext:   ptrTime(time.Date(2025, 8, 20, 14, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:           "closed after hours opens next day",
+			schedule:       Schedule{Timezone: "UTC", OpeningHours: weekly},
+			now:            time.Date(2025, 8, 20, 21, 0, 0, 0, time.UTC),
+			expectedIsOpen: false,
+			expectedNext:   ptrTime(time.Date(2025, 8, 21, 9, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:           "open after midnight in overnight interval",
+			schedule:       Schedule{Timezone: "UTC", OpeningHours: weekly},
+			now:            time.Date(2025, 8, 23, 1, 0, 0, 0, time.UTC),
+			expectedIsOpen: true,
+		},
+		{
+			name:           "closing time is exclusive",
+			schedule:       Schedule{Timezone: "UTC", OpeningHours: weekly},
+			now:            time.Date(2025, 8, 20, 20, 0, 0, 0, time.UTC),
+			expectedIsOpen: false,
+			expectedNext:   ptrTime(time.Date(2025, 8, 21, 9, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "holiday closure skips the day",
+			schedule: Schedule{
+				Timezone:     "UTC",
+				OpeningHours: weekly,
+				Exceptions:   []HoursException{{Date: "2025-08-21", IsClosed: true}},
+			},
+			now:            time.Date(2025, 8, 20, 21, 0, 0, 0, time.UTC),
+			expectedIsOpen: false,
+			expectedNext:   ptrTime(time.Date(2025, 8, 22, 18, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "special hours replace weekly hours",
+			schedule: Schedule{
+				Timezone:     "UTC",
+				OpeningHours: weekly,
+				Exceptions:   []HoursException{{Date: "2025-08-20", OpensAt: "12:00", ClosesAt: "15:00"}},
+			},
+			now:            time.Date(2025, 8, 20, 10, 0, 0, 0, time.UTC),
+			expectedIsOpen: false,
+			expectedNext:   ptrTime(time.Date(2025, 8, 20, 12, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:           "store timezone is respected",
+			schedule:       Schedule{Timezone: "America/New_York", OpeningHours: weekly},
+			now:            time.Date(2025, 8, 20, 12, 0, 0, 0, time.UTC),
+			expectedIsOpen: false,
+			expectedNext:   ptrTime(time.Date(2025, 8, 20, 9, 0, 0, 0, newYork)),
+		},
+		{
+			name:           "no hours means closed without next opening",
+			schedule:       Schedule{Timezone: "UTC"},
+			now:            time.Date(2025, 8, 20, 12, 0, 0, 0, time.UTC),
+			expectedIsOpen: false,
+		},
+		{
+			name:           "unknown timezone falls back to UTC",
+			schedule:       Schedule{Timezone: "Mars/Olympus", OpeningHours: weekly},
+			now:            time.Date(2025, 8, 20, 10, 0, 0, 0, time.UTC),
+			expectedIsOpen: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isOpen, next := tt.schedule.OpenStatus(tt.now)
+
+			require.Equal(t, tt.expectedIsOpen, isOpen)
+
+			if tt.expectedNext == nil {
+				require.Nil(t, next)
+			} else {
+				require.NotNil(t, next)
+				require.True(t, tt.expectedNext.Equal(*next), "expected %s, got %s", tt.expectedNext, next)
+			}
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,102 @@
+package storeservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/i18n"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/store"
+	"go.uber.org/zap"
+)
+
+type LicenseRepository interface {
+	ClaimLicenseReminders(ctx context.Context, until string) ([]store.LicenseReminder, error)
+	ReleaseLicenseReminder(ctx context.Context, licenseID int) error
+}
+
+type MailManager interface {
+	SendMail(subject string, body string, to []string) error
+}
+
+type licenseReminder struct {
+	repository  LicenseRepository
+	mailManager MailManager
+	interval    time.Duration
+	leadTime    time.Duration
+	logger      *zap.Logger
+}
+
+// NewLicenseReminder mails business owners about store licenses
+// that expire within leadTime, checking every interval.
+func NewLicenseReminder(
+	repository LicenseRepository,
+	mailManager MailManager,
+	interval time.Duration,
+	leadTime time.Duration,
+	logger *zap.Logger,
+) *licenseReminder {
+	return &licenseReminder{
+		repository:  repository,
+		mailManager: mailManager,
+		interval:    interval,
+		leadTime:    leadTime,
+		logger:      logger,
+	}
+}
+
+// Run reminds until ctx is cancelled.
+func (lr *licenseReminder) Run(ctx context.Context) {
+	ticker := time.NewTicker(lr.interval)
+	defer ticker.Stop()
+
+	for {
+		lr.Remind(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Remind mails about every license that has not been reminded about yet.
+// A license is reminded about once per expiry date, failed mails are retried
+// on the next run.
+func (lr *licenseReminder) Remind(ctx context.Context) {
+	until := time.Now().Add(lr.leadTime).Format(store.DateLayout)
+
+	reminders, err := lr.repository.ClaimLicenseReminders(ctx, until)
+	if err != nil {
+		logging.FromContext(ctx, lr.logger).Error("unexpected error when claiming license reminders", zap.Error(err))
+		return
+	}
+
+	for _, reminder := range reminders {
+		isSent := true
+
+		for _, recipient := range reminder.Recipients {
+			subject, body := i18n.Mail(
+				i18n.Resolve(recipient.Locale, i18n.DefaultLocale),
+				"email.license_expiring",
+				reminder.StoreName,
+				reminder.License.Number,
+				reminder.License.ExpiresAt,
+			)
+
+			if err := lr.mailManager.SendMail(subject, body, []string{recipient.Email}); err != nil {
+				logging.FromContext(ctx, lr.logger).Error("unexpected error when sending license reminder", zap.Error(err))
+				isSent = false
+			}
+		}
+
+		if isSent {
+			continue
+		}
+
+		if err := lr.repository.ReleaseLicenseReminder(ctx, reminder.License.ID); err != nil {
+			logging.FromContext(ctx, lr.logger).Error("unexpected error when releasing license reminder", zap.Error(err))
+		}
+	}
+}
@@ -3,10 +3,18 @@ package storeservice
 import (
 	"context"
 	"errors"
+	"slices"
+	"time"
 
 	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/audit"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/market/social"
 	"github.com/xw1nchester/kushfinds-backend/internal/market/store"
 	storedb "github.com/xw1nchester/kushfinds-backend/internal/market/store/db"
+	"github.com/xw1nchester/kushfinds-backend/internal/team"
+	"github.com/xw1nchester/kushfinds-backend/internal/tracing"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor"
 	"go.uber.org/zap"
 )
 
@@ -16,15 +24,45 @@ type Repository interface {
 
 	CreateStore(ctx context.Context, data store.Store) (*store.Store, error)
 	GetUserStores(ctx context.Context, userID int) ([]store.StoreSummary, error)
+	GetPublishedStores(ctx context.Context) ([]store.StoreSummary, error)
+	GetFavoriteStores(ctx context.Context, userID int) ([]store.StoreSummary, error)
 	GetStoreByID(ctx context.Context, id int) (*store.Store, error)
+	UpdateStoreSchedule(ctx context.Context, storeID int, schedule store.Schedule, versions []int) error
+	SetStoreLocation(ctx context.Context, storeID int, latitude, longitude float64) error
+	BumpStoreVersion(ctx context.Context, storeID int, versions []int) error
+
+	GetStorePictures(ctx context.Context, storeID int) ([]store.Picture, error)
+	AddStorePicture(ctx context.Context, storeID int, url string, position *int) error
+	DeleteStorePicture(ctx context.Context, storeID, pictureID int) error
+	ReorderStorePictures(ctx context.Context, storeID int, pictureIDs []int) error
+
+	GetStoreSocials(ctx context.Context, storeID int) ([]social.EntitySocial, error)
+	SetStoreSocial(ctx context.Context, storeID, socialID int, url string) error
+	DeleteStoreSocial(ctx context.Context, storeID, socialID int) error
+
+	GetStoreLicenses(ctx context.Context, storeID int) ([]store.License, error)
+	CreateStoreLicense(ctx context.Context, data store.License) (*store.License, error)
+	UpdateStoreLicense(ctx context.Context, data store.License) (*store.License, error)
+	DeleteStoreLicense(ctx context.Context, storeID, licenseID int) error
 }
 
+var (
+	ErrInvalidSchedule      = apperror.NewAppError("store.invalid_schedule", "opening hours are not valid")
+	ErrPictureAlreadyExists = apperror.NewConflictError("store.picture_already_exists", "the store already has this picture")
+	ErrInvalidPictureOrder  = apperror.NewAppError("store.invalid_picture_order", "the order should list every store picture once")
+)
+
 type UserService interface {
 	CheckBusinessProfileExists(ctx context.Context, userID int, requireVerified bool) error
 }
 
 type BrandService interface {
-	CheckBrandExists(ctx context.Context, brandID, userID int) error
+	CheckBrandPermission(ctx context.Context, brandID, userID int, permission team.Permission) (int, error)
+	CheckBrandAvailableInState(ctx context.Context, brandID, stateID int) error
+}
+
+type TeamService interface {
+	CheckStorePermission(ctx context.Context, businessID, storeID, userID int, permission team.Permission) error
 }
 
 type RegionService interface {
@@ -35,12 +73,24 @@ type SocialService interface {
 	CheckSocialsExist(ctx context.Context, IDs []int) error
 }
 
+type Notifier interface {
+	NotifyNewStore(ctx context.Context, s store.Store)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, record audit.Record) error
+}
+
 type service struct {
 	repository    Repository
 	userService   UserService
 	brandService  BrandService
+	teamService   TeamService
 	regionService RegionService
 	socialService SocialService
+	notifier      Notifier
+	txManager     transactor.Manager
+	auditRecorder AuditRecorder
 	logger        *zap.Logger
 }
 
@@ -48,16 +98,24 @@ func New(
 	repository Repository,
 	userService UserService,
 	brandService BrandService,
+	teamService TeamService,
 	regionService RegionService,
 	socialService SocialService,
+	notifier Notifier,
+	txManager transactor.Manager,
+	auditRecorder AuditRecorder,
 	logger *zap.Logger,
 ) *service {
 	return &service{
 		repository:    repository,
 		userService:   userService,
 		brandService:  brandService,
+		teamService:   teamService,
 		regionService: regionService,
 		socialService: socialService,
+		notifier:      notifier,
+		txManager:     txManager,
+		auditRecorder: auditRecorder,
 		logger:        logger,
 	}
 }
@@ -65,7 +123,7 @@ func New(
 func (s *service) GetAllStoreTypes(ctx context.Context) ([]store.StoreType, error) {
 	storeTypes, err := s.repository.GetAllStoreTypes(ctx)
 	if err != nil {
-		s.logger.Error("unexpected error when fetching all store types", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching all store types", zap.Error(err))
 
 		return nil, err
 	}
@@ -73,19 +131,76 @@ func (s *service) GetAllStoreTypes(ctx context.Context) ([]store.StoreType, erro
 	return storeTypes, nil
 }
 
+func validateSchedule(schedule store.Schedule) error {
+	for _, h := range schedule.OpeningHours {
+		if h.Weekday < 0 || h.Weekday > 6 || h.OpensAt == h.ClosesAt {
+			return ErrInvalidSchedule
+		}
+		if _, err := time.Parse(store.TimeLayout, h.OpensAt); err != nil {
+			return ErrInvalidSchedule
+		}
+		if _, err := time.Parse(store.TimeLayout, h.ClosesAt); err != nil {
+			return ErrInvalidSchedule
+		}
+	}
+
+	for _, e := range schedule.Exceptions {
+		if _, err := time.Parse(store.DateLayout, e.Date); err != nil {
+			return ErrInvalidSchedule
+		}
+		if e.IsClosed {
+			continue
+		}
+		if e.OpensAt == e.ClosesAt {
+			return ErrInvalidSchedule
+		}
+		if _, err := time.Parse(store.TimeLayout, e.OpensAt); err != nil {
+			return ErrInvalidSchedule
+		}
+		if _, err := time.Parse(store.TimeLayout, e.ClosesAt); err != nil {
+			return ErrInvalidSchedule
+		}
+	}
+
+	return nil
+}
+
+func setOpenStatus(stores []store.StoreSummary, filter store.Filter) []store.StoreSummary {
+	now := time.Now()
+
+	res := make([]store.StoreSummary, 0, len(stores))
+	for _, s := range stores {
+		s.IsOpenNow, s.NextOpenAt = s.Schedule.OpenStatus(now)
+		if filter.OpenNow && !s.IsOpenNow {
+			continue
+		}
+		res = append(res, s)
+	}
+
+	return res
+}
+
 func (s *service) validateStoreData(ctx context.Context, data store.Store) error {
+	ctx, span := tracing.Start(ctx, "storeservice.validateStoreData")
+	defer span.End()
+
+	if err := validateSchedule(data.Schedule); err != nil {
+		return err
+	}
+
+	businessID, err := s.brandService.CheckBrandPermission(ctx, data.Brand.ID, data.UserID, team.PermissionManageStores)
+	if err != nil {
+		return err
+	}
+
 	if err := s.userService.CheckBusinessProfileExists(
 		ctx,
-		data.UserID,
+		businessID,
 		data.IsPublished,
 	); err != nil {
 		return err
 	}
 
-	if err := s.brandService.CheckBrandExists(ctx, data.Brand.ID, data.UserID); err != nil {
-		return err
-	}
-
 	if err := s.regionService.CheckLocationExists(
 		ctx,
 		data.Region.ID,
@@ -95,6 +210,10 @@ func (s *service) validateStoreData(ctx context.Context, data store.Store) error
 		return err
 	}
 
+	if err := s.brandService.CheckBrandAvailableInState(ctx, data.Brand.ID, data.State.ID); err != nil {
+		return err
+	}
+
 	socialIDs := make([]int, len(data.Socials))
 	for i, s := range data.Socials {
 		socialIDs[i] = s.ID
@@ -108,7 +227,7 @@ func (s *service) validateStoreData(ctx context.Context, data store.Store) error
 		if errors.Is(err, storedb.ErrStoreTypeNotFound) {
 			return apperror.ErrNotFound
 		}
-		s.logger.Error("unexpected error when fetching store type by id", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching store type by id", zap.Error(err))
 		return err
 	}
 
@@ -116,45 +235,472 @@ func (s *service) validateStoreData(ctx context.Context, data store.Store) error
 }
 
 func (s *service) CreateStore(ctx context.Context, data store.Store) (*store.Store, error) {
+	ctx, span := tracing.Start(ctx, "storeservice.CreateStore")
+	defer span.End()
+
 	if err := s.validateStoreData(ctx, data); err != nil {
 		return nil, err
 	}
 
-	createdStore, err := s.repository.CreateStore(ctx, data)
-	if err != nil {
-		s.logger.Error("unexpected error when creating store", zap.Error(err))
+	var createdStore *store.Store
+
+	if err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		createdStore, err = s.repository.CreateStore(ctx, data)
+		if err != nil {
+			logging.FromContext(ctx, s.logger).Error("unexpected error when creating store", zap.Error(err))
+			return err
+		}
+
+		if !createdStore.IsPublished {
+			return nil
+		}
+
+		return s.auditRecorder.Record(ctx, audit.Record{
+			ActorID:    data.UserID,
+			Action:     audit.ActionStorePublish,
+			EntityType: audit.EntityStore,
+			EntityID:   createdStore.ID,
+			After:      createdStore,
+		})
+	}); err != nil {
 		return nil, err
 	}
 
+	if createdStore.IsPublished {
+		s.notifier.NotifyNewStore(ctx, *createdStore)
+	}
+
 	return createdStore, nil
 }
 
-func (s *service) GetUserStores(ctx context.Context, userID int) ([]store.StoreSummary, error) {
-	brands, err := s.repository.GetUserStores(ctx, userID)
+func (s *service) GetUserStores(ctx context.Context, userID int, filter store.Filter) ([]store.StoreSummary, error) {
+	stores, err := s.repository.GetUserStores(ctx, userID)
 	if err != nil {
-		s.logger.Error("unexpected error when fetching user stores", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching user stores", zap.Error(err))
 
 		return nil, err
 	}
 
-	return brands, nil
+	return setOpenStatus(stores, filter), nil
 }
 
-func (s *service) GetUserStore(ctx context.Context, storeID, userID int) (*store.Store, error) {
+func (s *service) GetStores(ctx context.Context, filter store.Filter) ([]store.StoreSummary, error) {
+	ctx, span := tracing.Start(ctx, "storeservice.GetStores")
+	defer span.End()
+
+	stores, err := s.repository.GetPublishedStores(ctx)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching published stores", zap.Error(err))
+
+		return nil, err
+	}
+
+	return setOpenStatus(stores, filter), nil
+}
+
+func (s *service) GetFavoriteStores(ctx context.Context, userID int) ([]store.StoreSummary, error) {
+	stores, err := s.repository.GetFavoriteStores(ctx, userID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching favorite stores", zap.Error(err))
+
+		return nil, err
+	}
+
+	return setOpenStatus(stores, store.Filter{}), nil
+}
+
+func (s *service) getStoreByID(ctx context.Context, storeID int) (*store.Store, error) {
 	store, err := s.repository.GetStoreByID(ctx, storeID)
 	if err != nil {
 		if errors.Is(err, storedb.ErrStoreNotFound) {
 			return nil, apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when fetching store by id", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching store by id", zap.Error(err))
 
 		return nil, err
 	}
 
-	if store.UserID != userID {
+	store.IsOpenNow, store.NextOpenAt = store.Schedule.OpenStatus(time.Now())
+
+	return store, nil
+}
+
+// authorizeStore returns the store if the user has the permission
+// in the team of its business.
+func (s *service) authorizeStore(
+	ctx context.Context,
+	storeID int,
+	userID int,
+	permission team.Permission,
+) (*store.Store, error) {
+	store, err := s.getStoreByID(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.teamService.CheckStorePermission(ctx, store.UserID, storeID, userID, permission); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// GetUserStore returns the store if the user may operate it,
+// i.e. manage its menu, orders and reviews.
+func (s *service) GetUserStore(ctx context.Context, storeID, userID int) (*store.Store, error) {
+	return s.authorizeStore(ctx, storeID, userID, team.PermissionOperateStore)
+}
+
+func (s *service) GetStore(ctx context.Context, storeID int) (*store.Store, error) {
+	ctx, span := tracing.Start(ctx, "storeservice.GetStore")
+	defer span.End()
+
+	store, err := s.getStoreByID(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !store.IsPublished {
 		return nil, apperror.ErrNotFound
 	}
 
 	return store, nil
 }
+
+// UpdateStoreSchedule replaces the opening hours only if the store version
+// is one of versions, nil versions skip the check.
+func (s *service) UpdateStoreSchedule(
+	ctx context.Context,
+	storeID int,
+	userID int,
+	schedule store.Schedule,
+	versions []int,
+) (*store.Store, error) {
+	existingStore, err := s.authorizeStore(ctx, storeID, userID, team.PermissionManageStores)
+	if err != nil {
+		return nil, err
+	}
+
+	if versions != nil && !slices.Contains(versions, existingStore.Version) {
+		return nil, apperror.ErrPreconditionFailed
+	}
+
+	if err := validateSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.UpdateStoreSchedule(ctx, storeID, schedule, versions); err != nil {
+		if errors.Is(err, storedb.ErrStoreVersionMismatch) {
+			return nil, apperror.ErrPreconditionFailed
+		}
+		if errors.Is(err, storedb.ErrStoreNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when updating store schedule", zap.Error(err))
+		return nil, err
+	}
+
+	return s.getStoreByID(ctx, storeID)
+}
+
+// UpdateStoreLocation sets the coordinates delivery distances are measured from.
+func (s *service) UpdateStoreLocation(
+	ctx context.Context,
+	storeID int,
+	userID int,
+	latitude float64,
+	longitude float64,
+	versions []int,
+) (*store.Store, error) {
+	if err := s.editStore(ctx, storeID, userID, versions, func(ctx context.Context) error {
+		if err := s.repository.SetStoreLocation(ctx, storeID, latitude, longitude); err != nil {
+			logging.FromContext(ctx, s.logger).Error("unexpected error when setting store location", zap.Error(err))
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.getStoreByID(ctx, storeID)
+}
+
+// editStore runs edit of the store related entities in a transaction
+// that bumps the store version, versions work as in UpdateStoreSchedule.
+func (s *service) editStore(
+	ctx context.Context,
+	storeID int,
+	userID int,
+	versions []int,
+	edit func(ctx context.Context) error,
+) error {
+	existingStore, err := s.authorizeStore(ctx, storeID, userID, team.PermissionManageStores)
+	if err != nil {
+		return err
+	}
+
+	if versions != nil && !slices.Contains(versions, existingStore.Version) {
+		return apperror.ErrPreconditionFailed
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.BumpStoreVersion(ctx, storeID, versions); err != nil {
+			if errors.Is(err, storedb.ErrStoreVersionMismatch) {
+				return apperror.ErrPreconditionFailed
+			}
+			if errors.Is(err, storedb.ErrStoreNotFound) {
+				return apperror.ErrNotFound
+			}
+
+			logging.FromContext(ctx, s.logger).Error("unexpected error when bumping store version", zap.Error(err))
+			return err
+		}
+
+		return edit(ctx)
+	})
+}
+
+func (s *service) getStorePictures(ctx context.Context, storeID int) ([]store.Picture, error) {
+	pictures, err := s.repository.GetStorePictures(ctx, storeID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching store pictures", zap.Error(err))
+
+		return nil, err
+	}
+
+	return pictures, nil
+}
+
+func (s *service) GetStorePictures(ctx context.Context, storeID, userID int) ([]store.Picture, error) {
+	if _, err := s.GetUserStore(ctx, storeID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.getStorePictures(ctx, storeID)
+}
+
+// AddStorePicture inserts the picture at the position, nil position appends it.
+func (s *service) AddStorePicture(
+	ctx context.Context,
+	storeID int,
+	userID int,
+	url string,
+	position *int,
+	versions []int,
+) ([]store.Picture, error) {
+	if err := s.editStore(ctx, storeID, userID, versions, func(ctx context.Context) error {
+		if err := s.repository.AddStorePicture(ctx, storeID, url, position); err != nil {
+			if errors.Is(err, storedb.ErrPictureAlreadyExists) {
+				return ErrPictureAlreadyExists
+			}
+
+			logging.FromContext(ctx, s.logger).Error("unexpected error when adding store picture", zap.Error(err))
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.getStorePictures(ctx, storeID)
+}
+
+func (s *service) DeleteStorePicture(
+	ctx context.Context,
+	storeID int,
+	pictureID int,
+	userID int,
+	versions []int,
+) ([]store.Picture, error) {
+	if err := s.editStore(ctx, storeID, userID, versions, func(ctx context.Context) error {
+		if err := s.repository.DeleteStorePicture(ctx, storeID, pictureID); err != nil {
+			if errors.Is(err, storedb.ErrPictureNotFound) {
+				return apperror.ErrNotFound
+			}
+
+			logging.FromContext(ctx, s.logger).Error("unexpected error when deleting store picture", zap.Error(err))
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.getStorePictures(ctx, storeID)
+}
+
+// ReorderStorePictures moves the pictures to the positions of their IDs in pictureIDs.
+func (s *service) ReorderStorePictures(
+	ctx context.Context,
+	storeID int,
+	userID int,
+	pictureIDs []int,
+	versions []int,
+) ([]store.Picture, error) {
+	if err := s.editStore(ctx, storeID, userID, versions, func(ctx context.Context) error {
+		pictures, err := s.getStorePictures(ctx, storeID)
+		if err != nil {
+			return err
+		}
+
+		if !store.IsPictureOrder(pictures, pictureIDs) {
+			return ErrInvalidPictureOrder
+		}
+
+		if err := s.repository.ReorderStorePictures(ctx, storeID, pictureIDs); err != nil {
+			logging.FromContext(ctx, s.logger).Error("unexpected error when reordering store pictures", zap.Error(err))
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.getStorePictures(ctx, storeID)
+}
+
+func (s *service) getStoreSocials(ctx context.Context, storeID int) ([]social.EntitySocial, error) {
+	socials, err := s.repository.GetStoreSocials(ctx, storeID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching store socials", zap.Error(err))
+
+		return nil, err
+	}
+
+	return socials, nil
+}
+
+func (s *service) GetStoreSocials(ctx context.Context, storeID, userID int) ([]social.EntitySocial, error) {
+	if _, err := s.GetUserStore(ctx, storeID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.getStoreSocials(ctx, storeID)
+}
+
+// SetStoreSocial adds the social link to the store or replaces its url.
+func (s *service) SetStoreSocial(
+	ctx context.Context,
+	storeID int,
+	socialID int,
+	userID int,
+	url string,
+	versions []int,
+) ([]social.EntitySocial, error) {
+	if err := s.socialService.CheckSocialsExist(ctx, []int{socialID}); err != nil {
+		return nil, err
+	}
+
+	if err := s.editStore(ctx, storeID, userID, versions, func(ctx context.Context) error {
+		if err := s.repository.SetStoreSocial(ctx, storeID, socialID, url); err != nil {
+			logging.FromContext(ctx, s.logger).Error("unexpected error when setting store social", zap.Error(err))
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.getStoreSocials(ctx, storeID)
+}
+
+func (s *service) DeleteStoreSocial(
+	ctx context.Context,
+	storeID int,
+	socialID int,
+	userID int,
+	versions []int,
+) ([]social.EntitySocial, error) {
+	if err := s.editStore(ctx, storeID, userID, versions, func(ctx context.Context) error {
+		if err := s.repository.DeleteStoreSocial(ctx, storeID, socialID); err != nil {
+			if errors.Is(err, storedb.ErrSocialNotFound) {
+				return apperror.ErrNotFound
+			}
+
+			logging.FromContext(ctx, s.logger).Error("unexpected error when deleting store social", zap.Error(err))
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.getStoreSocials(ctx, storeID)
+}
+
+func (s *service) GetStoreLicenses(ctx context.Context, storeID, userID int) ([]store.License, error) {
+	if _, err := s.GetUserStore(ctx, storeID, userID); err != nil {
+		return nil, err
+	}
+
+	licenses, err := s.repository.GetStoreLicenses(ctx, storeID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching store licenses", zap.Error(err))
+
+		return nil, err
+	}
+
+	return licenses, nil
+}
+
+func (s *service) CreateStoreLicense(ctx context.Context, data store.License, userID int) (*store.License, error) {
+	if _, err := s.authorizeStore(ctx, data.StoreID, userID, team.PermissionManageStores); err != nil {
+		return nil, err
+	}
+
+	license, err := s.repository.CreateStoreLicense(ctx, data)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when creating store license", zap.Error(err))
+
+		return nil, err
+	}
+
+	return license, nil
+}
+
+func (s *service) UpdateStoreLicense(ctx context.Context, data store.License, userID int) (*store.License, error) {
+	if _, err := s.authorizeStore(ctx, data.StoreID, userID, team.PermissionManageStores); err != nil {
+		return nil, err
+	}
+
+	license, err := s.repository.UpdateStoreLicense(ctx, data)
+	if err != nil {
+		if errors.Is(err, storedb.ErrLicenseNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when updating store license", zap.Error(err))
+
+		return nil, err
+	}
+
+	return license, nil
+}
+
+func (s *service) DeleteStoreLicense(ctx context.Context, storeID, licenseID, userID int) error {
+	if _, err := s.authorizeStore(ctx, storeID, userID, team.PermissionManageStores); err != nil {
+		return err
+	}
+
+	if err := s.repository.DeleteStoreLicense(ctx, storeID, licenseID); err != nil {
+		if errors.Is(err, storedb.ErrLicenseNotFound) {
+			return apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when deleting store license", zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
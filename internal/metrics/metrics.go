@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "kushfinds"
+
+const (
+	AuthEventLogin       = "login"
+	AuthEventLoginFailed = "login_failed"
+	AuthEventRefresh     = "refresh"
+)
+
+// Registry holds the application collectors. Each registry creates its own,
+// so several of them, as in tests, do not clash.
+type Registry struct {
+	registry *prometheus.Registry
+
+	httpRequestDuration *prometheus.HistogramVec
+	mailSent            *prometheus.CounterVec
+	uploadSize          prometheus.Histogram
+	uploadFailures      prometheus.Counter
+	authEvents          *prometheus.CounterVec
+}
+
+// NewRegistry collects the application metrics together with runtime
+// and connection pool statistics.
+func NewRegistry(pool *pgxpool.Pool) *Registry {
+	reg := newRegistry()
+
+	reg.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		newPoolCollector(pool),
+	)
+
+	return reg
+}
+
+// newRegistry registers only the application collectors.
+func newRegistry() *Registry {
+	reg := &Registry{
+		registry: prometheus.NewRegistry(),
+
+		httpRequestDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Subsystem: "http",
+				Name:      "request_duration_seconds",
+				Help:      "Duration of HTTP requests by route pattern and status.",
+				Buckets:   prometheus.DefBuckets,
+			},
+			[]string{"method", "route", "status"},
+		),
+
+		mailSent: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: "mail",
+				Name:      "sent_total",
+				Help:      "Number of emails sent, by result.",
+			},
+			[]string{"result"},
+		),
+
+		uploadSize: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Subsystem: "upload",
+				Name:      "size_bytes",
+				Help:      "Size of successfully uploaded files.",
+				Buckets:   prometheus.ExponentialBuckets(16*1024, 4, 8),
+			},
+		),
+
+		uploadFailures: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: "upload",
+				Name:      "failures_total",
+				Help:      "Number of failed uploads.",
+			},
+		),
+
+		authEvents: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: "auth",
+				Name:      "events_total",
+				Help:      "Number of authentication events: logins, failed logins and token refreshes.",
+			},
+			[]string{"event"},
+		),
+	}
+
+	reg.registry.MustRegister(
+		reg.httpRequestDuration,
+		reg.mailSent,
+		reg.uploadSize,
+		reg.uploadFailures,
+		reg.authEvents,
+	)
+
+	return reg
+}
+
+func resultLabel(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+func (reg *Registry) ObserveMail(err error) {
+	reg.mailSent.WithLabelValues(resultLabel(err)).Inc()
+}
+
+func (reg *Registry) ObserveUpload(size int64, err error) {
+	if err != nil {
+		reg.uploadFailures.Inc()
+		return
+	}
+	reg.uploadSize.Observe(float64(size))
+}
+
+func (reg *Registry) IncAuthEvent(event string) {
+	reg.authEvents.WithLabelValues(event).Inc()
+}
+
+func (reg *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(reg.registry, promhttp.HandlerOpts{Registry: reg.registry})
+}
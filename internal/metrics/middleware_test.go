@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func requestCount(t *testing.T, registry *prometheus.Registry, labels map[string]string) uint64 {
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != "kushfinds_http_request_duration_seconds" {
+			continue
+		}
+
+	metrics:
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if labels[label.GetName()] != label.GetValue() {
+					continue metrics
+				}
+			}
+			return metric.GetHistogram().GetSampleCount()
+		}
+	}
+
+	return 0
+}
+
+func TestMiddleware(t *testing.T) {
+	reg := newRegistry()
+
+	router := chi.NewRouter()
+	router.Use(reg.Middleware)
+	router.Route("/api", func(r chi.Router) {
+		r.Get("/brands/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("{}"))
+		})
+		r.Delete("/brands/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		})
+	})
+
+	for _, path := range []string{"/api/brands/1", "/api/brands/2", "/api/unknown", "/unknown"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/api/brands/1", nil))
+
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		expected uint64
+	}{
+		{
+			name:     "Route pattern instead of path",
+			labels:   map[string]string{"method": "GET", "route": "/api/brands/{id}", "status": "200"},
+			expected: 2,
+		},
+		{
+			name:     "Status from handler",
+			labels:   map[string]string{"method": "DELETE", "route": "/api/brands/{id}", "status": "403"},
+			expected: 1,
+		},
+		{
+			name:     "Unknown path inside a mounted router",
+			labels:   map[string]string{"method": "GET", "route": "/api/*", "status": "404"},
+			expected: 1,
+		},
+		{
+			name:     "Unmatched route",
+			labels:   map[string]string{"method": "GET", "route": "unmatched", "status": "404"},
+			expected: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, requestCount(t, reg.registry, tt.labels))
+		})
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	assert.NotPanics(t, func() {
+		NewRegistry(nil)
+		NewRegistry(nil)
+	})
+}
@@ -0,0 +1,8 @@
+package referencedb
+
+import "errors"
+
+var (
+	ErrEntryNotFound = errors.New("reference entry not found")
+	ErrNameTaken     = errors.New("reference entry name is already taken")
+)
@@ -0,0 +1,247 @@
+package referencedb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/reference"
+	"go.uber.org/zap"
+)
+
+const uniqueViolationCode = "23505"
+
+type repository struct {
+	client *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func New(client *pgxpool.Pool, logger *zap.Logger) *repository {
+	return &repository{
+		client: client,
+		logger: logger,
+	}
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+func selectColumns(kind reference.Kind) string {
+	icon := "NULL::text"
+	if kind.HasIcon {
+		icon = "icon"
+	}
+
+	parent := "NULL::int"
+	if kind.ParentColumn != "" {
+		parent = kind.ParentColumn
+	}
+
+	return fmt.Sprintf("id, name, %s, %s, position, is_active", icon, parent)
+}
+
+func scanEntry(row pgx.Row) (*reference.Entry, error) {
+	var entry reference.Entry
+
+	if err := row.Scan(
+		&entry.ID,
+		&entry.Name,
+		&entry.Icon,
+		&entry.ParentID,
+		&entry.Position,
+		&entry.IsActive,
+	); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (r *repository) GetEntries(ctx context.Context, kind reference.Kind, parentID *int) ([]reference.Entry, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s", selectColumns(kind), kind.Table)
+
+	args := []any{}
+
+	if kind.ParentColumn != "" && parentID != nil {
+		query += fmt.Sprintf(" WHERE %s=$1", kind.ParentColumn)
+		args = append(args, *parentID)
+	}
+
+	query += " ORDER BY position, id"
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]reference.Entry, 0)
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+
+		entries = append(entries, *entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return entries, nil
+}
+
+func (r *repository) GetEntry(ctx context.Context, kind reference.Kind, id int) (*reference.Entry, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id=$1", selectColumns(kind), kind.Table)
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	entry, err := scanEntry(r.client.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEntryNotFound
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// CreateEntry appends the entry to the end of its list, or of its parent's
+// list for nested kinds.
+func (r *repository) CreateEntry(ctx context.Context, kind reference.Kind, data reference.Entry) (*reference.Entry, error) {
+	columns := "name"
+	values := "$1"
+	scope := "true"
+	args := []any{data.Name}
+
+	if kind.HasIcon {
+		args = append(args, data.Icon)
+		columns += ", icon"
+		values += fmt.Sprintf(", $%d", len(args))
+	}
+
+	if kind.ParentColumn != "" {
+		args = append(args, data.ParentID)
+		columns += ", " + kind.ParentColumn
+		values += fmt.Sprintf(", $%d", len(args))
+		scope = fmt.Sprintf("%s=$%d", kind.ParentColumn, len(args))
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s (%[2]s, position)
+		VALUES (%[3]s, (SELECT COALESCE(MAX(position), 0) + 1 FROM %[1]s WHERE %[4]s))
+		RETURNING %[5]s
+	`, kind.Table, columns, values, scope, selectColumns(kind))
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	entry, err := scanEntry(r.client.QueryRow(ctx, query, args...))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrNameTaken
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (r *repository) UpdateEntry(ctx context.Context, kind reference.Kind, data reference.Entry) (*reference.Entry, error) {
+	set := "name=$2"
+	args := []any{data.ID, data.Name}
+
+	if kind.HasIcon && data.Icon != nil {
+		args = append(args, *data.Icon)
+		set += fmt.Sprintf(", icon=$%d", len(args))
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE %s SET %s WHERE id=$1 RETURNING %s",
+		kind.Table,
+		set,
+		selectColumns(kind),
+	)
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	entry, err := scanEntry(r.client.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEntryNotFound
+		}
+		if isUniqueViolation(err) {
+			return nil, ErrNameTaken
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// ReorderEntries assigns positions following the order of ids. Entries that
+// are not listed keep their positions. For nested kinds only the entries of
+// the given parent are reordered.
+func (r *repository) ReorderEntries(ctx context.Context, kind reference.Kind, parentID *int, ids []int) error {
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := fmt.Sprintf(`
+		UPDATE %s t
+		SET position=o.ord
+		FROM unnest($1::int[]) WITH ORDINALITY AS o(id, ord)
+		WHERE t.id=o.id
+	`, kind.Table)
+
+	args := []any{ids}
+
+	if kind.ParentColumn != "" {
+		query += fmt.Sprintf(" AND t.%s=$2", kind.ParentColumn)
+		args = append(args, parentID)
+	}
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() != int64(len(ids)) {
+		return ErrEntryNotFound
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *repository) SetEntryActive(ctx context.Context, kind reference.Kind, id int, isActive bool) (*reference.Entry, error) {
+	query := fmt.Sprintf(
+		"UPDATE %s SET is_active=$2 WHERE id=$1 RETURNING %s",
+		kind.Table,
+		selectColumns(kind),
+	)
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	entry, err := scanEntry(r.client.QueryRow(ctx, query, id, isActive))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEntryNotFound
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,249 @@
+package referencehandler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	jwtmiddleware "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt/middleware"
+	"github.com/xw1nchester/kushfinds-backend/internal/handlers"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/reference"
+	"go.uber.org/zap"
+)
+
+var validate = apperror.NewValidator()
+
+type Service interface {
+	GetEntries(ctx context.Context, adminID int, kind string, parentID *int) ([]reference.Entry, error)
+	CreateEntry(ctx context.Context, adminID int, kind string, data reference.Entry) (*reference.Entry, error)
+	UpdateEntry(ctx context.Context, adminID int, kind string, data reference.Entry) (*reference.Entry, error)
+	ReorderEntries(ctx context.Context, adminID int, kind string, parentID *int, ids []int) ([]reference.Entry, error)
+	SetEntryActive(ctx context.Context, adminID int, kind string, id int, isActive bool) (*reference.Entry, error)
+}
+
+type handler struct {
+	service        Service
+	authMiddleware func(http.Handler) http.Handler
+	logger         *zap.Logger
+}
+
+func New(
+	service Service,
+	authMiddleware func(http.Handler) http.Handler,
+	logger *zap.Logger,
+) handlers.Handler {
+	return &handler{
+		service:        service,
+		authMiddleware: authMiddleware,
+		logger:         logger,
+	}
+}
+
+func (h *handler) Register(router chi.Router) {
+	router.Route("/admin/reference/{kind}", func(referenceRouter chi.Router) {
+		referenceRouter.Use(h.authMiddleware)
+		referenceRouter.Get("/", apperror.Middleware(h.getEntriesHandler))
+		referenceRouter.Post("/", apperror.Middleware(h.createEntryHandler))
+		referenceRouter.Put("/order", apperror.Middleware(h.reorderEntriesHandler))
+		referenceRouter.Patch("/{id}", apperror.Middleware(h.updateEntryHandler))
+		referenceRouter.Delete("/{id}", apperror.Middleware(h.deactivateEntryHandler))
+		referenceRouter.Post("/{id}/restore", apperror.Middleware(h.restoreEntryHandler))
+	})
+}
+
+func parseID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id <= 0 {
+		return 0, apperror.NewFieldError("id", "field.positive_integer")
+	}
+	return id, nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		admin reference
+// @Param		kind		path		string	true	"countries, states, regions, industries, market-sections, socials, store-types"
+// @Param		parentId	query		int		false	"parent entry id for states and regions"
+// @Success	200			{object}	EntriesResponse
+// @Failure	400,500		{object}	apperror.AppError
+// @Router		/admin/reference/{kind} [get]
+func (h *handler) getEntriesHandler(w http.ResponseWriter, r *http.Request) error {
+	var parentID *int
+	if value := r.URL.Query().Get("parentId"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			return apperror.NewFieldError("parentId", "field.positive_integer")
+		}
+		parentID = &id
+	}
+
+	adminID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	entries, err := h.service.GetEntries(r.Context(), adminID, chi.URLParam(r, "kind"), parentID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, EntriesResponse{Entries: entries})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		admin reference
+// @Param		kind	path		string				true	"reference kind"
+// @Param		request	body		CreateEntryRequest	true	"request body"
+// @Success	200		{object}	EntryResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/admin/reference/{kind} [post]
+func (h *handler) createEntryHandler(w http.ResponseWriter, r *http.Request) error {
+	var dto CreateEntryRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	data := reference.Entry{Name: dto.Name, Icon: dto.Icon}
+	if dto.ParentID != nil {
+		parentID := int(*dto.ParentID)
+		data.ParentID = &parentID
+	}
+
+	adminID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	entry, err := h.service.CreateEntry(r.Context(), adminID, chi.URLParam(r, "kind"), data)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, EntryResponse{Entry: *entry})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		admin reference
+// @Param		kind	path		string				true	"reference kind"
+// @Param		request	body		UpdateEntryRequest	true	"request body"
+// @Success	200		{object}	EntryResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/admin/reference/{kind}/{id} [patch]
+func (h *handler) updateEntryHandler(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseID(r)
+	if err != nil {
+		return err
+	}
+
+	var dto UpdateEntryRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	adminID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	entry, err := h.service.UpdateEntry(
+		r.Context(),
+		adminID,
+		chi.URLParam(r, "kind"),
+		reference.Entry{ID: id, Name: dto.Name, Icon: dto.Icon},
+	)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, EntryResponse{Entry: *entry})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		admin reference
+// @Param		kind	path		string			true	"reference kind"
+// @Param		request	body		ReorderRequest	true	"ids in the new order, parentId is required for states and regions"
+// @Success	200		{object}	EntriesResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/admin/reference/{kind}/order [put]
+func (h *handler) reorderEntriesHandler(w http.ResponseWriter, r *http.Request) error {
+	var dto ReorderRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	ids := make([]int, len(dto.IDs))
+	for i, id := range dto.IDs {
+		ids[i] = int(id)
+	}
+
+	adminID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	var parentID *int
+	if dto.ParentID != nil {
+		id := int(*dto.ParentID)
+		parentID = &id
+	}
+
+	entries, err := h.service.ReorderEntries(r.Context(), adminID, chi.URLParam(r, "kind"), parentID, ids)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, EntriesResponse{Entries: entries})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		admin reference
+// @Param		kind	path		string	true	"reference kind"
+// @Success	200		{object}	EntryResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/admin/reference/{kind}/{id} [delete]
+func (h *handler) deactivateEntryHandler(w http.ResponseWriter, r *http.Request) error {
+	return h.setEntryActive(w, r, false)
+}
+
+// @Security	ApiKeyAuth
+// @Tags		admin reference
+// @Param		kind	path		string	true	"reference kind"
+// @Success	200		{object}	EntryResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/admin/reference/{kind}/{id}/restore [post]
+func (h *handler) restoreEntryHandler(w http.ResponseWriter, r *http.Request) error {
+	return h.setEntryActive(w, r, true)
+}
+
+func (h *handler) setEntryActive(w http.ResponseWriter, r *http.Request, isActive bool) error {
+	id, err := parseID(r)
+	if err != nil {
+		return err
+	}
+
+	adminID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	entry, err := h.service.SetEntryActive(r.Context(), adminID, chi.URLParam(r, "kind"), id, isActive)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, EntryResponse{Entry: *entry})
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package referencehandler
+
+import (
+	"github.com/xw1nchester/kushfinds-backend/internal/reference"
+	"github.com/xw1nchester/kushfinds-backend/pkg/types"
+)
+
+type CreateEntryRequest struct {
+	Name     string             `json:"name" validate:"required,max=100"`
+	Icon     *string            `json:"icon" validate:"omitempty,max=500"`
+	ParentID *types.IntOrString `json:"parentId" validate:"omitempty,gt=0"`
+}
+
+type UpdateEntryRequest struct {
+	Name string  `json:"name" validate:"required,max=100"`
+	Icon *string `json:"icon" validate:"omitempty,max=500"`
+}
+
+type ReorderRequest struct {
+	IDs      []types.IntOrString `json:"ids" validate:"required,min=1,unique,dive,gt=0"`
+	ParentID *types.IntOrString  `json:"parentId" validate:"omitempty,gt=0"`
+}
+
+type EntryResponse struct {
+	Entry reference.Entry `json:"entry"`
+}
+
+type EntriesResponse struct {
+	Entries []reference.Entry `json:"entries"`
+}
@@ -0,0 +1,39 @@
+package reference
+
+// Kind describes a reference table managed through the admin API.
+// Table and column names are fixed here and never taken from user input.
+type Kind struct {
+	Table        string
+	ParentKind   string
+	ParentColumn string
+	HasIcon      bool
+}
+
+const (
+	KindCountries      = "countries"
+	KindStates         = "states"
+	KindRegions        = "regions"
+	KindIndustries     = "industries"
+	KindMarketSections = "market-sections"
+	KindSocials        = "socials"
+	KindStoreTypes     = "store-types"
+)
+
+var Kinds = map[string]Kind{
+	KindCountries:      {Table: "countries"},
+	KindStates:         {Table: "states", ParentKind: KindCountries, ParentColumn: "country_id"},
+	KindRegions:        {Table: "regions", ParentKind: KindStates, ParentColumn: "state_id"},
+	KindIndustries:     {Table: "business_industries"},
+	KindMarketSections: {Table: "market_sections"},
+	KindSocials:        {Table: "socials", HasIcon: true},
+	KindStoreTypes:     {Table: "store_types"},
+}
+
+type Entry struct {
+	ID       int     `json:"id"`
+	Name     string  `json:"name"`
+	Icon     *string `json:"icon,omitempty"`
+	ParentID *int    `json:"parentId,omitempty"`
+	Position int     `json:"position"`
+	IsActive bool    `json:"isActive"`
+}
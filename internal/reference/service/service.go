@@ -0,0 +1,231 @@
+package referenceservice
+
+import (
+	"context"
+	"errors"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/reference"
+	referencedb "github.com/xw1nchester/kushfinds-backend/internal/reference/db"
+	"github.com/xw1nchester/kushfinds-backend/internal/user"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrNameTaken           = apperror.NewConflictError("reference.name_taken", "an entry with this name already exists")
+	ErrIconRequired        = apperror.NewAppError("reference.icon_required", "icon is required")
+	ErrParentRequired      = apperror.NewAppError("reference.parent_required", "parentId is required")
+	ErrParentNotFound      = apperror.NewAppError("reference.parent_not_found", "parent entry not found")
+	ErrUnknownEntryInOrder = apperror.NewAppError("reference.unknown_entry_in_order", "order contains unknown entries")
+)
+
+type Repository interface {
+	GetEntries(ctx context.Context, kind reference.Kind, parentID *int) ([]reference.Entry, error)
+	GetEntry(ctx context.Context, kind reference.Kind, id int) (*reference.Entry, error)
+	CreateEntry(ctx context.Context, kind reference.Kind, data reference.Entry) (*reference.Entry, error)
+	UpdateEntry(ctx context.Context, kind reference.Kind, data reference.Entry) (*reference.Entry, error)
+	ReorderEntries(ctx context.Context, kind reference.Kind, parentID *int, ids []int) error
+	SetEntryActive(ctx context.Context, kind reference.Kind, id int, isActive bool) (*reference.Entry, error)
+}
+
+type UserService interface {
+	GetByID(ctx context.Context, id int) (*user.User, error)
+}
+
+type service struct {
+	repository  Repository
+	userService UserService
+	logger      *zap.Logger
+}
+
+func New(repository Repository, userService UserService, logger *zap.Logger) *service {
+	return &service{
+		repository:  repository,
+		userService: userService,
+		logger:      logger,
+	}
+}
+
+func (s *service) checkAdmin(ctx context.Context, userID int) error {
+	existingUser, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !existingUser.IsAdmin {
+		return apperror.ErrForbidden
+	}
+
+	return nil
+}
+
+// resolveKind checks admin rights and looks up the reference table.
+// Unknown kinds are reported as not found, like unknown routes.
+func (s *service) resolveKind(ctx context.Context, adminID int, kindName string) (reference.Kind, error) {
+	if err := s.checkAdmin(ctx, adminID); err != nil {
+		return reference.Kind{}, err
+	}
+
+	kind, ok := reference.Kinds[kindName]
+	if !ok {
+		return reference.Kind{}, apperror.ErrNotFound
+	}
+
+	return kind, nil
+}
+
+func (s *service) GetEntries(
+	ctx context.Context,
+	adminID int,
+	kindName string,
+	parentID *int,
+) ([]reference.Entry, error) {
+	kind, err := s.resolveKind(ctx, adminID, kindName)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.repository.GetEntries(ctx, kind, parentID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching reference entries", zap.Error(err))
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (s *service) CreateEntry(
+	ctx context.Context,
+	adminID int,
+	kindName string,
+	data reference.Entry,
+) (*reference.Entry, error) {
+	kind, err := s.resolveKind(ctx, adminID, kindName)
+	if err != nil {
+		return nil, err
+	}
+
+	if kind.HasIcon && (data.Icon == nil || *data.Icon == "") {
+		return nil, ErrIconRequired
+	}
+
+	if kind.ParentKind != "" {
+		if data.ParentID == nil {
+			return nil, ErrParentRequired
+		}
+
+		if _, err := s.repository.GetEntry(ctx, reference.Kinds[kind.ParentKind], *data.ParentID); err != nil {
+			if errors.Is(err, referencedb.ErrEntryNotFound) {
+				return nil, ErrParentNotFound
+			}
+
+			logging.FromContext(ctx, s.logger).Error("unexpected error when fetching parent reference entry", zap.Error(err))
+
+			return nil, err
+		}
+	}
+
+	createdEntry, err := s.repository.CreateEntry(ctx, kind, data)
+	if err != nil {
+		if errors.Is(err, referencedb.ErrNameTaken) {
+			return nil, ErrNameTaken
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when creating reference entry", zap.Error(err))
+
+		return nil, err
+	}
+
+	return createdEntry, nil
+}
+
+func (s *service) UpdateEntry(
+	ctx context.Context,
+	adminID int,
+	kindName string,
+	data reference.Entry,
+) (*reference.Entry, error) {
+	kind, err := s.resolveKind(ctx, adminID, kindName)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedEntry, err := s.repository.UpdateEntry(ctx, kind, data)
+	if err != nil {
+		if errors.Is(err, referencedb.ErrEntryNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+		if errors.Is(err, referencedb.ErrNameTaken) {
+			return nil, ErrNameTaken
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when updating reference entry", zap.Error(err))
+
+		return nil, err
+	}
+
+	return updatedEntry, nil
+}
+
+func (s *service) ReorderEntries(
+	ctx context.Context,
+	adminID int,
+	kindName string,
+	parentID *int,
+	ids []int,
+) ([]reference.Entry, error) {
+	kind, err := s.resolveKind(ctx, adminID, kindName)
+	if err != nil {
+		return nil, err
+	}
+
+	// nested entries are ordered within their parent only
+	if kind.ParentKind != "" && parentID == nil {
+		return nil, ErrParentRequired
+	}
+
+	if err := s.repository.ReorderEntries(ctx, kind, parentID, ids); err != nil {
+		if errors.Is(err, referencedb.ErrEntryNotFound) {
+			return nil, ErrUnknownEntryInOrder
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when reordering reference entries", zap.Error(err))
+
+		return nil, err
+	}
+
+	entries, err := s.repository.GetEntries(ctx, kind, parentID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching reference entries", zap.Error(err))
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (s *service) SetEntryActive(
+	ctx context.Context,
+	adminID int,
+	kindName string,
+	id int,
+	isActive bool,
+) (*reference.Entry, error) {
+	kind, err := s.resolveKind(ctx, adminID, kindName)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.repository.SetEntryActive(ctx, kind, id, isActive)
+	if err != nil {
+		if errors.Is(err, referencedb.ErrEntryNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when changing reference entry activity", zap.Error(err))
+
+		return nil, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,157 @@
+package seeder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Fixture is the content of a single fixture file. Every entity is
+// identified by its natural key (name or email), never by id, so the
+// same file can be applied to any database any number of times.
+type Fixture struct {
+	Countries      []Country `yaml:"countries" json:"countries"`
+	Industries     []string  `yaml:"industries" json:"industries"`
+	MarketSections []string  `yaml:"marketSections" json:"marketSections"`
+	Socials        []Social  `yaml:"socials" json:"socials"`
+	StoreTypes     []string  `yaml:"storeTypes" json:"storeTypes"`
+	Users          []User    `yaml:"users" json:"users"`
+	Brands         []Brand   `yaml:"brands" json:"brands"`
+	Stores         []Store   `yaml:"stores" json:"stores"`
+}
+
+type Country struct {
+	Name   string  `yaml:"name" json:"name"`
+	States []State `yaml:"states" json:"states"`
+}
+
+type State struct {
+	Name       string   `yaml:"name" json:"name"`
+	MinimumAge int      `yaml:"minimumAge" json:"minimumAge"`
+	Timezone   string   `yaml:"timezone" json:"timezone"`
+	Regions    []string `yaml:"regions" json:"regions"`
+}
+
+type Social struct {
+	Name string `yaml:"name" json:"name"`
+	Icon string `yaml:"icon" json:"icon"`
+}
+
+type BusinessProfile struct {
+	Industry    string `yaml:"industry" json:"industry"`
+	Name        string `yaml:"name" json:"name"`
+	Country     string `yaml:"country" json:"country"`
+	State       string `yaml:"state" json:"state"`
+	Region      string `yaml:"region" json:"region"`
+	Email       string `yaml:"email" json:"email"`
+	PhoneNumber string `yaml:"phoneNumber" json:"phoneNumber"`
+}
+
+type User struct {
+	Email           string           `yaml:"email" json:"email"`
+	Username        string           `yaml:"username" json:"username"`
+	FirstName       string           `yaml:"firstName" json:"firstName"`
+	LastName        string           `yaml:"lastName" json:"lastName"`
+	Password        string           `yaml:"password" json:"password"`
+	IsAdmin         bool             `yaml:"isAdmin" json:"isAdmin"`
+	BusinessProfile *BusinessProfile `yaml:"businessProfile" json:"businessProfile"`
+}
+
+type Brand struct {
+	Owner         string   `yaml:"owner" json:"owner"`
+	Name          string   `yaml:"name" json:"name"`
+	Country       string   `yaml:"country" json:"country"`
+	MarketSection string   `yaml:"marketSection" json:"marketSection"`
+	States        []string `yaml:"states" json:"states"`
+	Email         string   `yaml:"email" json:"email"`
+	PhoneNumber   string   `yaml:"phoneNumber" json:"phoneNumber"`
+	Logo          string   `yaml:"logo" json:"logo"`
+	Banner        string   `yaml:"banner" json:"banner"`
+	IsPublished   bool     `yaml:"isPublished" json:"isPublished"`
+}
+
+type Store struct {
+	Brand             string   `yaml:"brand" json:"brand"`
+	Name              string   `yaml:"name" json:"name"`
+	Description       string   `yaml:"description" json:"description"`
+	Banner            string   `yaml:"banner" json:"banner"`
+	StoreType         string   `yaml:"storeType" json:"storeType"`
+	Country           string   `yaml:"country" json:"country"`
+	State             string   `yaml:"state" json:"state"`
+	Region            string   `yaml:"region" json:"region"`
+	Street            string   `yaml:"street" json:"street"`
+	House             string   `yaml:"house" json:"house"`
+	PostCode          string   `yaml:"postCode" json:"postCode"`
+	Email             string   `yaml:"email" json:"email"`
+	PhoneNumber       string   `yaml:"phoneNumber" json:"phoneNumber"`
+	DeliveryPrice     int      `yaml:"deliveryPrice" json:"deliveryPrice"`
+	MinimalOrderPrice int      `yaml:"minimalOrderPrice" json:"minimalOrderPrice"`
+	DeliveryDistance  int      `yaml:"deliveryDistance" json:"deliveryDistance"`
+	Latitude          *float64 `yaml:"latitude" json:"latitude"`
+	Longitude         *float64 `yaml:"longitude" json:"longitude"`
+	IsPublished       bool     `yaml:"isPublished" json:"isPublished"`
+}
+
+// File is a parsed fixture file. Files are versioned by their name prefix
+// (001_locations.yml, 002_dictionaries.json, ...) and applied in that order.
+type File struct {
+	Name    string
+	Fixture Fixture
+}
+
+func LoadDir(dir string) ([]File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures dir: %w", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".yml", ".yaml", ".json":
+			names = append(names, e.Name())
+		}
+	}
+	slices.Sort(names)
+
+	files := make([]File, 0, len(names))
+	for _, name := range names {
+		fixture, err := LoadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, File{Name: name, Fixture: *fixture})
+	}
+
+	return files, nil
+}
+
+func LoadFile(path string) (*Fixture, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture %s: %w", path, err)
+	}
+
+	var fixture Fixture
+
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		err = json.Unmarshal(content, &fixture)
+	} else {
+		err = yaml.Unmarshal(content, &fixture)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+
+	return &fixture, nil
+}
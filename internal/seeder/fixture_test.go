@@ -0,0 +1,67 @@
+package seeder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"002_socials.json":  `{"socials": [{"name": "Instagram", "icon": "instagram.svg"}]}`,
+		"001_countries.yml": "countries:\n  - name: Canada\n    states:\n      - name: Ontario\n        minimumAge: 19\n",
+		"README.md":         "not a fixture",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	loaded, err := LoadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+
+	require.Equal(t, "001_countries.yml", loaded[0].Name)
+	require.Equal(t, []Country{
+		{Name: "Canada", States: []State{{Name: "Ontario", MinimumAge: 19}}},
+	}, loaded[0].Fixture.Countries)
+
+	require.Equal(t, "002_socials.json", loaded[1].Name)
+	require.Equal(t, []Social{{Name: "Instagram", Icon: "instagram.svg"}}, loaded[1].Fixture.Socials)
+}
+
+func TestLoadDirInvalidFixture(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "001_broken.json"), []byte("{"), 0o600))
+
+	_, err := LoadDir(dir)
+	require.Error(t, err)
+}
+
+func TestRepositoryFixtures(t *testing.T) {
+	reference, err := LoadDir(filepath.Join("..", "..", "fixtures", "reference"))
+	require.NoError(t, err)
+
+	states := map[string]int{}
+	for _, f := range reference {
+		for _, c := range f.Fixture.Countries {
+			states[c.Name] += len(c.States)
+
+			for _, st := range c.States {
+				_, err := time.LoadLocation(st.Timezone)
+				require.NoError(t, err, st.Name)
+				require.NotEmpty(t, st.Timezone, st.Name)
+			}
+		}
+	}
+
+	require.Equal(t, 51, states["United States"])
+	require.Equal(t, 13, states["Canada"])
+
+	_, err = LoadDir(filepath.Join("..", "..", "fixtures", "demo"))
+	require.NoError(t, err)
+}
@@ -0,0 +1,545 @@
+package seeder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	ProfileReference = "reference"
+	ProfileDemo      = "demo"
+)
+
+// profiles lists fixture directories loaded by each profile, in order.
+// Demo content refers to reference data, so it always loads it first.
+var profiles = map[string][]string{
+	ProfileReference: {"reference"},
+	ProfileDemo:      {"reference", "demo"},
+}
+
+var ErrUnknownProfile = errors.New("unknown seed profile")
+
+type Seeder struct {
+	client *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func New(client *pgxpool.Pool, logger *zap.Logger) *Seeder {
+	return &Seeder{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Run applies every fixture file of the profile. Each file is applied in
+// its own transaction, so a broken file does not leave partial data behind.
+func (s *Seeder) Run(ctx context.Context, fixturesDir, profile string) error {
+	dirs, ok := profiles[profile]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownProfile, profile)
+	}
+
+	for _, dir := range dirs {
+		files, err := LoadDir(filepath.Join(fixturesDir, dir))
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			s.logger.Info("applying fixture", zap.String("dir", dir), zap.String("file", file.Name))
+
+			if err := pgx.BeginFunc(ctx, s.client, func(tx pgx.Tx) error {
+				return apply(ctx, tx, file.Fixture)
+			}); err != nil {
+				return fmt.Errorf("failed to apply fixture %s/%s: %w", dir, file.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func apply(ctx context.Context, tx pgx.Tx, f Fixture) error {
+	for i, c := range f.Countries {
+		if err := upsertCountry(ctx, tx, c, i+1); err != nil {
+			return err
+		}
+	}
+
+	dictionaries := []struct {
+		table string
+		names []string
+	}{
+		{"business_industries", f.Industries},
+		{"market_sections", f.MarketSections},
+		{"store_types", f.StoreTypes},
+	}
+	for _, d := range dictionaries {
+		for i, name := range d.names {
+			if _, err := upsertNamed(ctx, tx, d.table, name, i+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, social := range f.Socials {
+		if err := upsertSocial(ctx, tx, social, i+1); err != nil {
+			return err
+		}
+	}
+
+	for _, u := range f.Users {
+		if err := upsertUser(ctx, tx, u); err != nil {
+			return err
+		}
+	}
+
+	for _, b := range f.Brands {
+		if err := upsertBrand(ctx, tx, b); err != nil {
+			return err
+		}
+	}
+
+	for _, st := range f.Stores {
+		if err := upsertStore(ctx, tx, st); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lookupID resolves a natural key to an id. table and column are always
+// constants from this package.
+func lookupID(ctx context.Context, tx pgx.Tx, table, column string, value any) (int, error) {
+	var id int
+
+	err := tx.QueryRow(
+		ctx,
+		fmt.Sprintf("SELECT id FROM %s WHERE %s=$1 ORDER BY id LIMIT 1", table, column),
+		value,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%s with %s %v not found", table, column, value)
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// upsertNamed inserts a dictionary entry keeping the position of an
+// existing one, so reordering done by admins survives reseeding.
+func upsertNamed(ctx context.Context, tx pgx.Tx, table, name string, position int) (int, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (name, position)
+		VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET name=EXCLUDED.name
+		RETURNING id
+	`, table)
+
+	var id int
+	if err := tx.QueryRow(ctx, query, name, position).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to upsert %s %q: %w", table, name, err)
+	}
+
+	return id, nil
+}
+
+func upsertCountry(ctx context.Context, tx pgx.Tx, c Country, position int) error {
+	countryID, err := upsertNamed(ctx, tx, "countries", c.Name, position)
+	if err != nil {
+		return err
+	}
+
+	for i, st := range c.States {
+		query := `
+			INSERT INTO states (name, country_id, minimum_age, timezone, position)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (name) DO UPDATE
+			SET country_id=EXCLUDED.country_id, minimum_age=EXCLUDED.minimum_age, timezone=EXCLUDED.timezone
+			RETURNING id
+		`
+
+		minimumAge := st.MinimumAge
+		if minimumAge == 0 {
+			minimumAge = 21
+		}
+
+		timezone := st.Timezone
+		if timezone == "" {
+			timezone = "UTC"
+		}
+
+		var stateID int
+		if err := tx.QueryRow(ctx, query, st.Name, countryID, minimumAge, timezone, i+1).Scan(&stateID); err != nil {
+			return fmt.Errorf("failed to upsert state %q: %w", st.Name, err)
+		}
+
+		for j, region := range st.Regions {
+			query := `
+				INSERT INTO regions (name, state_id, position)
+				VALUES ($1, $2, $3)
+				ON CONFLICT (name) DO UPDATE SET state_id=EXCLUDED.state_id
+			`
+
+			if _, err := tx.Exec(ctx, query, region, stateID, j+1); err != nil {
+				return fmt.Errorf("failed to upsert region %q: %w", region, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func upsertSocial(ctx context.Context, tx pgx.Tx, social Social, position int) error {
+	// socials.name has no unique constraint, so the upsert is done by hand
+	tag, err := tx.Exec(ctx, `UPDATE socials SET icon=$2 WHERE name=$1`, social.Name, social.Icon)
+	if err != nil {
+		return fmt.Errorf("failed to update social %q: %w", social.Name, err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	if _, err := tx.Exec(
+		ctx,
+		`INSERT INTO socials (name, icon, position) VALUES ($1, $2, $3)`,
+		social.Name,
+		social.Icon,
+		position,
+	); err != nil {
+		return fmt.Errorf("failed to insert social %q: %w", social.Name, err)
+	}
+
+	return nil
+}
+
+// upsertUser creates a verified user. The password of an existing user is
+// left untouched, everything else is synced with the fixture.
+func upsertUser(ctx context.Context, tx pgx.Tx, u User) error {
+	var userID int
+
+	err := tx.QueryRow(ctx, `SELECT id FROM users WHERE email=$1`, u.Email).Scan(&userID)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return err
+		}
+
+		query := `
+			INSERT INTO users (email, username, first_name, last_name, password_hash, is_verified, is_admin)
+			VALUES ($1, $2, $3, $4, $5, true, $6)
+			RETURNING id
+		`
+
+		if err := tx.QueryRow(
+			ctx,
+			query,
+			u.Email,
+			u.Username,
+			u.FirstName,
+			u.LastName,
+			string(passwordHash),
+			u.IsAdmin,
+		).Scan(&userID); err != nil {
+			return fmt.Errorf("failed to insert user %q: %w", u.Email, err)
+		}
+	case err != nil:
+		return err
+	default:
+		query := `
+			UPDATE users
+			SET username=$2, first_name=$3, last_name=$4, is_verified=true, is_admin=$5
+			WHERE id=$1
+		`
+
+		if _, err := tx.Exec(ctx, query, userID, u.Username, u.FirstName, u.LastName, u.IsAdmin); err != nil {
+			return fmt.Errorf("failed to update user %q: %w", u.Email, err)
+		}
+	}
+
+	if u.BusinessProfile == nil {
+		return nil
+	}
+
+	return upsertBusinessProfile(ctx, tx, userID, *u.BusinessProfile)
+}
+
+func upsertBusinessProfile(ctx context.Context, tx pgx.Tx, userID int, bp BusinessProfile) error {
+	industryID, err := lookupID(ctx, tx, "business_industries", "name", bp.Industry)
+	if err != nil {
+		return err
+	}
+
+	countryID, err := lookupID(ctx, tx, "countries", "name", bp.Country)
+	if err != nil {
+		return err
+	}
+
+	stateID, err := lookupID(ctx, tx, "states", "name", bp.State)
+	if err != nil {
+		return err
+	}
+
+	regionID, err := lookupID(ctx, tx, "regions", "name", bp.Region)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO business_profiles (user_id, business_industry_id, business_name, country_id, state_id, region_id, email, phone_number, status, submitted_at, reviewed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 'approved', NOW(), NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET
+			business_industry_id=EXCLUDED.business_industry_id,
+			business_name=EXCLUDED.business_name,
+			country_id=EXCLUDED.country_id,
+			state_id=EXCLUDED.state_id,
+			region_id=EXCLUDED.region_id,
+			email=EXCLUDED.email,
+			phone_number=EXCLUDED.phone_number,
+			status=EXCLUDED.status
+	`
+
+	if _, err := tx.Exec(
+		ctx,
+		query,
+		userID,
+		industryID,
+		bp.Name,
+		countryID,
+		stateID,
+		regionID,
+		bp.Email,
+		bp.PhoneNumber,
+	); err != nil {
+		return fmt.Errorf("failed to upsert business profile %q: %w", bp.Name, err)
+	}
+
+	ownerQuery := `
+		INSERT INTO team_members (business_id, user_id, role)
+		VALUES ($1, $1, 'owner')
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := tx.Exec(ctx, ownerQuery, userID); err != nil {
+		return fmt.Errorf("failed to add business profile %q owner: %w", bp.Name, err)
+	}
+
+	return nil
+}
+
+// upsertBrand syncs a brand by name. Published demo brands are stored as
+// already approved so that they are visible without going through moderation.
+func upsertBrand(ctx context.Context, tx pgx.Tx, b Brand) error {
+	userID, err := lookupID(ctx, tx, "users", "email", b.Owner)
+	if err != nil {
+		return err
+	}
+
+	countryID, err := lookupID(ctx, tx, "countries", "name", b.Country)
+	if err != nil {
+		return err
+	}
+
+	marketSectionID, err := lookupID(ctx, tx, "market_sections", "name", b.MarketSection)
+	if err != nil {
+		return err
+	}
+
+	moderationStatus := "draft"
+	if b.IsPublished {
+		moderationStatus = "approved"
+	}
+
+	var brandID int
+
+	err = tx.QueryRow(ctx, `SELECT id FROM brands WHERE name=$1`, b.Name).Scan(&brandID)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		query := `
+			INSERT INTO brands (user_id, country_id, market_section_id, name, email, phone_number, logo, banner, is_published, moderation_status)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			RETURNING id
+		`
+
+		if err := tx.QueryRow(
+			ctx,
+			query,
+			userID,
+			countryID,
+			marketSectionID,
+			b.Name,
+			b.Email,
+			b.PhoneNumber,
+			b.Logo,
+			b.Banner,
+			b.IsPublished,
+			moderationStatus,
+		).Scan(&brandID); err != nil {
+			return fmt.Errorf("failed to insert brand %q: %w", b.Name, err)
+		}
+	case err != nil:
+		return err
+	default:
+		query := `
+			UPDATE brands
+			SET
+				user_id=$2,
+				country_id=$3,
+				market_section_id=$4,
+				email=$5,
+				phone_number=$6,
+				logo=$7,
+				banner=$8,
+				is_published=$9,
+				moderation_status=$10,
+				updated_at=NOW()
+			WHERE id=$1
+		`
+
+		if _, err := tx.Exec(
+			ctx,
+			query,
+			brandID,
+			userID,
+			countryID,
+			marketSectionID,
+			b.Email,
+			b.PhoneNumber,
+			b.Logo,
+			b.Banner,
+			b.IsPublished,
+			moderationStatus,
+		); err != nil {
+			return fmt.Errorf("failed to update brand %q: %w", b.Name, err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM brands_states WHERE brand_id=$1`, brandID); err != nil {
+		return err
+	}
+
+	for _, stateName := range b.States {
+		stateID, err := lookupID(ctx, tx, "states", "name", stateName)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(
+			ctx,
+			`INSERT INTO brands_states (brand_id, state_id) VALUES ($1, $2)`,
+			brandID,
+			stateID,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// upsertStore syncs a store by its brand and name.
+func upsertStore(ctx context.Context, tx pgx.Tx, st Store) error {
+	brandID, err := lookupID(ctx, tx, "brands", "name", st.Brand)
+	if err != nil {
+		return err
+	}
+
+	storeTypeID, err := lookupID(ctx, tx, "store_types", "name", st.StoreType)
+	if err != nil {
+		return err
+	}
+
+	countryID, err := lookupID(ctx, tx, "countries", "name", st.Country)
+	if err != nil {
+		return err
+	}
+
+	stateID, err := lookupID(ctx, tx, "states", "name", st.State)
+	if err != nil {
+		return err
+	}
+
+	regionID, err := lookupID(ctx, tx, "regions", "name", st.Region)
+	if err != nil {
+		return err
+	}
+
+	args := []any{
+		brandID,
+		st.Name,
+		st.Banner,
+		st.Description,
+		countryID,
+		stateID,
+		regionID,
+		st.Street,
+		st.House,
+		st.PostCode,
+		st.Email,
+		st.PhoneNumber,
+		storeTypeID,
+		st.DeliveryPrice,
+		st.MinimalOrderPrice,
+		st.DeliveryDistance,
+		st.Latitude,
+		st.Longitude,
+		st.IsPublished,
+	}
+
+	query := `
+		UPDATE stores
+		SET
+			banner=$3,
+			description=$4,
+			country_id=$5,
+			state_id=$6,
+			region_id=$7,
+			street=$8,
+			house=$9,
+			post_code=$10,
+			email=$11,
+			phone_number=$12,
+			store_type_id=$13,
+			delivery_price=$14,
+			minimal_order_price=$15,
+			delivery_distance=$16,
+			latitude=$17,
+			longitude=$18,
+			is_published=$19,
+			updated_at=NOW()
+		WHERE brand_id=$1 AND name=$2
+	`
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update store %q: %w", st.Name, err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	query = `
+		INSERT INTO stores (brand_id, name, banner, description, country_id, state_id, region_id, street, house, post_code, email, phone_number, store_type_id, delivery_price, minimal_order_price, delivery_distance, latitude, longitude, is_published)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+	`
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to insert store %q: %w", st.Name, err)
+	}
+
+	return nil
+}
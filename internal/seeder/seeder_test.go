@@ -0,0 +1,85 @@
+package seeder
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+type query struct {
+	sql  string
+	args []any
+}
+
+// recordingTx answers every query with a fresh id, matches no rows on
+// update and remembers the statements, so apply can run without a database.
+type recordingTx struct {
+	pgx.Tx
+	queries []query
+	lastID  int
+}
+
+func (tx *recordingTx) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	tx.queries = append(tx.queries, query{sql: sql, args: args})
+	if strings.HasPrefix(strings.TrimSpace(sql), "UPDATE") {
+		return pgconn.NewCommandTag("UPDATE 0"), nil
+	}
+	return pgconn.NewCommandTag("INSERT 0 1"), nil
+}
+
+func (tx *recordingTx) QueryRow(_ context.Context, sql string, args ...any) pgx.Row {
+	tx.queries = append(tx.queries, query{sql: sql, args: args})
+	tx.lastID++
+	return idRow(tx.lastID)
+}
+
+type idRow int
+
+func (r idRow) Scan(dest ...any) error {
+	*dest[0].(*int) = int(r)
+	return nil
+}
+
+func (tx *recordingTx) find(prefix string) []query {
+	var res []query
+	for _, q := range tx.queries {
+		if strings.HasPrefix(strings.TrimSpace(q.sql), prefix) {
+			res = append(res, q)
+		}
+	}
+	return res
+}
+
+func TestApply(t *testing.T) {
+	tx := &recordingTx{}
+
+	err := apply(context.Background(), tx, Fixture{
+		Countries: []Country{{
+			Name: "United States",
+			States: []State{
+				{Name: "California", Timezone: "America/Los_Angeles", Regions: []string{"Los Angeles"}},
+				{Name: "Colorado", MinimumAge: 19},
+			},
+		}},
+		Stores: []Store{{Brand: "Green Demo", Name: "Green Demo Venice", State: "California"}},
+	})
+	require.NoError(t, err)
+
+	states := tx.find("INSERT INTO states")
+	require.Len(t, states, 2)
+	require.Contains(t, states[0].sql, "timezone=EXCLUDED.timezone")
+	require.Equal(t, []any{"California", 1, 21, "America/Los_Angeles", 1}, states[0].args)
+	require.Equal(t, []any{"Colorado", 1, 19, "UTC", 2}, states[1].args)
+
+	for _, prefix := range []string{"UPDATE stores", "INSERT INTO stores"} {
+		stores := tx.find(prefix)
+		require.Len(t, stores, 1, prefix)
+		require.NotContains(t, stores[0].sql, "timezone", prefix)
+		require.Len(t, stores[0].args, 19, prefix)
+		require.Equal(t, "Green Demo Venice", stores[0].args[1], prefix)
+	}
+}
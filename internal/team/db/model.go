@@ -0,0 +1,10 @@
+package teamdb
+
+import "errors"
+
+var (
+	ErrMemberNotFound      = errors.New("team member not found")
+	ErrMemberAlreadyExists = errors.New("team member already exists")
+	ErrInviteNotFound      = errors.New("team invite not found")
+	ErrInviteAlreadyExists = errors.New("team invite already exists")
+)
@@ -0,0 +1,411 @@
+package teamdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/team"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor/postgresql"
+	"go.uber.org/zap"
+)
+
+const uniqueViolationCode = "23505"
+
+type repository struct {
+	client *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func New(client *pgxpool.Pool, logger *zap.Logger) *repository {
+	return &repository{
+		client: client,
+		logger: logger,
+	}
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+const memberQuery = `
+	SELECT
+		tm.business_id,
+		u.id,
+		u.email,
+		u.username,
+		u.first_name,
+		u.last_name,
+		u.avatar,
+		tm.role,
+		ARRAY(
+			SELECT tms.store_id
+			FROM team_members_stores tms
+			WHERE tms.business_id = tm.business_id AND tms.user_id = tm.user_id
+			ORDER BY tms.store_id
+		),
+		tm.created_at
+	FROM team_members tm
+	JOIN users u ON tm.user_id = u.id
+`
+
+func scanMember(row pgx.Row) (*team.Member, error) {
+	var m team.Member
+
+	if err := row.Scan(
+		&m.BusinessID,
+		&m.UserID,
+		&m.Email,
+		&m.Username,
+		&m.FirstName,
+		&m.LastName,
+		&m.Avatar,
+		&m.Role,
+		&m.StoreIDs,
+		&m.JoinedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (r *repository) GetMember(ctx context.Context, businessID, userID int) (*team.Member, error) {
+	query := memberQuery + " WHERE tm.business_id=$1 AND tm.user_id=$2"
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	member, err := scanMember(postgresql.GetExecutor(ctx, r.client).QueryRow(ctx, query, businessID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (r *repository) GetMembers(ctx context.Context, businessID int) ([]team.Member, error) {
+	query := memberQuery + `
+		WHERE tm.business_id=$1
+		ORDER BY tm.role, tm.created_at, u.id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, businessID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make([]team.Member, 0)
+	for rows.Next() {
+		member, err := scanMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+		members = append(members, *member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return members, nil
+}
+
+func (r *repository) GetMemberships(ctx context.Context, userID int) ([]team.Membership, error) {
+	query := `
+		SELECT
+			tm.business_id,
+			COALESCE(bp.business_name, ''),
+			tm.role,
+			ARRAY(
+				SELECT tms.store_id
+				FROM team_members_stores tms
+				WHERE tms.business_id = tm.business_id AND tms.user_id = tm.user_id
+				ORDER BY tms.store_id
+			)
+		FROM team_members tm
+		JOIN business_profiles bp ON tm.business_id = bp.user_id
+		WHERE tm.user_id=$1
+		ORDER BY tm.created_at, tm.business_id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	memberships := make([]team.Membership, 0)
+	for rows.Next() {
+		var m team.Membership
+		if err := rows.Scan(&m.BusinessID, &m.BusinessName, &m.Role, &m.StoreIDs); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+		memberships = append(memberships, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return memberships, nil
+}
+
+// CountBusinessStores returns how many of storeIDs belong to brands of the business.
+func (r *repository) CountBusinessStores(ctx context.Context, businessID int, storeIDs []int) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM stores s
+		JOIN brands b ON s.brand_id = b.id
+		WHERE b.user_id=$1 AND s.id = ANY($2)
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	var count int
+	if err := postgresql.GetExecutor(ctx, r.client).QueryRow(ctx, query, businessID, storeIDs).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *repository) setMemberStores(ctx context.Context, businessID, userID int, storeIDs []int) error {
+	executor := postgresql.GetExecutor(ctx, r.client)
+
+	deleteQuery := "DELETE FROM team_members_stores WHERE business_id=$1 AND user_id=$2"
+	logging.LogSQLQuery(ctx, r.logger, deleteQuery)
+	if _, err := executor.Exec(ctx, deleteQuery, businessID, userID); err != nil {
+		return err
+	}
+
+	// stores deleted or moved since they were picked are skipped
+	insertQuery := `
+		INSERT INTO team_members_stores (business_id, user_id, store_id)
+		SELECT $1, $2, s.id
+		FROM stores s
+		JOIN brands b ON s.brand_id = b.id
+		WHERE b.user_id=$1 AND s.id = ANY($3)
+	`
+	logging.LogSQLQuery(ctx, r.logger, insertQuery)
+	_, err := executor.Exec(ctx, insertQuery, businessID, userID, storeIDs)
+
+	return err
+}
+
+// AddMember has to run within a transaction together with its stores.
+func (r *repository) AddMember(ctx context.Context, businessID, userID int, role string, storeIDs []int) (*team.Member, error) {
+	query := `
+		INSERT INTO team_members (business_id, user_id, role)
+		VALUES ($1, $2, $3)
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	if _, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, businessID, userID, role); err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrMemberAlreadyExists
+		}
+		return nil, err
+	}
+
+	if err := r.setMemberStores(ctx, businessID, userID, storeIDs); err != nil {
+		return nil, err
+	}
+
+	return r.GetMember(ctx, businessID, userID)
+}
+
+// UpdateMember has to run within a transaction together with its stores.
+func (r *repository) UpdateMember(ctx context.Context, businessID, userID int, role string, storeIDs []int) (*team.Member, error) {
+	query := `
+		UPDATE team_members
+		SET role=$3
+		WHERE business_id=$1 AND user_id=$2
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, businessID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return nil, ErrMemberNotFound
+	}
+
+	if err := r.setMemberStores(ctx, businessID, userID, storeIDs); err != nil {
+		return nil, err
+	}
+
+	return r.GetMember(ctx, businessID, userID)
+}
+
+func (r *repository) DeleteMember(ctx context.Context, businessID, userID int) error {
+	query := "DELETE FROM team_members WHERE business_id=$1 AND user_id=$2"
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, businessID, userID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrMemberNotFound
+	}
+
+	return nil
+}
+
+const inviteQuery = `
+	SELECT
+		ti.id,
+		ti.business_id,
+		COALESCE(bp.business_name, ''),
+		ti.email,
+		ti.role,
+		ti.store_ids,
+		ti.inviter_id,
+		ti.status,
+		ti.created_at,
+		ti.responded_at
+	FROM team_invites ti
+	JOIN business_profiles bp ON ti.business_id = bp.user_id
+`
+
+func scanInvite(row pgx.Row) (*team.Invite, error) {
+	var i team.Invite
+
+	if err := row.Scan(
+		&i.ID,
+		&i.BusinessID,
+		&i.BusinessName,
+		&i.Email,
+		&i.Role,
+		&i.StoreIDs,
+		&i.InviterID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.RespondedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &i, nil
+}
+
+func (r *repository) getInvites(ctx context.Context, condition string, args ...any) ([]team.Invite, error) {
+	query := inviteQuery + " WHERE " + condition + " ORDER BY ti.created_at DESC, ti.id DESC"
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invites := make([]team.Invite, 0)
+	for rows.Next() {
+		invite, err := scanInvite(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+		invites = append(invites, *invite)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %v", err)
+	}
+
+	return invites, nil
+}
+
+func (r *repository) GetInvite(ctx context.Context, inviteID int) (*team.Invite, error) {
+	query := inviteQuery + " WHERE ti.id=$1"
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	invite, err := scanInvite(postgresql.GetExecutor(ctx, r.client).QueryRow(ctx, query, inviteID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInviteNotFound
+		}
+		return nil, err
+	}
+
+	return invite, nil
+}
+
+func (r *repository) GetBusinessInvites(ctx context.Context, businessID int) ([]team.Invite, error) {
+	return r.getInvites(ctx, "ti.business_id=$1 AND ti.status='pending'", businessID)
+}
+
+func (r *repository) GetEmailInvites(ctx context.Context, email string) ([]team.Invite, error) {
+	return r.getInvites(ctx, "lower(ti.email)=lower($1) AND ti.status='pending'", email)
+}
+
+func (r *repository) CreateInvite(ctx context.Context, data team.Invite) (*team.Invite, error) {
+	query := `
+		INSERT INTO team_invites (business_id, email, role, store_ids, inviter_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	var id int
+	if err := postgresql.GetExecutor(ctx, r.client).QueryRow(
+		ctx,
+		query,
+		data.BusinessID,
+		data.Email,
+		data.Role,
+		data.StoreIDs,
+		data.InviterID,
+	).Scan(&id); err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrInviteAlreadyExists
+		}
+		return nil, err
+	}
+
+	return r.GetInvite(ctx, id)
+}
+
+// SetInviteStatus resolves a pending invite. An invite that has already
+// been resolved, e.g. by a concurrent request, returns ErrInviteNotFound.
+func (r *repository) SetInviteStatus(ctx context.Context, inviteID int, status string) error {
+	query := `
+		UPDATE team_invites
+		SET status=$2, responded_at=CURRENT_TIMESTAMP
+		WHERE id=$1 AND status='pending'
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, inviteID, status)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrInviteNotFound
+	}
+
+	return nil
+}
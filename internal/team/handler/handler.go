@@ -0,0 +1,321 @@
+package teamhandler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	jwtmiddleware "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt/middleware"
+	"github.com/xw1nchester/kushfinds-backend/internal/handlers"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/team"
+	"go.uber.org/zap"
+)
+
+var validate = apperror.NewValidator()
+
+type Service interface {
+	GetMemberships(ctx context.Context, userID int) ([]team.Membership, error)
+	GetMembers(ctx context.Context, businessID, userID int) ([]team.Member, error)
+	UpdateMember(ctx context.Context, businessID, memberID, userID int, role string, storeIDs []int) (*team.Member, error)
+	RemoveMember(ctx context.Context, businessID, memberID, userID int) error
+	Invite(ctx context.Context, data team.Invite) (*team.Invite, error)
+	GetInvites(ctx context.Context, businessID, userID int) ([]team.Invite, error)
+	RevokeInvite(ctx context.Context, businessID, inviteID, userID int) error
+	GetUserInvites(ctx context.Context, userID int) ([]team.Invite, error)
+	AcceptInvite(ctx context.Context, inviteID, userID int) (*team.Membership, error)
+	DeclineInvite(ctx context.Context, inviteID, userID int) error
+}
+
+type handler struct {
+	service        Service
+	authMiddleware func(http.Handler) http.Handler
+	staticURL      string
+	logger         *zap.Logger
+}
+
+func New(
+	service Service,
+	authMiddleware func(http.Handler) http.Handler,
+	staticURL string,
+	logger *zap.Logger,
+) handlers.Handler {
+	return &handler{
+		service:        service,
+		authMiddleware: authMiddleware,
+		staticURL:      staticURL,
+		logger:         logger,
+	}
+}
+
+func (h *handler) Register(router chi.Router) {
+	router.Route("/me/teams", func(userTeamRouter chi.Router) {
+		userTeamRouter.Use(h.authMiddleware)
+		userTeamRouter.Get("/", apperror.Middleware(h.getMembershipsHandler))
+	})
+
+	router.Route("/me/invites", func(userInviteRouter chi.Router) {
+		userInviteRouter.Use(h.authMiddleware)
+		userInviteRouter.Get("/", apperror.Middleware(h.getUserInvitesHandler))
+		userInviteRouter.Post("/{id}/accept", apperror.Middleware(h.acceptInviteHandler))
+		userInviteRouter.Post("/{id}/decline", apperror.Middleware(h.declineInviteHandler))
+	})
+
+	router.Route("/teams/{business_id}", func(teamRouter chi.Router) {
+		teamRouter.Use(h.authMiddleware)
+		teamRouter.Get("/members", apperror.Middleware(h.getMembersHandler))
+		teamRouter.Patch("/members/{id}", apperror.Middleware(h.updateMemberHandler))
+		teamRouter.Delete("/members/{id}", apperror.Middleware(h.removeMemberHandler))
+		teamRouter.Get("/invites", apperror.Middleware(h.getInvitesHandler))
+		teamRouter.Post("/invites", apperror.Middleware(h.inviteHandler))
+		teamRouter.Delete("/invites/{id}", apperror.Middleware(h.revokeInviteHandler))
+	})
+}
+
+func parseIntParam(r *http.Request, name string) (int, error) {
+	value, err := strconv.Atoi(chi.URLParam(r, name))
+	if err != nil {
+		return 0, apperror.NewFieldError(name, "field.positive_integer")
+	}
+	return value, nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		team
+// @Success	200		{object}	MembershipsResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/teams [get]
+func (h *handler) getMembershipsHandler(w http.ResponseWriter, r *http.Request) error {
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	memberships, err := h.service.GetMemberships(r.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, MembershipsResponse{Teams: memberships})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		team
+// @Success	200		{object}	InvitesResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/me/invites [get]
+func (h *handler) getUserInvitesHandler(w http.ResponseWriter, r *http.Request) error {
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	invites, err := h.service.GetUserInvites(r.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, InvitesResponse{Invites: invites})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		team
+// @Success	200		{object}	MembershipResponse
+// @Failure	400,404,409,500	{object}	apperror.AppError
+// @Router		/me/invites/{id}/accept [post]
+func (h *handler) acceptInviteHandler(w http.ResponseWriter, r *http.Request) error {
+	inviteID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	membership, err := h.service.AcceptInvite(r.Context(), inviteID, userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, MembershipResponse{Team: *membership})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		team
+// @Success	200
+// @Failure	400,404,500	{object}	apperror.AppError
+// @Router		/me/invites/{id}/decline [post]
+func (h *handler) declineInviteHandler(w http.ResponseWriter, r *http.Request) error {
+	inviteID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	return h.service.DeclineInvite(r.Context(), inviteID, userID)
+}
+
+// @Security	ApiKeyAuth
+// @Tags		team
+// @Success	200		{object}	MembersResponse
+// @Failure	400,404,500	{object}	apperror.AppError
+// @Router		/teams/{business_id}/members [get]
+func (h *handler) getMembersHandler(w http.ResponseWriter, r *http.Request) error {
+	businessID, err := parseIntParam(r, "business_id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	members, err := h.service.GetMembers(r.Context(), businessID, userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewMembersResponse(members, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		team
+// @Param		request	body		MemberRequest	true	"request body"
+// @Success	200		{object}	MemberResponse
+// @Failure	400,403,404,500	{object}	apperror.AppError
+// @Router		/teams/{business_id}/members/{id} [patch]
+func (h *handler) updateMemberHandler(w http.ResponseWriter, r *http.Request) error {
+	businessID, err := parseIntParam(r, "business_id")
+	if err != nil {
+		return err
+	}
+
+	memberID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var dto MemberRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	member, err := h.service.UpdateMember(r.Context(), businessID, memberID, userID, dto.Role, toInts(dto.StoreIDs))
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewMemberResponse(*member, h.staticURL))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		team
+// @Success	200
+// @Failure	400,403,404,500	{object}	apperror.AppError
+// @Router		/teams/{business_id}/members/{id} [delete]
+func (h *handler) removeMemberHandler(w http.ResponseWriter, r *http.Request) error {
+	businessID, err := parseIntParam(r, "business_id")
+	if err != nil {
+		return err
+	}
+
+	memberID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	return h.service.RemoveMember(r.Context(), businessID, memberID, userID)
+}
+
+// @Security	ApiKeyAuth
+// @Tags		team
+// @Success	200		{object}	InvitesResponse
+// @Failure	400,403,404,500	{object}	apperror.AppError
+// @Router		/teams/{business_id}/invites [get]
+func (h *handler) getInvitesHandler(w http.ResponseWriter, r *http.Request) error {
+	businessID, err := parseIntParam(r, "business_id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	invites, err := h.service.GetInvites(r.Context(), businessID, userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, InvitesResponse{Invites: invites})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		team
+// @Param		request	body		InviteRequest	true	"request body"
+// @Success	200		{object}	InviteResponse
+// @Failure	400,403,404,409,500	{object}	apperror.AppError
+// @Router		/teams/{business_id}/invites [post]
+func (h *handler) inviteHandler(w http.ResponseWriter, r *http.Request) error {
+	businessID, err := parseIntParam(r, "business_id")
+	if err != nil {
+		return err
+	}
+
+	var dto InviteRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	invite, err := h.service.Invite(r.Context(), dto.ToDomain(businessID, userID))
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, InviteResponse{Invite: *invite})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		team
+// @Success	200
+// @Failure	400,403,404,500	{object}	apperror.AppError
+// @Router		/teams/{business_id}/invites/{id} [delete]
+func (h *handler) revokeInviteHandler(w http.ResponseWriter, r *http.Request) error {
+	businessID, err := parseIntParam(r, "business_id")
+	if err != nil {
+		return err
+	}
+
+	inviteID, err := parseIntParam(r, "id")
+	if err != nil {
+		return err
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	return h.service.RevokeInvite(r.Context(), businessID, inviteID, userID)
+}
@@ -0,0 +1,78 @@
+package teamhandler
+
+import (
+	"github.com/xw1nchester/kushfinds-backend/internal/team"
+	"github.com/xw1nchester/kushfinds-backend/pkg/types"
+)
+
+type InviteRequest struct {
+	Email    string              `json:"email" validate:"required,email"`
+	Role     string              `json:"role" validate:"required,oneof=owner manager staff"`
+	StoreIDs []types.IntOrString `json:"storeIds" validate:"required_if=Role staff,dive,gt=0"`
+}
+
+func (ir *InviteRequest) ToDomain(businessID, inviterID int) team.Invite {
+	return team.Invite{
+		BusinessID: businessID,
+		Email:      ir.Email,
+		Role:       ir.Role,
+		StoreIDs:   toInts(ir.StoreIDs),
+		InviterID:  &inviterID,
+	}
+}
+
+type MemberRequest struct {
+	Role     string              `json:"role" validate:"required,oneof=owner manager staff"`
+	StoreIDs []types.IntOrString `json:"storeIds" validate:"required_if=Role staff,dive,gt=0"`
+}
+
+func toInts(ids []types.IntOrString) []int {
+	res := make([]int, len(ids))
+	for i, id := range ids {
+		res[i] = int(id)
+	}
+	return res
+}
+
+type MembershipResponse struct {
+	Team team.Membership `json:"team"`
+}
+
+type MembershipsResponse struct {
+	Teams []team.Membership `json:"teams"`
+}
+
+type MemberResponse struct {
+	Member team.Member `json:"member"`
+}
+
+func prefixMember(m *team.Member, staticURL string) {
+	if m.Avatar != nil {
+		avatar := staticURL + "/" + *m.Avatar
+		m.Avatar = &avatar
+	}
+}
+
+func NewMemberResponse(m team.Member, staticURL string) MemberResponse {
+	prefixMember(&m, staticURL)
+	return MemberResponse{Member: m}
+}
+
+type MembersResponse struct {
+	Members []team.Member `json:"members"`
+}
+
+func NewMembersResponse(elements []team.Member, staticURL string) MembersResponse {
+	for i := range elements {
+		prefixMember(&elements[i], staticURL)
+	}
+	return MembersResponse{Members: elements}
+}
+
+type InviteResponse struct {
+	Invite team.Invite `json:"invite"`
+}
+
+type InvitesResponse struct {
+	Invites []team.Invite `json:"invites"`
+}
@@ -0,0 +1,52 @@
+package team
+
+import "time"
+
+const (
+	RoleOwner   = "owner"
+	RoleManager = "manager"
+	RoleStaff   = "staff"
+)
+
+const (
+	InviteStatusPending  = "pending"
+	InviteStatusAccepted = "accepted"
+	InviteStatusDeclined = "declined"
+	InviteStatusRevoked  = "revoked"
+)
+
+// Member is a user working for a business. A business is identified by the
+// id of the user who created its business profile, brands are keyed by it.
+type Member struct {
+	BusinessID int       `json:"-"`
+	UserID     int       `json:"userId"`
+	Email      string    `json:"email"`
+	Username   *string   `json:"username"`
+	FirstName  *string   `json:"firstName"`
+	LastName   *string   `json:"lastName"`
+	Avatar     *string   `json:"avatar"`
+	Role       string    `json:"role"`
+	StoreIDs   []int     `json:"storeIds"`
+	JoinedAt   time.Time `json:"joinedAt"`
+}
+
+// Membership is a business as seen by one of its members.
+type Membership struct {
+	BusinessID   int    `json:"businessId"`
+	BusinessName string `json:"businessName"`
+	Role         string `json:"role"`
+	StoreIDs     []int  `json:"storeIds"`
+}
+
+type Invite struct {
+	ID           int        `json:"id"`
+	BusinessID   int        `json:"businessId"`
+	BusinessName string     `json:"businessName"`
+	Email        string     `json:"email"`
+	Role         string     `json:"role"`
+	StoreIDs     []int      `json:"storeIds"`
+	InviterID    *int       `json:"-"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	RespondedAt  *time.Time `json:"respondedAt"`
+}
@@ -0,0 +1,60 @@
+package team
+
+import "slices"
+
+type Permission int
+
+const (
+	// PermissionView allows reading brands, stores and products of the business.
+	PermissionView Permission = iota
+	// PermissionOperateStore allows managing menus, orders and review replies.
+	PermissionOperateStore
+	// PermissionManageStores allows creating stores and changing their details.
+	PermissionManageStores
+	// PermissionManageBrands allows creating, editing and submitting brands and products.
+	PermissionManageBrands
+	PermissionDeleteBrands
+	PermissionManageTeam
+)
+
+var rolePermissions = map[string][]Permission{
+	RoleOwner: {
+		PermissionView,
+		PermissionOperateStore,
+		PermissionManageStores,
+		PermissionManageBrands,
+		PermissionDeleteBrands,
+		PermissionManageTeam,
+	},
+	RoleManager: {
+		PermissionView,
+		PermissionOperateStore,
+		PermissionManageStores,
+		PermissionManageBrands,
+	},
+	RoleStaff: {
+		PermissionView,
+		PermissionOperateStore,
+	},
+}
+
+// Can reports whether the role grants the permission.
+func Can(role string, permission Permission) bool {
+	return slices.Contains(rolePermissions[role], permission)
+}
+
+// IsStoreScoped reports whether members with the role only have access
+// to the stores assigned to them.
+func IsStoreScoped(role string) bool {
+	return role == RoleStaff
+}
+
+// CanAccessStore reports whether the member may use the permission
+// in the given store of the business.
+func (m Member) CanAccessStore(storeID int, permission Permission) bool {
+	if !Can(m.Role, permission) {
+		return false
+	}
+
+	return !IsStoreScoped(m.Role) || slices.Contains(m.StoreIDs, storeID)
+}
@@ -0,0 +1,42 @@
+package team
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCan(t *testing.T) {
+	testCases := []struct {
+		role       string
+		permission Permission
+		want       bool
+	}{
+		{role: RoleOwner, permission: PermissionManageTeam, want: true},
+		{role: RoleOwner, permission: PermissionDeleteBrands, want: true},
+		{role: RoleManager, permission: PermissionManageBrands, want: true},
+		{role: RoleManager, permission: PermissionManageStores, want: true},
+		{role: RoleManager, permission: PermissionDeleteBrands, want: false},
+		{role: RoleManager, permission: PermissionManageTeam, want: false},
+		{role: RoleStaff, permission: PermissionView, want: true},
+		{role: RoleStaff, permission: PermissionOperateStore, want: true},
+		{role: RoleStaff, permission: PermissionManageStores, want: false},
+		{role: RoleStaff, permission: PermissionManageBrands, want: false},
+		{role: "unknown", permission: PermissionView, want: false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, Can(tc.role, tc.permission), "%s %d", tc.role, tc.permission)
+	}
+}
+
+func TestMemberCanAccessStore(t *testing.T) {
+	manager := Member{Role: RoleManager}
+	assert.True(t, manager.CanAccessStore(1, PermissionOperateStore))
+	assert.True(t, manager.CanAccessStore(1, PermissionManageStores))
+
+	staff := Member{Role: RoleStaff, StoreIDs: []int{1, 2}}
+	assert.True(t, staff.CanAccessStore(2, PermissionOperateStore))
+	assert.False(t, staff.CanAccessStore(3, PermissionOperateStore))
+	assert.False(t, staff.CanAccessStore(1, PermissionManageStores))
+}
@@ -0,0 +1,516 @@
+package teamservice
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/audit"
+	"github.com/xw1nchester/kushfinds-backend/internal/i18n"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
+	"github.com/xw1nchester/kushfinds-backend/internal/team"
+	teamdb "github.com/xw1nchester/kushfinds-backend/internal/team/db"
+	"github.com/xw1nchester/kushfinds-backend/internal/tracing"
+	"github.com/xw1nchester/kushfinds-backend/internal/user"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor"
+	"github.com/xw1nchester/kushfinds-backend/pkg/utils"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInviteAlreadyExists = apperror.NewConflictError("team.invite_already_exists", "this email already has a pending invite")
+	ErrAlreadyMember       = apperror.NewConflictError("team.already_member", "the user is already a team member")
+	ErrStoresRequired      = apperror.NewAppError("team.stores_required", "staff should be assigned at least one store")
+	ErrInvalidStores       = apperror.NewAppError("team.invalid_stores", "stores should belong to the business")
+	ErrFounderImmutable    = apperror.NewAppError("team.founder_immutable", "the business founder can not be removed or change role")
+)
+
+type Repository interface {
+	GetMember(ctx context.Context, businessID, userID int) (*team.Member, error)
+	GetMembers(ctx context.Context, businessID int) ([]team.Member, error)
+	GetMemberships(ctx context.Context, userID int) ([]team.Membership, error)
+	CountBusinessStores(ctx context.Context, businessID int, storeIDs []int) (int, error)
+	AddMember(ctx context.Context, businessID, userID int, role string, storeIDs []int) (*team.Member, error)
+	UpdateMember(ctx context.Context, businessID, userID int, role string, storeIDs []int) (*team.Member, error)
+	DeleteMember(ctx context.Context, businessID, userID int) error
+	GetInvite(ctx context.Context, inviteID int) (*team.Invite, error)
+	GetBusinessInvites(ctx context.Context, businessID int) ([]team.Invite, error)
+	GetEmailInvites(ctx context.Context, email string) ([]team.Invite, error)
+	CreateInvite(ctx context.Context, data team.Invite) (*team.Invite, error)
+	SetInviteStatus(ctx context.Context, inviteID int, status string) error
+}
+
+type UserService interface {
+	GetByID(ctx context.Context, id int) (*user.User, error)
+	GetByEmail(ctx context.Context, email string) (*user.User, error)
+}
+
+type MailManager interface {
+	SendMail(subject string, body string, to []string) error
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, record audit.Record) error
+}
+
+type service struct {
+	repository    Repository
+	userService   UserService
+	mailManager   MailManager
+	txManager     transactor.Manager
+	auditRecorder AuditRecorder
+	logger        *zap.Logger
+}
+
+func New(
+	repository Repository,
+	userService UserService,
+	mailManager MailManager,
+	txManager transactor.Manager,
+	auditRecorder AuditRecorder,
+	logger *zap.Logger,
+) *service {
+	return &service{
+		repository:    repository,
+		userService:   userService,
+		mailManager:   mailManager,
+		txManager:     txManager,
+		auditRecorder: auditRecorder,
+		logger:        logger,
+	}
+}
+
+// getMember returns apperror.ErrNotFound for users outside of the business,
+// so that its brands and stores look nonexistent to them.
+func (s *service) getMember(ctx context.Context, businessID, userID int) (*team.Member, error) {
+	member, err := s.repository.GetMember(ctx, businessID, userID)
+	if err != nil {
+		if errors.Is(err, teamdb.ErrMemberNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching team member", zap.Error(err))
+
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// CheckPermission fails unless userID is a member of the business
+// whose role grants the permission.
+func (s *service) CheckPermission(ctx context.Context, businessID, userID int, permission team.Permission) error {
+	ctx, span := tracing.Start(ctx, "teamservice.CheckPermission")
+	defer span.End()
+
+	member, err := s.getMember(ctx, businessID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !team.Can(member.Role, permission) {
+		return apperror.ErrForbidden
+	}
+
+	return nil
+}
+
+// CheckStorePermission is CheckPermission scoped to a store of the business,
+// staff only sees the stores assigned to them.
+func (s *service) CheckStorePermission(
+	ctx context.Context,
+	businessID int,
+	storeID int,
+	userID int,
+	permission team.Permission,
+) error {
+	ctx, span := tracing.Start(ctx, "teamservice.CheckStorePermission")
+	defer span.End()
+
+	member, err := s.getMember(ctx, businessID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !member.CanAccessStore(storeID, team.PermissionView) {
+		return apperror.ErrNotFound
+	}
+
+	if !member.CanAccessStore(storeID, permission) {
+		return apperror.ErrForbidden
+	}
+
+	return nil
+}
+
+func (s *service) GetMemberships(ctx context.Context, userID int) ([]team.Membership, error) {
+	memberships, err := s.repository.GetMemberships(ctx, userID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching team memberships", zap.Error(err))
+
+		return nil, err
+	}
+
+	return memberships, nil
+}
+
+func (s *service) GetMembers(ctx context.Context, businessID, userID int) ([]team.Member, error) {
+	if err := s.CheckPermission(ctx, businessID, userID, team.PermissionView); err != nil {
+		return nil, err
+	}
+
+	members, err := s.repository.GetMembers(ctx, businessID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching team members", zap.Error(err))
+
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// normalizeStores keeps stores for store scoped roles only
+// and checks that they belong to the business.
+func (s *service) normalizeStores(ctx context.Context, businessID int, role string, storeIDs []int) ([]int, error) {
+	if !team.IsStoreScoped(role) {
+		return []int{}, nil
+	}
+
+	storeIDs = utils.RemoveDuplicates(storeIDs)
+	if len(storeIDs) == 0 {
+		return nil, ErrStoresRequired
+	}
+
+	count, err := s.repository.CountBusinessStores(ctx, businessID, storeIDs)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when counting business stores", zap.Error(err))
+		return nil, err
+	}
+
+	if count != len(storeIDs) {
+		return nil, ErrInvalidStores
+	}
+
+	return storeIDs, nil
+}
+
+// Invite sends an invite to join the business to data.Email on behalf of data.InviterID.
+func (s *service) Invite(ctx context.Context, data team.Invite) (*team.Invite, error) {
+	ctx, span := tracing.Start(ctx, "teamservice.Invite")
+	defer span.End()
+
+	if err := s.CheckPermission(ctx, data.BusinessID, *data.InviterID, team.PermissionManageTeam); err != nil {
+		return nil, err
+	}
+
+	storeIDs, err := s.normalizeStores(ctx, data.BusinessID, data.Role, data.StoreIDs)
+	if err != nil {
+		return nil, err
+	}
+	data.StoreIDs = storeIDs
+	data.Email = strings.TrimSpace(data.Email)
+
+	invitee, err := s.userService.GetByEmail(ctx, data.Email)
+	if err != nil && !errors.Is(err, apperror.ErrNotFound) {
+		return nil, err
+	}
+
+	if invitee != nil {
+		if _, err := s.getMember(ctx, data.BusinessID, invitee.ID); err == nil {
+			return nil, ErrAlreadyMember
+		} else if !errors.Is(err, apperror.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	createdInvite, err := s.repository.CreateInvite(ctx, data)
+	if err != nil {
+		if errors.Is(err, teamdb.ErrInviteAlreadyExists) {
+			return nil, ErrInviteAlreadyExists
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when creating team invite", zap.Error(err))
+
+		return nil, err
+	}
+
+	locale := i18n.FromContext(ctx)
+	if invitee != nil {
+		locale = i18n.Resolve(invitee.Locale, locale)
+	}
+
+	s.sendInvite(ctx, locale, *createdInvite)
+
+	return createdInvite, nil
+}
+
+func (s *service) sendInvite(ctx context.Context, locale string, invite team.Invite) {
+	subject, body := i18n.Mail(
+		locale,
+		"email.team_invite",
+		invite.BusinessName,
+		i18n.T(locale, "team.role."+invite.Role),
+	)
+
+	go func() {
+		if err := s.mailManager.SendMail(subject, body, []string{invite.Email}); err != nil {
+			logging.FromContext(ctx, s.logger).Error("unexpected error when sending email", zap.Error(err))
+		}
+	}()
+}
+
+func (s *service) GetInvites(ctx context.Context, businessID, userID int) ([]team.Invite, error) {
+	if err := s.CheckPermission(ctx, businessID, userID, team.PermissionManageTeam); err != nil {
+		return nil, err
+	}
+
+	invites, err := s.repository.GetBusinessInvites(ctx, businessID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching team invites", zap.Error(err))
+
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+func (s *service) getInvite(ctx context.Context, inviteID int) (*team.Invite, error) {
+	invite, err := s.repository.GetInvite(ctx, inviteID)
+	if err != nil {
+		if errors.Is(err, teamdb.ErrInviteNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching team invite", zap.Error(err))
+
+		return nil, err
+	}
+
+	return invite, nil
+}
+
+func (s *service) setInviteStatus(ctx context.Context, inviteID int, status string) error {
+	if err := s.repository.SetInviteStatus(ctx, inviteID, status); err != nil {
+		if errors.Is(err, teamdb.ErrInviteNotFound) {
+			return apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when updating team invite", zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) RevokeInvite(ctx context.Context, businessID, inviteID, userID int) error {
+	if err := s.CheckPermission(ctx, businessID, userID, team.PermissionManageTeam); err != nil {
+		return err
+	}
+
+	invite, err := s.getInvite(ctx, inviteID)
+	if err != nil {
+		return err
+	}
+
+	if invite.BusinessID != businessID {
+		return apperror.ErrNotFound
+	}
+
+	return s.setInviteStatus(ctx, inviteID, team.InviteStatusRevoked)
+}
+
+// GetUserInvites returns pending invites sent to the user email.
+func (s *service) GetUserInvites(ctx context.Context, userID int) ([]team.Invite, error) {
+	existingUser, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	invites, err := s.repository.GetEmailInvites(ctx, existingUser.Email)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching user invites", zap.Error(err))
+
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+// getUserInvite returns a pending invite addressed to the user. Invites
+// sent to other emails look nonexistent.
+func (s *service) getUserInvite(ctx context.Context, inviteID, userID int) (*team.Invite, error) {
+	existingUser, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	invite, err := s.getInvite(ctx, inviteID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existingUser.IsVerified ||
+		invite.Status != team.InviteStatusPending ||
+		!strings.EqualFold(invite.Email, existingUser.Email) {
+		return nil, apperror.ErrNotFound
+	}
+
+	return invite, nil
+}
+
+func (s *service) AcceptInvite(ctx context.Context, inviteID, userID int) (*team.Membership, error) {
+	ctx, span := tracing.Start(ctx, "teamservice.AcceptInvite")
+	defer span.End()
+
+	invite, err := s.getUserInvite(ctx, inviteID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var member *team.Member
+
+	if err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.setInviteStatus(ctx, inviteID, team.InviteStatusAccepted); err != nil {
+			return err
+		}
+
+		member, err = s.repository.AddMember(ctx, invite.BusinessID, userID, invite.Role, invite.StoreIDs)
+		if err != nil {
+			if errors.Is(err, teamdb.ErrMemberAlreadyExists) {
+				return ErrAlreadyMember
+			}
+
+			logging.FromContext(ctx, s.logger).Error("unexpected error when adding team member", zap.Error(err))
+
+			return err
+		}
+
+		return s.auditRecorder.Record(ctx, audit.Record{
+			ActorID:    userID,
+			Action:     audit.ActionTeamMemberJoin,
+			EntityType: audit.EntityTeam,
+			EntityID:   invite.BusinessID,
+			After:      member,
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return &team.Membership{
+		BusinessID:   invite.BusinessID,
+		BusinessName: invite.BusinessName,
+		Role:         member.Role,
+		StoreIDs:     member.StoreIDs,
+	}, nil
+}
+
+func (s *service) DeclineInvite(ctx context.Context, inviteID, userID int) error {
+	if _, err := s.getUserInvite(ctx, inviteID, userID); err != nil {
+		return err
+	}
+
+	return s.setInviteStatus(ctx, inviteID, team.InviteStatusDeclined)
+}
+
+// UpdateMember changes the member role and, for staff, the assigned stores.
+func (s *service) UpdateMember(
+	ctx context.Context,
+	businessID int,
+	memberID int,
+	userID int,
+	role string,
+	storeIDs []int,
+) (*team.Member, error) {
+	ctx, span := tracing.Start(ctx, "teamservice.UpdateMember")
+	defer span.End()
+
+	if err := s.CheckPermission(ctx, businessID, userID, team.PermissionManageTeam); err != nil {
+		return nil, err
+	}
+
+	existingMember, err := s.getMember(ctx, businessID, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	if memberID == businessID && role != team.RoleOwner {
+		return nil, ErrFounderImmutable
+	}
+
+	storeIDs, err = s.normalizeStores(ctx, businessID, role, storeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedMember *team.Member
+
+	if err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		updatedMember, err = s.repository.UpdateMember(ctx, businessID, memberID, role, storeIDs)
+		if err != nil {
+			if errors.Is(err, teamdb.ErrMemberNotFound) {
+				return apperror.ErrNotFound
+			}
+
+			logging.FromContext(ctx, s.logger).Error("unexpected error when updating team member", zap.Error(err))
+
+			return err
+		}
+
+		return s.auditRecorder.Record(ctx, audit.Record{
+			ActorID:    userID,
+			Action:     audit.ActionTeamMemberUpdate,
+			EntityType: audit.EntityTeam,
+			EntityID:   businessID,
+			Before:     existingMember,
+			After:      updatedMember,
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return updatedMember, nil
+}
+
+// RemoveMember removes the member from the business. Members may also
+// remove themselves to leave the team.
+func (s *service) RemoveMember(ctx context.Context, businessID, memberID, userID int) error {
+	ctx, span := tracing.Start(ctx, "teamservice.RemoveMember")
+	defer span.End()
+
+	if memberID != userID {
+		if err := s.CheckPermission(ctx, businessID, userID, team.PermissionManageTeam); err != nil {
+			return err
+		}
+	}
+
+	existingMember, err := s.getMember(ctx, businessID, memberID)
+	if err != nil {
+		return err
+	}
+
+	if memberID == businessID {
+		return ErrFounderImmutable
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.DeleteMember(ctx, businessID, memberID); err != nil {
+			if errors.Is(err, teamdb.ErrMemberNotFound) {
+				return apperror.ErrNotFound
+			}
+
+			logging.FromContext(ctx, s.logger).Error("unexpected error when removing team member", zap.Error(err))
+
+			return err
+		}
+
+		return s.auditRecorder.Record(ctx, audit.Record{
+			ActorID:    userID,
+			Action:     audit.ActionTeamMemberRemove,
+			EntityType: audit.EntityTeam,
+			EntityID:   businessID,
+			Before:     existingMember,
+		})
+	})
+}
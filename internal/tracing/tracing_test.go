@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSetup(t *testing.T) {
+	t.Run("none keeps the global provider", func(t *testing.T) {
+		before := otel.GetTracerProvider()
+
+		shutdown, err := Setup(context.Background(), Config{Exporter: ExporterNone})
+		require.NoError(t, err)
+		assert.NoError(t, shutdown(context.Background()))
+
+		assert.Equal(t, before, otel.GetTracerProvider())
+	})
+
+	t.Run("unknown exporter", func(t *testing.T) {
+		_, err := Setup(context.Background(), Config{Exporter: "jaeger"})
+		assert.Error(t, err)
+	})
+
+	t.Run("stdout installs sdk provider", func(t *testing.T) {
+		before := otel.GetTracerProvider()
+		t.Cleanup(func() { otel.SetTracerProvider(before) })
+
+		shutdown, err := Setup(context.Background(), Config{
+			Exporter:    ExporterStdout,
+			ServiceName: "test",
+			SampleRatio: 1,
+		})
+		require.NoError(t, err)
+
+		assert.IsType(t, &sdktrace.TracerProvider{}, otel.GetTracerProvider())
+
+		_, span := Start(context.Background(), "test.Span")
+		assert.True(t, span.SpanContext().IsValid())
+		span.End()
+
+		assert.NoError(t, shutdown(context.Background()))
+	})
+}
@@ -2,7 +2,6 @@ package handler
 
 import (
 	"context"
-	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -11,6 +10,7 @@ import (
 	"github.com/go-chi/render"
 	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
 	"github.com/xw1nchester/kushfinds-backend/internal/handlers"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
 	"github.com/xw1nchester/kushfinds-backend/internal/upload"
 	"go.uber.org/zap"
 )
@@ -58,11 +58,11 @@ func (h *handler) Register(router chi.Router) {
 func (h *handler) uploadHandler(w http.ResponseWriter, r *http.Request) error {
 	file, header, err := r.FormFile("file")
 	if err != nil {
-		return apperror.NewAppError(fmt.Sprintf("failed to retrieving file: %s", err.Error()))
+		return apperror.NewFieldError("file", "field.file_unreadable", err.Error())
 	}
 	defer file.Close()
 
-	h.logger.Info(
+	logging.FromContext(r.Context(), h.logger).Info(
 		"file to upload info",
 		zap.String("extension", strings.Split(header.Filename, ".")[1]),
 		zap.Int64("size", header.Size),
@@ -97,7 +97,7 @@ func (h *handler) getFileHandler(w http.ResponseWriter, r *http.Request) error {
 	// w.Header().Set("Content-Length", fmt.Sprintf("%d", dto.Size))
 
 	if _, err := io.Copy(w, dto.Object); err != nil {
-		h.logger.Error("failed to copy object to body", zap.Error(err))
+		logging.FromContext(r.Context(), h.logger).Error("failed to copy object to body", zap.Error(err))
 		return err
 	}
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/minio/minio-go/v7"
 	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
 	"github.com/xw1nchester/kushfinds-backend/internal/upload"
 	"go.uber.org/zap"
 )
@@ -16,32 +17,51 @@ const (
 	BucketName = "default"
 )
 
+type Metrics interface {
+	ObserveUpload(size int64, err error)
+}
+
 type service struct {
 	minioClient *minio.Client
+	metrics     Metrics
 	logger      *zap.Logger
 }
 
 func New(
 	minioClient *minio.Client,
+	metrics Metrics,
 	logger *zap.Logger,
 ) *service {
 	return &service{
 		minioClient: minioClient,
+		metrics:     metrics,
 		logger:      logger,
 	}
 }
 
 func (s *service) UploadFile(ctx context.Context, reader io.Reader, size int64, contentType string) (*upload.File, error) {
+	file, err := s.uploadFile(ctx, reader, size, contentType)
+	if err != nil {
+		s.metrics.ObserveUpload(size, err)
+		return nil, err
+	}
+
+	s.metrics.ObserveUpload(file.Size, nil)
+
+	return file, nil
+}
+
+func (s *service) uploadFile(ctx context.Context, reader io.Reader, size int64, contentType string) (*upload.File, error) {
 	exists, err := s.minioClient.BucketExists(ctx, BucketName)
 	if err != nil {
-		s.logger.Error("error checking if bucket exists", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("error checking if bucket exists", zap.Error(err))
 		return nil, err
 	}
 
 	if !exists {
 		err = s.minioClient.MakeBucket(ctx, BucketName, minio.MakeBucketOptions{})
 		if err != nil {
-			s.logger.Error("error creating bucket", zap.Error(err))
+			logging.FromContext(ctx, s.logger).Error("error creating bucket", zap.Error(err))
 			return nil, err
 		}
 	}
@@ -56,17 +76,20 @@ func (s *service) UploadFile(ctx context.Context, reader io.Reader, size int64,
 			ContentType: contentType,
 		},
 	)
-	if err == nil {
-		s.logger.Info("uploaded file info",
-			zap.String("bucket", ui.Bucket),
-			zap.String("key", ui.Key),
-			zap.String("etag", ui.ETag),
-			zap.Int64("size", ui.Size),
-			zap.String("version_id", ui.VersionID),
-			zap.Time("version_id", ui.LastModified),
-		)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("error putting object", zap.Error(err))
+		return nil, err
 	}
 
+	logging.FromContext(ctx, s.logger).Info("uploaded file info",
+		zap.String("bucket", ui.Bucket),
+		zap.String("key", ui.Key),
+		zap.String("etag", ui.ETag),
+		zap.Int64("size", ui.Size),
+		zap.String("version_id", ui.VersionID),
+		zap.Time("version_id", ui.LastModified),
+	)
+
 	return &upload.File{
 		Name:        ui.Key,
 		ContentType: contentType,
@@ -77,13 +100,13 @@ func (s *service) UploadFile(ctx context.Context, reader io.Reader, size int64,
 func (s *service) GetFile(ctx context.Context, filename string) (*upload.File, error) {
 	obj, err := s.minioClient.GetObject(ctx, BucketName, filename, minio.GetObjectOptions{})
 	if err != nil {
-		s.logger.Error("error getting object", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("error getting object", zap.Error(err))
 		return nil, apperror.ErrNotFound
 	}
 
 	stat, err := obj.Stat()
 	if err != nil {
-		s.logger.Error("error getting object stats", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("error getting object stats", zap.Error(err))
 		return nil, apperror.ErrNotFound
 	}
 
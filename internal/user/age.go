@@ -0,0 +1,43 @@
+package user
+
+import "time"
+
+const DateOfBirthLayout = "2006-01-02"
+
+// AgeAt returns the number of full years a person born on dateOfBirth has at now.
+func AgeAt(dateOfBirth, now time.Time) int {
+	age := now.Year() - dateOfBirth.Year()
+	if now.Month() < dateOfBirth.Month() ||
+		(now.Month() == dateOfBirth.Month() && now.Day() < dateOfBirth.Day()) {
+		age--
+	}
+	return age
+}
+
+// IsAgeVerified reports whether the date of birth was confirmed by an ID verification provider.
+func (u *User) IsAgeVerified() bool {
+	return u.AgeVerifiedAt != nil && u.DateOfBirth != nil
+}
+
+// MinimumAge returns the minimum age required in the user's state,
+// falling back to defaultAge when the state is unknown.
+func (u *User) MinimumAge(defaultAge int) int {
+	if u.State != nil && u.State.MinimumAge > 0 {
+		return u.State.MinimumAge
+	}
+	return defaultAge
+}
+
+// IsOldEnough reports whether the user has a verified age of at least minimumAge at now.
+func (u *User) IsOldEnough(minimumAge int, now time.Time) bool {
+	if !u.IsAgeVerified() {
+		return false
+	}
+
+	dateOfBirth, err := time.Parse(DateOfBirthLayout, *u.DateOfBirth)
+	if err != nil {
+		return false
+	}
+
+	return AgeAt(dateOfBirth, now) >= minimumAge
+}
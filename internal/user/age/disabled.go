@@ -0,0 +1,32 @@
+package age
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+)
+
+const DisabledProviderName = "disabled"
+
+var ErrVerificationUnavailable = apperror.New(
+	http.StatusServiceUnavailable,
+	"user.age_verification_unavailable",
+	"age verification is unavailable",
+)
+
+// Disabled is used when no provider is configured.
+// Every verification attempt is answered with ErrVerificationUnavailable.
+type Disabled struct{}
+
+func NewDisabled() *Disabled {
+	return &Disabled{}
+}
+
+func (d *Disabled) Name() string {
+	return DisabledProviderName
+}
+
+func (d *Disabled) Verify(ctx context.Context, req Request) (*Result, error) {
+	return nil, ErrVerificationUnavailable
+}
@@ -0,0 +1,52 @@
+package age
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/user"
+)
+
+const FakeProviderName = "fake"
+
+// Fake approves any request with a document and a valid date of birth.
+// It is meant for local development and tests.
+type Fake struct {
+	// Reject makes the provider decline every request.
+	Reject bool
+	// Err is returned from Verify when set.
+	Err error
+}
+
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+func (f *Fake) Name() string {
+	return FakeProviderName
+}
+
+func (f *Fake) Verify(ctx context.Context, req Request) (*Result, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	if f.Reject {
+		return &Result{Reason: "document rejected"}, nil
+	}
+
+	if req.Document == "" {
+		return &Result{Reason: "document is missing"}, nil
+	}
+
+	if _, err := time.Parse(user.DateOfBirthLayout, req.DateOfBirth); err != nil {
+		return &Result{Reason: "date of birth is not readable"}, nil
+	}
+
+	return &Result{
+		IsVerified:  true,
+		DateOfBirth: req.DateOfBirth,
+		Reference:   fmt.Sprintf("fake-%d-%d", req.UserID, time.Now().Unix()),
+	}, nil
+}
@@ -0,0 +1,61 @@
+package age
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeVerify(t *testing.T) {
+	tests := []struct {
+		name       string
+		provider   *Fake
+		req        Request
+		isVerified bool
+		wantErr    bool
+	}{
+		{
+			name:       "approves document with date of birth",
+			provider:   NewFake(),
+			req:        Request{UserID: 1, DateOfBirth: "1990-01-02", Document: "documents/id.png"},
+			isVerified: true,
+		},
+		{
+			name:     "declines missing document",
+			provider: NewFake(),
+			req:      Request{UserID: 1, DateOfBirth: "1990-01-02"},
+		},
+		{
+			name:     "declines when configured to reject",
+			provider: &Fake{Reject: true},
+			req:      Request{UserID: 1, DateOfBirth: "1990-01-02", Document: "documents/id.png"},
+		},
+		{
+			name:     "returns configured error",
+			provider: &Fake{Err: errors.New("provider unavailable")},
+			req:      Request{UserID: 1, DateOfBirth: "1990-01-02", Document: "documents/id.png"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.provider.Verify(context.Background(), tt.req)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.isVerified, result.IsVerified)
+			if tt.isVerified {
+				require.Equal(t, tt.req.DateOfBirth, result.DateOfBirth)
+				require.NotEmpty(t, result.Reference)
+			} else {
+				require.NotEmpty(t, result.Reason)
+			}
+		})
+	}
+}
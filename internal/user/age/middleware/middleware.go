@@ -0,0 +1,43 @@
+package agemiddleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	jwtmiddleware "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt/middleware"
+	"go.uber.org/zap"
+)
+
+type AgeChecker interface {
+	CheckAge(ctx context.Context, userID int) error
+}
+
+// NewMiddleware rejects requests of users without a verified age at or above
+// the minimum age of their state. It has to run after the auth middleware.
+func NewMiddleware(logger *zap.Logger, checker AgeChecker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+			if !ok {
+				apperror.Write(w, r, apperror.ErrUnauthorized)
+				return
+			}
+
+			if err := checker.CheckAge(r.Context(), userID); err != nil {
+				var appErr *apperror.AppError
+				if !errors.As(err, &appErr) {
+					logger.Error("unexpected error when checking user age", zap.Error(err))
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				apperror.Write(w, r, appErr.WithStatus(http.StatusForbidden))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package agemiddleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	jwtmiddleware "github.com/xw1nchester/kushfinds-backend/internal/auth/jwt/middleware"
+	"go.uber.org/zap"
+)
+
+type checkerFunc func(ctx context.Context, userID int) error
+
+func (f checkerFunc) CheckAge(ctx context.Context, userID int) error {
+	return f(ctx, userID)
+}
+
+func TestAgeMiddleware(t *testing.T) {
+	tests := []struct {
+		name               string
+		userID             *int
+		checkErr           error
+		expectedStatusCode int
+	}{
+		{
+			name:               "No user in context",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Age not verified",
+			userID:             ptr(1),
+			checkErr:           apperror.NewAppError("user.age_not_verified", "age verification required"),
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Unexpected error",
+			userID:             ptr(1),
+			checkErr:           errors.New("connection refused"),
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:               "Verified adult",
+			userID:             ptr(1),
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware := NewMiddleware(zap.NewNop(), checkerFunc(func(ctx context.Context, userID int) error {
+				return tt.checkErr
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/products", nil)
+			if tt.userID != nil {
+				req = req.WithContext(context.WithValue(req.Context(), jwtmiddleware.UserIDContextKey{}, *tt.userID))
+			}
+			rec := httptest.NewRecorder()
+
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+			})
+
+			middleware(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rec.Code)
+			assert.Equal(t, tt.expectedStatusCode == http.StatusOK, called)
+		})
+	}
+}
+
+func ptr(i int) *int {
+	return &i
+}
@@ -0,0 +1,53 @@
+package age
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+var (
+	ErrUnknownProvider        = errors.New("unknown age verification provider")
+	ErrFakeProviderNotAllowed = errors.New("fake age verification provider is allowed in local and test environments only")
+)
+
+// fakeProviderEnvs are the environments the fake provider may run in.
+var fakeProviderEnvs = []string{"local", "test"}
+
+type Request struct {
+	UserID      int
+	DateOfBirth string
+	// Document is the uploaded path of the ID document photo.
+	Document string
+}
+
+type Result struct {
+	IsVerified bool
+	// DateOfBirth is the date read from the document by the provider.
+	DateOfBirth string
+	Reference   string
+	Reason      string
+}
+
+// Provider checks an ID document and confirms the holder's date of birth.
+type Provider interface {
+	Name() string
+	Verify(ctx context.Context, req Request) (*Result, error)
+}
+
+// NewProvider returns the provider configured by name. Without a name age
+// verification is disabled. The fake provider approves any document, so it is
+// refused outside local and test environments.
+func NewProvider(name, env string) (Provider, error) {
+	switch name {
+	case "", DisabledProviderName:
+		return NewDisabled(), nil
+	case FakeProviderName:
+		if !slices.Contains(fakeProviderEnvs, env) {
+			return nil, ErrFakeProviderNotAllowed
+		}
+		return NewFake(), nil
+	default:
+		return nil, ErrUnknownProvider
+	}
+}
@@ -0,0 +1,37 @@
+package age
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      string
+		wantName string
+		wantErr  error
+	}{
+		{name: "", env: "prod", wantName: DisabledProviderName},
+		{name: DisabledProviderName, env: "dev", wantName: DisabledProviderName},
+		{name: FakeProviderName, env: "local", wantName: FakeProviderName},
+		{name: FakeProviderName, env: "test", wantName: FakeProviderName},
+		{name: FakeProviderName, env: "dev", wantErr: ErrFakeProviderNotAllowed},
+		{name: FakeProviderName, env: "prod", wantErr: ErrFakeProviderNotAllowed},
+		{name: "unknown", env: "local", wantErr: ErrUnknownProvider},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.env, func(t *testing.T) {
+			provider, err := NewProvider(tt.name, tt.env)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantName, provider.Name())
+		})
+	}
+}
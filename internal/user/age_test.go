@@ -0,0 +1,73 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xw1nchester/kushfinds-backend/internal/location/state"
+)
+
+func TestAgeAt(t *testing.T) {
+	date := func(value string) time.Time {
+		parsed, err := time.Parse(DateOfBirthLayout, value)
+		require.NoError(t, err)
+		return parsed
+	}
+
+	tests := []struct {
+		name        string
+		dateOfBirth string
+		now         string
+		expected    int
+	}{
+		{name: "day before birthday", dateOfBirth: "2005-10-19", now: "2026-10-18", expected: 20},
+		{name: "on birthday", dateOfBirth: "2005-10-18", now: "2026-10-18", expected: 21},
+		{name: "earlier month", dateOfBirth: "2005-11-01", now: "2026-10-18", expected: 20},
+		{name: "leap day in common year", dateOfBirth: "2004-02-29", now: "2025-02-28", expected: 20},
+		{name: "leap day after february", dateOfBirth: "2004-02-29", now: "2025-03-01", expected: 21},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, AgeAt(date(tt.dateOfBirth), date(tt.now)))
+		})
+	}
+}
+
+func TestUserIsOldEnough(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	dateOfBirth := "2007-06-01"
+
+	tests := []struct {
+		name     string
+		user     User
+		expected bool
+	}{
+		{
+			name:     "not verified",
+			user:     User{DateOfBirth: &dateOfBirth},
+			expected: false,
+		},
+		{
+			name:     "verified but under default minimum age",
+			user:     User{DateOfBirth: &dateOfBirth, AgeVerifiedAt: &now},
+			expected: false,
+		},
+		{
+			name: "verified and old enough for state",
+			user: User{
+				DateOfBirth:   &dateOfBirth,
+				AgeVerifiedAt: &now,
+				State:         &state.State{ID: 1, MinimumAge: 19},
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.user.IsOldEnough(tt.user.MinimumAge(21), now))
+		})
+	}
+}
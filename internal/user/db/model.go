@@ -1,6 +1,8 @@
 package db
 
 import (
+	"time"
+
 	"github.com/xw1nchester/kushfinds-backend/internal/location/country"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/region"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/state"
@@ -17,8 +19,10 @@ type User struct {
 	PasswordHash       *[]byte
 	IsVerified         bool
 	IsAdmin            bool
-	Age                *int
+	DateOfBirth        *string
+	AgeVerifiedAt      *time.Time
 	PhoneNumber        *string
+	Locale             *string
 	Country            *country.Country
 	State              *state.State
 	Region             *region.Region
@@ -39,7 +43,10 @@ type BusinessProfile struct {
 	Region           region.Region
 	Email            string
 	PhoneNumber      string
-	IsVerified       bool
+	Status           string
+	RejectionReason  *string
+	SubmittedAt      *time.Time
+	ReviewedAt       *time.Time
 }
 
 func (bp *BusinessProfile) ToDomain() *user.BusinessProfile {
@@ -48,16 +55,20 @@ func (bp *BusinessProfile) ToDomain() *user.BusinessProfile {
 	}
 
 	return &user.BusinessProfile{
+		UserID: bp.UserID,
 		BusinessIndustry: user.BusinessIndustry{
 			ID:   bp.BusinessIndustry.ID,
 			Name: bp.BusinessIndustry.Name,
 		},
-		BusinessName: bp.BusinessName,
-		Country:      bp.Country,
-		State:        bp.State,
-		Region:       bp.Region,
-		Email:        bp.Email,
-		PhoneNumber:  bp.PhoneNumber,
-		IsVerified:   bp.IsVerified,
+		BusinessName:    bp.BusinessName,
+		Country:         bp.Country,
+		State:           bp.State,
+		Region:          bp.Region,
+		Email:           bp.Email,
+		PhoneNumber:     bp.PhoneNumber,
+		Status:          bp.Status,
+		RejectionReason: bp.RejectionReason,
+		SubmittedAt:     bp.SubmittedAt,
+		ReviewedAt:      bp.ReviewedAt,
 	}
 }
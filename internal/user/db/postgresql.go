@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -38,12 +39,15 @@ func (r *repository) GetByID(ctx context.Context, id int) (*User, error) {
 			u.password_hash, 
 			u.is_verified, 
 			u.is_admin, 
-			u.age, 
+			to_char(u.date_of_birth, 'YYYY-MM-DD'),
+			u.age_verified_at,
 			u.phone_number,
+			u.locale,
 			c.id,
 			c.name,
 			s.id,
 			s.name,
+			s.minimum_age,
 			r.id,
 			r.name,
 			CASE 
@@ -58,13 +62,14 @@ func (r *repository) GetByID(ctx context.Context, id int) (*User, error) {
 		WHERE u.id=$1
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var existingUser User
 	var countryID *int
 	var countryName *string
 	var stateID *int
 	var stateName *string
+	var stateMinimumAge *int
 	var regionID *int
 	var regionName *string
 
@@ -78,12 +83,15 @@ func (r *repository) GetByID(ctx context.Context, id int) (*User, error) {
 		&existingUser.PasswordHash,
 		&existingUser.IsVerified,
 		&existingUser.IsAdmin,
-		&existingUser.Age,
+		&existingUser.DateOfBirth,
+		&existingUser.AgeVerifiedAt,
 		&existingUser.PhoneNumber,
+		&existingUser.Locale,
 		&countryID,
 		&countryName,
 		&stateID,
 		&stateName,
+		&stateMinimumAge,
 		&regionID,
 		&regionName,
 		&existingUser.HasBusinessProfile,
@@ -104,8 +112,9 @@ func (r *repository) GetByID(ctx context.Context, id int) (*User, error) {
 
 	if stateID != nil {
 		existingUser.State = &state.State{
-			ID:   *stateID,
-			Name: *stateName,
+			ID:         *stateID,
+			Name:       *stateName,
+			MinimumAge: *stateMinimumAge,
 		}
 	}
 
@@ -131,8 +140,10 @@ func (r *repository) GetByEmail(ctx context.Context, email string) (*User, error
 			u.password_hash, 
 			u.is_verified, 
 			u.is_admin, 
-			u.age, 
+			to_char(u.date_of_birth, 'YYYY-MM-DD'),
+			u.age_verified_at,
 			u.phone_number,
+			u.locale,
 			c.id,
 			c.name,
 			s.id,
@@ -146,7 +157,7 @@ func (r *repository) GetByEmail(ctx context.Context, email string) (*User, error
 		WHERE u.email=$1
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var existingUser User
 	var countryID *int
@@ -166,8 +177,10 @@ func (r *repository) GetByEmail(ctx context.Context, email string) (*User, error
 		&existingUser.PasswordHash,
 		&existingUser.IsVerified,
 		&existingUser.IsAdmin,
-		&existingUser.Age,
+		&existingUser.DateOfBirth,
+		&existingUser.AgeVerifiedAt,
 		&existingUser.PhoneNumber,
+		&existingUser.Locale,
 		&countryID,
 		&countryName,
 		&stateID,
@@ -213,7 +226,7 @@ func (r *repository) Create(ctx context.Context, email string) (int, error) {
         RETURNING id
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	executor := postgresql.GetExecutor(ctx, r.client)
 
@@ -232,7 +245,7 @@ func (r *repository) Verify(ctx context.Context, id int) (*User, error) {
 		WHERE id=$1
 	`
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	executor := postgresql.GetExecutor(ctx, r.client)
 
@@ -250,7 +263,7 @@ func (r *repository) CheckUsernameIsAvailable(ctx context.Context, username stri
 		WHERE username=$1
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var id int
 	err := r.client.QueryRow(ctx, query, username).Scan(&id)
@@ -267,7 +280,7 @@ func (r *repository) CheckUsernameIsAvailable(ctx context.Context, username stri
 func (r *repository) IsAdmin(ctx context.Context, userID int) (bool, error) {
 	const query = `SELECT is_admin FROM users WHERE id = $1`
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var isAdmin bool
 	err := r.client.QueryRow(ctx, query, userID).Scan(&isAdmin)
@@ -288,7 +301,7 @@ func (r *repository) SetProfileInfo(ctx context.Context, data User) (*User, erro
 		WHERE id=$4
 	`
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	_, err := r.client.Exec(
 		ctx,
@@ -312,7 +325,7 @@ func (r *repository) SetPassword(ctx context.Context, id int, passwordHash []byt
 		WHERE id=$2
 	`
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	_, err := r.client.Exec(ctx, query, passwordHash, id)
 
@@ -325,15 +338,16 @@ func (r *repository) UpdateProfile(ctx context.Context, data User) (*User, error
 		SET 
 			first_name=$2, 
 			last_name=$3, 
-			age=$4, 
+			date_of_birth=$4, 
 			phone_number=$5,
 			country_id=$6,
 			state_id=$7,
-			region_id=$8
+			region_id=$8,
+			locale=$9
 		WHERE id=$1
 	`
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var countryID *int
 	if data.Country != nil {
@@ -356,11 +370,12 @@ func (r *repository) UpdateProfile(ctx context.Context, data User) (*User, error
 		data.ID,
 		data.FirstName,
 		data.LastName,
-		data.Age,
+		data.DateOfBirth,
 		data.PhoneNumber,
 		countryID,
 		stateID,
 		regionID,
+		data.Locale,
 	); err != nil {
 		return nil, err
 	}
@@ -368,9 +383,42 @@ func (r *repository) UpdateProfile(ctx context.Context, data User) (*User, error
 	return r.GetByID(ctx, data.ID)
 }
 
-func (r *repository) GetUserBusinessProfile(ctx context.Context, userID int) (*BusinessProfile, error) {
+func (r *repository) SetAgeVerified(
+	ctx context.Context,
+	userID int,
+	dateOfBirth string,
+	provider string,
+	reference string,
+) (*User, error) {
 	query := `
+		UPDATE users
+		SET
+			date_of_birth=$2,
+			age_verified_at=NOW(),
+			age_verification_provider=$3,
+			age_verification_reference=$4
+		WHERE id=$1
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	if _, err := r.client.Exec(
+		ctx,
+		query,
+		userID,
+		dateOfBirth,
+		provider,
+		reference,
+	); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, userID)
+}
+
+const businessProfileQuery = `
         SELECT 
+			bp.user_id,
 			bi.id,
 			bi.name,
 			bp.business_name,
@@ -382,20 +430,22 @@ func (r *repository) GetUserBusinessProfile(ctx context.Context, userID int) (*B
 			r.name,
 			bp.email,
 			bp.phone_number,
-			bp.is_verified
+			bp.status,
+			bp.rejection_reason,
+			bp.submitted_at,
+			bp.reviewed_at
         FROM business_profiles bp
 		LEFT JOIN business_industries bi ON bp.business_industry_id = bi.id
 		LEFT JOIN countries c ON bp.country_id = c.id
 		LEFT JOIN states s ON bp.state_id = s.id
 		LEFT JOIN regions r ON bp.region_id = r.id
-		WHERE bp.user_id=$1
-    `
-
-	logging.LogSQLQuery(r.logger, query)
+`
 
+func scanBusinessProfile(row pgx.Row) (*BusinessProfile, error) {
 	var businessProfile BusinessProfile
 
-	if err := r.client.QueryRow(ctx, query, userID).Scan(
+	if err := row.Scan(
+		&businessProfile.UserID,
 		&businessProfile.BusinessIndustry.ID,
 		&businessProfile.BusinessIndustry.Name,
 		&businessProfile.BusinessName,
@@ -407,8 +457,24 @@ func (r *repository) GetUserBusinessProfile(ctx context.Context, userID int) (*B
 		&businessProfile.Region.Name,
 		&businessProfile.Email,
 		&businessProfile.PhoneNumber,
-		&businessProfile.IsVerified,
+		&businessProfile.Status,
+		&businessProfile.RejectionReason,
+		&businessProfile.SubmittedAt,
+		&businessProfile.ReviewedAt,
 	); err != nil {
+		return nil, err
+	}
+
+	return &businessProfile, nil
+}
+
+func (r *repository) GetUserBusinessProfile(ctx context.Context, userID int) (*BusinessProfile, error) {
+	query := businessProfileQuery + " WHERE bp.user_id=$1"
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	businessProfile, err := scanBusinessProfile(postgresql.GetExecutor(ctx, r.client).QueryRow(ctx, query, userID))
+	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrBusinessProfileNotFound
 		}
@@ -416,13 +482,46 @@ func (r *repository) GetUserBusinessProfile(ctx context.Context, userID int) (*B
 		return nil, err
 	}
 
-	return &businessProfile, nil
+	return businessProfile, nil
 }
 
+func (r *repository) GetBusinessProfiles(ctx context.Context, status string) ([]BusinessProfile, error) {
+	query := businessProfileQuery + `
+		WHERE $1='' OR bp.status::text=$1
+		ORDER BY bp.submitted_at NULLS LAST, bp.user_id
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	rows, err := r.client.Query(ctx, query, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	businessProfiles := make([]BusinessProfile, 0)
+
+	for rows.Next() {
+		businessProfile, err := scanBusinessProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+
+		businessProfiles = append(businessProfiles, *businessProfile)
+	}
+
+	return businessProfiles, rows.Err()
+}
+
+// UpdateBusinessProfile upserts the profile data. Moving a profile to pending
+// clears the previous decision so that it lands in the moderation queue again,
+// a draft profile stays out of the queue.
+// The profile creator becomes the owner of the business team.
 func (r *repository) UpdateBusinessProfile(ctx context.Context, data BusinessProfile) (*BusinessProfile, error) {
 	query := `
-        INSERT INTO business_profiles (user_id, business_industry_id, business_name, country_id, state_id, region_id, email, phone_number, is_verified)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		WITH profile AS (
+        INSERT INTO business_profiles (user_id, business_industry_id, business_name, country_id, state_id, region_id, email, phone_number, status, submitted_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, CASE WHEN $9='pending' THEN CURRENT_TIMESTAMP END)
 		ON CONFLICT (user_id)
 		DO UPDATE SET
 			business_industry_id = EXCLUDED.business_industry_id,
@@ -432,12 +531,21 @@ func (r *repository) UpdateBusinessProfile(ctx context.Context, data BusinessPro
 			region_id = EXCLUDED.region_id,
 			email = EXCLUDED.email,
 			phone_number = EXCLUDED.phone_number,
-			is_verified = EXCLUDED.is_verified;
+			status = EXCLUDED.status,
+			submitted_at = CASE WHEN EXCLUDED.status='pending' THEN CURRENT_TIMESTAMP ELSE business_profiles.submitted_at END,
+			rejection_reason = CASE WHEN EXCLUDED.status='pending' THEN NULL ELSE business_profiles.rejection_reason END,
+			moderator_id = CASE WHEN EXCLUDED.status='pending' THEN NULL ELSE business_profiles.moderator_id END,
+			reviewed_at = CASE WHEN EXCLUDED.status='pending' THEN NULL ELSE business_profiles.reviewed_at END
+		RETURNING user_id
+		)
+		INSERT INTO team_members (business_id, user_id, role)
+		SELECT user_id, user_id, 'owner' FROM profile
+		ON CONFLICT DO NOTHING;
     `
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
-	if _, err := r.client.Exec(
+	if _, err := postgresql.GetExecutor(ctx, r.client).Exec(
 		ctx,
 		query,
 		data.UserID,
@@ -448,7 +556,7 @@ func (r *repository) UpdateBusinessProfile(ctx context.Context, data BusinessPro
 		data.Region.ID,
 		data.Email,
 		data.PhoneNumber,
-		data.IsVerified,
+		data.Status,
 	); err != nil {
 		return nil, err
 	}
@@ -456,6 +564,59 @@ func (r *repository) UpdateBusinessProfile(ctx context.Context, data BusinessPro
 	return r.GetUserBusinessProfile(ctx, data.UserID)
 }
 
+// SubmitBusinessProfile sends a draft profile for review.
+// ErrBusinessProfileNotFound is returned if there is no draft profile.
+func (r *repository) SubmitBusinessProfile(ctx context.Context, userID int) (*BusinessProfile, error) {
+	query := `
+		UPDATE business_profiles
+		SET status='pending', submitted_at=CURRENT_TIMESTAMP
+		WHERE user_id=$1 AND status='draft'
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return nil, ErrBusinessProfileNotFound
+	}
+
+	return r.GetUserBusinessProfile(ctx, userID)
+}
+
+// SetBusinessProfileStatus records a moderation decision. Only pending
+// profiles can be moderated, so a decision racing with an owner update
+// or with another moderator returns ErrBusinessProfileNotFound.
+func (r *repository) SetBusinessProfileStatus(
+	ctx context.Context,
+	userID int,
+	status string,
+	rejectionReason *string,
+	moderatorID int,
+) (*BusinessProfile, error) {
+	query := `
+		UPDATE business_profiles
+		SET status=$2, rejection_reason=$3, moderator_id=$4, reviewed_at=CURRENT_TIMESTAMP
+		WHERE user_id=$1 AND status='pending'
+	`
+
+	logging.LogSQLQuery(ctx, r.logger, query)
+
+	tag, err := postgresql.GetExecutor(ctx, r.client).Exec(ctx, query, userID, status, rejectionReason, moderatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return nil, ErrBusinessProfileNotFound
+	}
+
+	return r.GetUserBusinessProfile(ctx, userID)
+}
+
 func (r *repository) CheckBusinessProfileExists(
 	ctx context.Context,
 	userID int,
@@ -467,10 +628,10 @@ func (r *repository) CheckBusinessProfileExists(
     `
 
 	if requireVerified {
-		query += " AND is_verified=true"
+		query += " AND status='approved'"
 	}
 
-	logging.LogSQLQuery(r.logger, query)
+	logging.LogSQLQuery(ctx, r.logger, query)
 
 	var id int
 	err := r.client.QueryRow(ctx, query, userID).Scan(&id)
@@ -14,18 +14,30 @@ import (
 	"github.com/xw1nchester/kushfinds-backend/internal/location/country"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/region"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/state"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
 	"github.com/xw1nchester/kushfinds-backend/internal/user"
 	"go.uber.org/zap"
 )
 
-var validate = validator.New()
+var validate = apperror.NewValidator()
 
 type Service interface {
 	GetByID(ctx context.Context, id int) (*user.User, error)
 	UpdateProfile(ctx context.Context, data user.User) (*user.User, error)
+	VerifyAge(ctx context.Context, userID int, dateOfBirth, document string) (*user.User, error)
 	GetUserBusinessProfile(ctx context.Context, userID int) (*user.BusinessProfile, error)
 	UpdateBusinessProfile(ctx context.Context, data user.BusinessProfile) (*user.BusinessProfile, error)
+	SubmitBusinessProfile(ctx context.Context, userID int) (*user.BusinessProfile, error)
 	AdminUpdateBusinessProfile(ctx context.Context, adminID int, data user.BusinessProfile) (*user.BusinessProfile, error)
+	GetBusinessProfiles(ctx context.Context, adminID int, filter user.BusinessProfileFilter) ([]user.BusinessProfile, error)
+	GetBusinessProfile(ctx context.Context, adminID, userID int) (*user.BusinessProfile, error)
+	ModerateBusinessProfile(
+		ctx context.Context,
+		adminID int,
+		userID int,
+		action string,
+		reason string,
+	) (*user.BusinessProfile, error)
 }
 
 type handler struct {
@@ -49,10 +61,12 @@ func (h *handler) Register(router chi.Router) {
 
 			privateUserRouter.Get("/me", apperror.Middleware(h.userHandler))
 			privateUserRouter.Patch("/profile", apperror.Middleware(h.updateProfileHandler))
+			privateUserRouter.Post("/age-verification", apperror.Middleware(h.verifyAgeHandler))
 
 			privateUserRouter.Route("/business", func(businessRouter chi.Router) {
 				businessRouter.Get("/", apperror.Middleware(h.getBusinessProfileHandler))
 				businessRouter.Patch("/", apperror.Middleware(h.updateBusinessProfileHandler))
+				businessRouter.Post("/submit", apperror.Middleware(h.submitBusinessProfileHandler))
 			})
 		})
 	})
@@ -63,6 +77,14 @@ func (h *handler) Register(router chi.Router) {
 
 		adminUserRouter.Patch("/{user_id}/business", apperror.Middleware(h.adminUpdateBusinessProfileHandler))
 	})
+
+	router.Route("/admin/business-profiles", func(adminBusinessRouter chi.Router) {
+		adminBusinessRouter.Use(h.authMiddleware)
+
+		adminBusinessRouter.Get("/", apperror.Middleware(h.getBusinessProfilesHandler))
+		adminBusinessRouter.Get("/{user_id}", apperror.Middleware(h.adminGetBusinessProfileHandler))
+		adminBusinessRouter.Patch("/{user_id}", apperror.Middleware(h.moderateBusinessProfileHandler))
+	})
 }
 
 // @Security	ApiKeyAuth
@@ -92,7 +114,7 @@ func (h *handler) userHandler(w http.ResponseWriter, r *http.Request) error {
 func (h *handler) updateProfileHandler(w http.ResponseWriter, r *http.Request) error {
 	var dto ProfileRequest
 	if err := render.DecodeJSON(r.Body, &dto); err != nil {
-		h.logger.Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
 		return apperror.ErrDecodeBody
 	}
 
@@ -129,8 +151,9 @@ func (h *handler) updateProfileHandler(w http.ResponseWriter, r *http.Request) e
 			ID:          userID,
 			FirstName:   dto.FirstName,
 			LastName:    dto.LastName,
-			Age:         (*int)(dto.Age),
+			DateOfBirth: dto.DateOfBirth,
 			PhoneNumber: dto.PhoneNumber,
+			Locale:      dto.Locale,
 			Country:     countryData,
 			State:       stateData,
 			Region:      regionData,
@@ -145,6 +168,35 @@ func (h *handler) updateProfileHandler(w http.ResponseWriter, r *http.Request) e
 	return nil
 }
 
+// @Security	ApiKeyAuth
+// @Tags		users
+// @Param		request	body		AgeVerificationRequest	true	"request body"
+// @Success	200		{object}	user.UserResponse
+// @Failure	400,500,503	{object}	apperror.AppError
+// @Router		/users/age-verification [post]
+func (h *handler) verifyAgeHandler(w http.ResponseWriter, r *http.Request) error {
+	var dto AgeVerificationRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	verifiedUser, err := h.service.VerifyAge(r.Context(), userID, dto.DateOfBirth, dto.Document)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, user.UserResponse{User: *verifiedUser})
+
+	return nil
+}
+
 // @Security	ApiKeyAuth
 // @Tags		users
 // @Success	200		{object}	user.BusinessProfileResponse
@@ -172,7 +224,7 @@ func (h *handler) getBusinessProfileHandler(w http.ResponseWriter, r *http.Reque
 func (h *handler) updateBusinessProfileHandler(w http.ResponseWriter, r *http.Request) error {
 	var dto BusinessProfileRequest
 	if err := render.DecodeJSON(r.Body, &dto); err != nil {
-		h.logger.Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
 		return apperror.ErrDecodeBody
 	}
 
@@ -212,21 +264,39 @@ func (h *handler) updateBusinessProfileHandler(w http.ResponseWriter, r *http.Re
 	return nil
 }
 
+// @Security	ApiKeyAuth
+// @Tags		users
+// @Success	200		{object}	user.BusinessProfileResponse
+// @Failure	400,404,500	{object}	apperror.AppError
+// @Router		/users/business/submit [post]
+func (h *handler) submitBusinessProfileHandler(w http.ResponseWriter, r *http.Request) error {
+	userID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	businessProfile, err := h.service.SubmitBusinessProfile(r.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, user.BusinessProfileResponse{BusinessProfile: businessProfile})
+
+	return nil
+}
+
 // @Security	ApiKeyAuth
 // @Tags		admin users
-// @Param		request	body		AdminBusinessProfileRequest	true	"request body"
+// @Param		request	body		BusinessProfileRequest	true	"request body"
 // @Success	200		{object}	user.BusinessProfileResponse
 // @Failure	400,500	{object}	apperror.AppError
 // @Router		/admin/users/{user_id}/business [patch]
 func (h *handler) adminUpdateBusinessProfileHandler(w http.ResponseWriter, r *http.Request) error {
 	userID, err := strconv.Atoi(chi.URLParam(r, "user_id"))
 	if err != nil {
-		return apperror.NewAppError("user_id should be positive integer")
+		return apperror.NewFieldError("user_id", "field.positive_integer")
 	}
 
-	var dto AdminBusinessProfileRequest
+	var dto BusinessProfileRequest
 	if err := render.DecodeJSON(r.Body, &dto); err != nil {
-		h.logger.Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
 		return apperror.ErrDecodeBody
 	}
 
@@ -256,7 +326,6 @@ func (h *handler) adminUpdateBusinessProfileHandler(w http.ResponseWriter, r *ht
 			},
 			Email:       dto.Email,
 			PhoneNumber: dto.PhoneNumber,
-			IsVerified:  *dto.IsVerified,
 		},
 	)
 	if err != nil {
@@ -269,3 +338,99 @@ func (h *handler) adminUpdateBusinessProfileHandler(w http.ResponseWriter, r *ht
 }
 
 // TODO: set avatar
+
+// @Security	ApiKeyAuth
+// @Tags		admin business profiles
+// @Param		status	query		string	false	"profile status, pending by default"
+// @Success	200		{object}	AdminBusinessProfilesResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/admin/business-profiles [get]
+func (h *handler) getBusinessProfilesHandler(w http.ResponseWriter, r *http.Request) error {
+	filter := user.BusinessProfileFilter{Status: r.URL.Query().Get("status")}
+	switch filter.Status {
+	case "":
+		filter.Status = user.BusinessProfileStatusPending
+	case "all":
+		filter.Status = ""
+	case user.BusinessProfileStatusDraft,
+		user.BusinessProfileStatusPending,
+		user.BusinessProfileStatusApproved,
+		user.BusinessProfileStatusRejected:
+	default:
+		return apperror.NewFieldError("status", "field.one_of", "draft, pending, approved, rejected, all")
+	}
+
+	adminID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	businessProfiles, err := h.service.GetBusinessProfiles(r.Context(), adminID, filter)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, NewAdminBusinessProfilesResponse(businessProfiles))
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		admin business profiles
+// @Success	200		{object}	AdminBusinessProfileResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/admin/business-profiles/{user_id} [get]
+func (h *handler) adminGetBusinessProfileHandler(w http.ResponseWriter, r *http.Request) error {
+	userID, err := strconv.Atoi(chi.URLParam(r, "user_id"))
+	if err != nil {
+		return apperror.NewFieldError("user_id", "field.positive_integer")
+	}
+
+	adminID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	businessProfile, err := h.service.GetBusinessProfile(r.Context(), adminID, userID)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, AdminBusinessProfileResponse{BusinessProfile: NewAdminBusinessProfile(*businessProfile)})
+
+	return nil
+}
+
+// @Security	ApiKeyAuth
+// @Tags		admin business profiles
+// @Param		request	body		BusinessProfileModerationRequest	true	"request body"
+// @Success	200		{object}	AdminBusinessProfileResponse
+// @Failure	400,500	{object}	apperror.AppError
+// @Router		/admin/business-profiles/{user_id} [patch]
+func (h *handler) moderateBusinessProfileHandler(w http.ResponseWriter, r *http.Request) error {
+	userID, err := strconv.Atoi(chi.URLParam(r, "user_id"))
+	if err != nil {
+		return apperror.NewFieldError("user_id", "field.positive_integer")
+	}
+
+	var dto BusinessProfileModerationRequest
+	if err := render.DecodeJSON(r.Body, &dto); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error(apperror.ErrDecodeBody.Error(), zap.Error(err))
+		return apperror.ErrDecodeBody
+	}
+
+	if err := validate.Struct(dto); err != nil {
+		return apperror.NewValidationErr(err.(validator.ValidationErrors))
+	}
+
+	adminID := r.Context().Value(jwtmiddleware.UserIDContextKey{}).(int)
+
+	businessProfile, err := h.service.ModerateBusinessProfile(
+		r.Context(),
+		adminID,
+		userID,
+		dto.Action,
+		dto.Reason,
+	)
+	if err != nil {
+		return err
+	}
+
+	render.JSON(w, r, AdminBusinessProfileResponse{BusinessProfile: NewAdminBusinessProfile(*businessProfile)})
+
+	return nil
+}
@@ -1,15 +1,19 @@
 package handler
 
-import "github.com/xw1nchester/kushfinds-backend/pkg/types"
+import (
+	"github.com/xw1nchester/kushfinds-backend/internal/user"
+	"github.com/xw1nchester/kushfinds-backend/pkg/types"
+)
 
 type ProfileRequest struct {
 	FirstName   *string            `json:"firstName" validate:"omitempty,min=3,max=30"`
 	LastName    *string            `json:"lastName" validate:"omitempty,min=3,max=30"`
-	Age         *types.IntOrString `json:"age" validate:"omitempty,gt=0"`
+	DateOfBirth *string            `json:"dateOfBirth" validate:"omitempty,datetime=2006-01-02"`
 	PhoneNumber *string            `json:"phoneNumber" validate:"omitempty"`
 	CountryID   *types.IntOrString `json:"countryId" validate:"omitempty"`
 	StateID     *types.IntOrString `json:"stateId" validate:"omitempty"`
 	RegionID    *types.IntOrString `json:"regionId" validate:"omitempty"`
+	Locale      *string            `json:"locale" validate:"omitempty,oneof=en ru"`
 }
 
 type BusinessProfileRequest struct {
@@ -22,7 +26,37 @@ type BusinessProfileRequest struct {
 	PhoneNumber        string            `json:"phoneNumber" validate:"required"`
 }
 
-type AdminBusinessProfileRequest struct {
-	BusinessProfileRequest
-	IsVerified *bool `json:"isVerified" validate:"required"`
+type BusinessProfileModerationRequest struct {
+	Action string `json:"action" validate:"required,oneof=approve reject"`
+	Reason string `json:"reason" validate:"required_if=Action reject,max=500"`
+}
+
+type AdminBusinessProfile struct {
+	UserID int `json:"userId"`
+	user.BusinessProfile
+}
+
+type AdminBusinessProfileResponse struct {
+	BusinessProfile AdminBusinessProfile `json:"businessProfile"`
+}
+
+type AdminBusinessProfilesResponse struct {
+	BusinessProfiles []AdminBusinessProfile `json:"businessProfiles"`
+}
+
+func NewAdminBusinessProfile(bp user.BusinessProfile) AdminBusinessProfile {
+	return AdminBusinessProfile{UserID: bp.UserID, BusinessProfile: bp}
+}
+
+func NewAdminBusinessProfilesResponse(businessProfiles []user.BusinessProfile) AdminBusinessProfilesResponse {
+	result := make([]AdminBusinessProfile, len(businessProfiles))
+	for i, bp := range businessProfiles {
+		result[i] = NewAdminBusinessProfile(bp)
+	}
+	return AdminBusinessProfilesResponse{BusinessProfiles: result}
+}
+
+type AgeVerificationRequest struct {
+	DateOfBirth string `json:"dateOfBirth" validate:"required,datetime=2006-01-02"`
+	Document    string `json:"document" validate:"required"`
 }
@@ -1,6 +1,8 @@
 package user
 
 import (
+	"time"
+
 	"github.com/xw1nchester/kushfinds-backend/internal/location/country"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/region"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/state"
@@ -17,8 +19,10 @@ type User struct {
 	PasswordHash       *[]byte          `json:"-"`
 	IsPasswordSet      bool             `json:"isPasswordSet"`
 	IsAdmin            bool             `json:"isAdmin"`
-	Age                *int             `json:"age"`
+	DateOfBirth        *string          `json:"dateOfBirth"`
+	AgeVerifiedAt      *time.Time       `json:"ageVerifiedAt"`
 	PhoneNumber        *string          `json:"phoneNumber"`
+	Locale             *string          `json:"locale"`
 	Country            *country.Country `json:"country"`
 	State              *state.State     `json:"state"`
 	Region             *region.Region   `json:"region"`
@@ -34,6 +38,13 @@ type BusinessIndustry struct {
 	Name string `json:"name"`
 }
 
+const (
+	BusinessProfileStatusDraft    = "draft"
+	BusinessProfileStatusPending  = "pending"
+	BusinessProfileStatusApproved = "approved"
+	BusinessProfileStatusRejected = "rejected"
+)
+
 type BusinessProfile struct {
 	UserID           int              `json:"-"`
 	BusinessIndustry BusinessIndustry `json:"businessIndustry"`
@@ -43,7 +54,14 @@ type BusinessProfile struct {
 	Region           region.Region    `json:"region"`
 	Email            string           `json:"email"`
 	PhoneNumber      string           `json:"phoneNumber"`
-	IsVerified       bool             `json:"isVerified"`
+	Status           string           `json:"status"`
+	RejectionReason  *string          `json:"rejectionReason"`
+	SubmittedAt      *time.Time       `json:"submittedAt"`
+	ReviewedAt       *time.Time       `json:"reviewedAt"`
+}
+
+type BusinessProfileFilter struct {
+	Status string
 }
 
 type BusinessProfileResponse struct {
@@ -3,19 +3,34 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/xw1nchester/kushfinds-backend/internal/apperror"
+	"github.com/xw1nchester/kushfinds-backend/internal/audit"
+	"github.com/xw1nchester/kushfinds-backend/internal/i18n"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/country"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/region"
 	"github.com/xw1nchester/kushfinds-backend/internal/location/state"
+	"github.com/xw1nchester/kushfinds-backend/internal/logging"
 	"github.com/xw1nchester/kushfinds-backend/internal/market/industry"
+	"github.com/xw1nchester/kushfinds-backend/internal/tracing"
 	"github.com/xw1nchester/kushfinds-backend/internal/user"
+	"github.com/xw1nchester/kushfinds-backend/internal/user/age"
 	"github.com/xw1nchester/kushfinds-backend/internal/user/db"
+	"github.com/xw1nchester/kushfinds-backend/pkg/transactor"
 	"go.uber.org/zap"
 )
 
 var (
-	ErrBusinessProfileNotFound = apperror.NewAppError("business profile not found")
+	ErrBusinessProfileNotFound = apperror.NewAppError("user.business_profile_not_found", "business profile not found")
+	ErrInvalidDateOfBirth      = apperror.NewAppError("user.invalid_date_of_birth", "date of birth should be a past date")
+	ErrDateOfBirthLocked       = apperror.NewAppError("user.date_of_birth_locked", "date of birth can not be changed after age verification")
+	ErrAgeAlreadyVerified      = apperror.NewAppError("user.age_already_verified", "age is already verified")
+	ErrAgeNotVerified          = apperror.NewAppError("user.age_not_verified", "age verification required")
+	ErrUnderage                = apperror.NewAppError("user.underage", "you are under the minimum age for your state")
+	ErrBusinessProfileReviewed = apperror.NewAppError("user.business_profile_not_pending_review", "business profile is not pending review")
+	ErrBusinessProfileNotDraft = apperror.NewAppError("user.business_profile_not_draft", "business profile is already submitted")
+	ErrRejectionReasonRequired = apperror.NewAppError("user.rejection_reason_required", "rejection reason is required")
 )
 
 type Repository interface {
@@ -29,8 +44,18 @@ type Repository interface {
 	SetPassword(ctx context.Context, id int, passwordHash []byte) error
 	UpdateProfile(ctx context.Context, user db.User) (*db.User, error)
 	GetUserBusinessProfile(ctx context.Context, userID int) (*db.BusinessProfile, error)
+	GetBusinessProfiles(ctx context.Context, status string) ([]db.BusinessProfile, error)
 	UpdateBusinessProfile(ctx context.Context, data db.BusinessProfile) (*db.BusinessProfile, error)
+	SubmitBusinessProfile(ctx context.Context, userID int) (*db.BusinessProfile, error)
+	SetBusinessProfileStatus(
+		ctx context.Context,
+		userID int,
+		status string,
+		rejectionReason *string,
+		moderatorID int,
+	) (*db.BusinessProfile, error)
 	CheckBusinessProfileExists(ctx context.Context, userID int, requireVerified bool) error
+	SetAgeVerified(ctx context.Context, userID int, dateOfBirth, provider, reference string) (*db.User, error)
 }
 
 type IndustryService interface {
@@ -50,13 +75,26 @@ type RegionService interface {
 	CheckLocationExists(ctx context.Context, regionID, stateID, countryID int) error
 }
 
+type MailManager interface {
+	SendMail(subject string, body string, to []string) error
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, record audit.Record) error
+}
+
 type service struct {
-	repository      Repository
-	industryService IndustryService
-	countryService  CountryService
-	stateService    StateService
-	regionService   RegionService
-	logger          *zap.Logger
+	repository        Repository
+	industryService   IndustryService
+	countryService    CountryService
+	stateService      StateService
+	regionService     RegionService
+	ageProvider       age.Provider
+	defaultMinimumAge int
+	mailManager       MailManager
+	txManager         transactor.Manager
+	auditRecorder     AuditRecorder
+	logger            *zap.Logger
 }
 
 func New(
@@ -65,15 +103,25 @@ func New(
 	countryService CountryService,
 	stateService StateService,
 	regionService RegionService,
+	ageProvider age.Provider,
+	defaultMinimumAge int,
+	mailManager MailManager,
+	txManager transactor.Manager,
+	auditRecorder AuditRecorder,
 	logger *zap.Logger,
 ) *service {
 	return &service{
-		repository:      repository,
-		industryService: industryService,
-		countryService:  countryService,
-		stateService:    stateService,
-		regionService:   regionService,
-		logger:          logger,
+		repository:        repository,
+		industryService:   industryService,
+		countryService:    countryService,
+		stateService:      stateService,
+		regionService:     regionService,
+		ageProvider:       ageProvider,
+		defaultMinimumAge: defaultMinimumAge,
+		mailManager:       mailManager,
+		txManager:         txManager,
+		auditRecorder:     auditRecorder,
+		logger:            logger,
 	}
 }
 
@@ -90,8 +138,10 @@ func createUserDto(data *db.User) *user.User {
 		PasswordHash:       data.PasswordHash,
 		IsPasswordSet:      data.PasswordHash != nil,
 		IsAdmin:            data.IsAdmin,
-		Age:                data.Age,
+		DateOfBirth:        data.DateOfBirth,
+		AgeVerifiedAt:      data.AgeVerifiedAt,
 		PhoneNumber:        data.PhoneNumber,
+		Locale:             data.Locale,
 		Country:            data.Country,
 		State:              data.State,
 		Region:             data.Region,
@@ -100,13 +150,16 @@ func createUserDto(data *db.User) *user.User {
 }
 
 func (s *service) GetByID(ctx context.Context, id int) (*user.User, error) {
+	ctx, span := tracing.Start(ctx, "userservice.GetByID")
+	defer span.End()
+
 	existingUser, err := s.repository.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, db.ErrUserNotFound) {
 			return nil, apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when fetching user by id", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching user by id", zap.Error(err))
 
 		return nil, err
 	}
@@ -121,7 +174,7 @@ func (s *service) GetByEmail(ctx context.Context, email string) (*user.User, err
 			return nil, apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when fetching user by email", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching user by email", zap.Error(err))
 
 		return nil, err
 	}
@@ -136,13 +189,14 @@ func (s *service) GetByEmail(ctx context.Context, email string) (*user.User, err
 		IsVerified:    existingUser.IsVerified,
 		PasswordHash:  existingUser.PasswordHash,
 		IsPasswordSet: existingUser.PasswordHash != nil,
+		Locale:        existingUser.Locale,
 	}, nil
 }
 
 func (s *service) Create(ctx context.Context, email string) (int, error) {
 	userID, err := s.repository.Create(ctx, email)
 	if err != nil {
-		s.logger.Error("unexpected error when creating user", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when creating user", zap.Error(err))
 		return 0, err
 	}
 
@@ -152,7 +206,7 @@ func (s *service) Create(ctx context.Context, email string) (int, error) {
 func (s *service) Verify(ctx context.Context, id int) (*user.User, error) {
 	existingUser, err := s.repository.Verify(ctx, id)
 	if err != nil {
-		s.logger.Error("unexpected error when verifying user", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when verifying user", zap.Error(err))
 		return nil, err
 	}
 
@@ -176,7 +230,7 @@ func (s *service) CheckUsernameIsAvailable(ctx context.Context, username string)
 			return isAvailable, apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when fetching user by username", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching user by username", zap.Error(err))
 	}
 
 	return isAvailable, err
@@ -193,7 +247,7 @@ func (s *service) SetProfileInfo(ctx context.Context, data *user.User) (*user.Us
 		},
 	)
 	if err != nil {
-		s.logger.Error("unexpected error when setting user profile", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when setting user profile", zap.Error(err))
 		return nil, err
 	}
 
@@ -212,7 +266,7 @@ func (s *service) SetProfileInfo(ctx context.Context, data *user.User) (*user.Us
 
 func (s *service) SetPassword(ctx context.Context, id int, passwordHash []byte) error {
 	if err := s.repository.SetPassword(ctx, id, passwordHash); err != nil {
-		s.logger.Error("unexpected error when set user password", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when set user password", zap.Error(err))
 		return err
 	}
 
@@ -221,11 +275,33 @@ func (s *service) SetPassword(ctx context.Context, id int, passwordHash []byte)
 
 func (s *service) UpdateProfile(ctx context.Context, data user.User) (*user.User, error) {
 	if data.Country == nil && (data.State != nil || data.Region != nil) {
-		return nil, apperror.NewAppError("country should not be empty")
+		return nil, apperror.NewFieldError("country", "field.required")
 	}
 
 	if data.State == nil && data.Region != nil {
-		return nil, apperror.NewAppError("state should not be empty")
+		return nil, apperror.NewFieldError("state", "field.required")
+	}
+
+	existingUser, err := s.GetByID(ctx, data.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingUser.IsAgeVerified() {
+		if data.DateOfBirth == nil {
+			data.DateOfBirth = existingUser.DateOfBirth
+		} else if *data.DateOfBirth != *existingUser.DateOfBirth {
+			return nil, ErrDateOfBirthLocked
+		}
+	} else if data.DateOfBirth != nil {
+		if err := validateDateOfBirth(*data.DateOfBirth); err != nil {
+			return nil, err
+		}
+	}
+
+	// locale is a preference set separately from the profile form, keep it when omitted
+	if data.Locale == nil {
+		data.Locale = existingUser.Locale
 	}
 
 	if data.Country != nil {
@@ -252,21 +328,99 @@ func (s *service) UpdateProfile(ctx context.Context, data user.User) (*user.User
 			ID:          data.ID,
 			FirstName:   data.FirstName,
 			LastName:    data.LastName,
-			Age:         data.Age,
+			DateOfBirth: data.DateOfBirth,
 			PhoneNumber: data.PhoneNumber,
+			Locale:      data.Locale,
 			Country:     data.Country,
 			State:       data.State,
 			Region:      data.Region,
 		},
 	)
 	if err != nil {
-		s.logger.Error("unexpected error when updating user profile", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when updating user profile", zap.Error(err))
+		return nil, err
+	}
+
+	return createUserDto(updatedUser), nil
+}
+
+func validateDateOfBirth(value string) error {
+	dateOfBirth, err := time.Parse(user.DateOfBirthLayout, value)
+	if err != nil || !dateOfBirth.Before(time.Now()) {
+		return ErrInvalidDateOfBirth
+	}
+
+	return nil
+}
+
+func (s *service) VerifyAge(ctx context.Context, userID int, dateOfBirth, document string) (*user.User, error) {
+	existingUser, err := s.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingUser.IsAgeVerified() {
+		return nil, ErrAgeAlreadyVerified
+	}
+
+	if err := validateDateOfBirth(dateOfBirth); err != nil {
+		return nil, err
+	}
+
+	result, err := s.ageProvider.Verify(ctx, age.Request{
+		UserID:      userID,
+		DateOfBirth: dateOfBirth,
+		Document:    document,
+	})
+	if err != nil {
+		if errors.Is(err, age.ErrVerificationUnavailable) {
+			return nil, err
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when verifying user age", zap.Error(err))
+		return nil, err
+	}
+
+	if !result.IsVerified {
+		return nil, apperror.NewAppErrorf("user.age_verification_failed", "age verification failed: %s", result.Reason)
+	}
+
+	if result.DateOfBirth != "" {
+		dateOfBirth = result.DateOfBirth
+	}
+
+	updatedUser, err := s.repository.SetAgeVerified(
+		ctx,
+		userID,
+		dateOfBirth,
+		s.ageProvider.Name(),
+		result.Reference,
+	)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when saving user age verification", zap.Error(err))
 		return nil, err
 	}
 
 	return createUserDto(updatedUser), nil
 }
 
+func (s *service) CheckAge(ctx context.Context, userID int) error {
+	existingUser, err := s.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !existingUser.IsAgeVerified() {
+		return ErrAgeNotVerified
+	}
+
+	if !existingUser.IsOldEnough(existingUser.MinimumAge(s.defaultMinimumAge), time.Now()) {
+		return ErrUnderage
+	}
+
+	return nil
+}
+
 func (s *service) GetUserBusinessProfile(ctx context.Context, userID int) (*user.BusinessProfile, error) {
 	businessProfile, err := s.repository.GetUserBusinessProfile(ctx, userID)
 	if err != nil {
@@ -274,7 +428,7 @@ func (s *service) GetUserBusinessProfile(ctx context.Context, userID int) (*user
 			return nil, nil
 		}
 
-		s.logger.Error("unexpected error when fetching business profile", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching business profile", zap.Error(err))
 		return nil, err
 	}
 
@@ -299,10 +453,25 @@ func (s *service) validateBusinessProfileData(ctx context.Context, data user.Bus
 }
 
 func (s *service) UpdateBusinessProfile(ctx context.Context, data user.BusinessProfile) (*user.BusinessProfile, error) {
+	ctx, span := tracing.Start(ctx, "userservice.UpdateBusinessProfile")
+	defer span.End()
+
 	if err := s.validateBusinessProfileData(ctx, data); err != nil {
 		return nil, err
 	}
 
+	existingProfile, err := s.GetUserBusinessProfile(ctx, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// new profiles are drafts until submitted, changes to a submitted
+	// profile send it for review again
+	status := user.BusinessProfileStatusPending
+	if existingProfile == nil || existingProfile.Status == user.BusinessProfileStatusDraft {
+		status = user.BusinessProfileStatusDraft
+	}
+
 	businessProfile, err := s.repository.UpdateBusinessProfile(
 		ctx,
 		db.BusinessProfile{
@@ -322,11 +491,37 @@ func (s *service) UpdateBusinessProfile(ctx context.Context, data user.BusinessP
 			},
 			Email:       data.Email,
 			PhoneNumber: data.PhoneNumber,
-			IsVerified:  false,
+			Status:      status,
 		},
 	)
 	if err != nil {
-		s.logger.Error("unexpected error when updating business profile", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when updating business profile", zap.Error(err))
+		return nil, err
+	}
+
+	return businessProfile.ToDomain(), nil
+}
+
+func (s *service) SubmitBusinessProfile(ctx context.Context, userID int) (*user.BusinessProfile, error) {
+	ctx, span := tracing.Start(ctx, "userservice.SubmitBusinessProfile")
+	defer span.End()
+
+	existingProfile, err := s.getBusinessProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingProfile.Status != user.BusinessProfileStatusDraft {
+		return nil, ErrBusinessProfileNotDraft
+	}
+
+	businessProfile, err := s.repository.SubmitBusinessProfile(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrBusinessProfileNotFound) {
+			return nil, ErrBusinessProfileNotDraft
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when submitting business profile", zap.Error(err))
 		return nil, err
 	}
 
@@ -338,16 +533,38 @@ func (s *service) CheckBusinessProfileExists(
 	userID int,
 	requireVerified bool,
 ) error {
+	ctx, span := tracing.Start(ctx, "userservice.CheckBusinessProfileExists")
+	defer span.End()
+
 	err := s.repository.CheckBusinessProfileExists(ctx, userID, requireVerified)
 	if err != nil {
 		if errors.Is(err, db.ErrBusinessProfileNotFound) {
 			return ErrBusinessProfileNotFound
 		}
 
-		s.logger.Info("error when check business profile exists", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Info("error when check business profile exists", zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) checkAdmin(ctx context.Context, userID int) error {
+	// TODO: подумать как лучше работать с is_admin в токене
+	isAdmin, err := s.repository.IsAdmin(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrUserNotFound) {
+			return apperror.ErrNotFound
+		}
+
+		logging.FromContext(ctx, s.logger).Error("unexpected error when user is admin checking", zap.Error(err))
 
 		return err
 	}
+	if !isAdmin {
+		return apperror.ErrForbidden
+	}
 
 	return nil
 }
@@ -357,55 +574,209 @@ func (s *service) AdminUpdateBusinessProfile(
 	adminID int,
 	data user.BusinessProfile,
 ) (*user.BusinessProfile, error) {
-	// TODO: подумать как лучше работать с is_admin в токене
-	isAdmin, err := s.repository.IsAdmin(ctx, adminID)
+	if err := s.checkAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	existingProfile, err := s.getBusinessProfile(ctx, data.UserID)
 	if err != nil {
-		if errors.Is(err, db.ErrUserNotFound) {
+		return nil, err
+	}
+
+	if err := s.validateBusinessProfileData(ctx, data); err != nil {
+		return nil, err
+	}
+
+	var updatedProfile *user.BusinessProfile
+
+	if err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// admin corrections keep the current moderation decision
+		businessProfile, err := s.repository.UpdateBusinessProfile(
+			ctx,
+			db.BusinessProfile{
+				UserID: data.UserID,
+				BusinessIndustry: db.BusinessIndustry{
+					ID: data.BusinessIndustry.ID,
+				},
+				BusinessName: data.BusinessName,
+				Country: country.Country{
+					ID: data.Country.ID,
+				},
+				State: state.State{
+					ID: data.State.ID,
+				},
+				Region: region.Region{
+					ID: data.Region.ID,
+				},
+				Email:       data.Email,
+				PhoneNumber: data.PhoneNumber,
+				Status:      existingProfile.Status,
+			},
+		)
+		if err != nil {
+			logging.FromContext(ctx, s.logger).Error("unexpected error when updating business profile", zap.Error(err))
+			return err
+		}
+
+		updatedProfile = businessProfile.ToDomain()
+
+		return s.auditRecorder.Record(ctx, audit.Record{
+			ActorID:    adminID,
+			Action:     audit.ActionBusinessProfileUpdate,
+			EntityType: audit.EntityBusinessProfile,
+			EntityID:   data.UserID,
+			Before:     existingProfile,
+			After:      updatedProfile,
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return updatedProfile, nil
+}
+
+func (s *service) getBusinessProfile(ctx context.Context, userID int) (*user.BusinessProfile, error) {
+	businessProfile, err := s.repository.GetUserBusinessProfile(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrBusinessProfileNotFound) {
 			return nil, apperror.ErrNotFound
 		}
 
-		s.logger.Error("unexpected error when user is admin checking", zap.Error(err))
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching business profile", zap.Error(err))
+		return nil, err
+	}
+
+	return businessProfile.ToDomain(), nil
+}
 
+func (s *service) GetBusinessProfiles(
+	ctx context.Context,
+	adminID int,
+	filter user.BusinessProfileFilter,
+) ([]user.BusinessProfile, error) {
+	if err := s.checkAdmin(ctx, adminID); err != nil {
 		return nil, err
 	}
-	if !isAdmin {
-		return nil, apperror.ErrForbidden
+
+	businessProfiles, err := s.repository.GetBusinessProfiles(ctx, filter.Status)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching business profiles", zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]user.BusinessProfile, len(businessProfiles))
+	for i := range businessProfiles {
+		result[i] = *businessProfiles[i].ToDomain()
 	}
 
-	if err := s.CheckBusinessProfileExists(ctx, data.UserID, false); err != nil {
+	return result, nil
+}
+
+func (s *service) GetBusinessProfile(ctx context.Context, adminID, userID int) (*user.BusinessProfile, error) {
+	if err := s.checkAdmin(ctx, adminID); err != nil {
 		return nil, err
 	}
 
-	if err := s.validateBusinessProfileData(ctx, data); err != nil {
+	return s.getBusinessProfile(ctx, userID)
+}
+
+func (s *service) ModerateBusinessProfile(
+	ctx context.Context,
+	adminID int,
+	userID int,
+	action string,
+	reason string,
+) (*user.BusinessProfile, error) {
+	if err := s.checkAdmin(ctx, adminID); err != nil {
 		return nil, err
 	}
 
-	businessProfile, err := s.repository.UpdateBusinessProfile(
-		ctx,
-		db.BusinessProfile{
-			UserID: data.UserID,
-			BusinessIndustry: db.BusinessIndustry{
-				ID: data.BusinessIndustry.ID,
-			},
-			BusinessName: data.BusinessName,
-			Country: country.Country{
-				ID: data.Country.ID,
-			},
-			State: state.State{
-				ID: data.State.ID,
-			},
-			Region: region.Region{
-				ID: data.Region.ID,
-			},
-			Email:       data.Email,
-			PhoneNumber: data.PhoneNumber,
-			IsVerified:  data.IsVerified,
-		},
-	)
+	existingProfile, err := s.getBusinessProfile(ctx, userID)
 	if err != nil {
-		s.logger.Error("unexpected error when updating business profile", zap.Error(err))
 		return nil, err
 	}
 
-	return businessProfile.ToDomain(), nil
+	if existingProfile.Status != user.BusinessProfileStatusPending {
+		return nil, ErrBusinessProfileReviewed
+	}
+
+	var (
+		status          string
+		rejectionReason *string
+		auditAction     string
+	)
+	switch action {
+	case "approve":
+		status = user.BusinessProfileStatusApproved
+		auditAction = audit.ActionBusinessProfileApprove
+	case "reject":
+		if reason == "" {
+			return nil, ErrRejectionReasonRequired
+		}
+		status = user.BusinessProfileStatusRejected
+		rejectionReason = &reason
+		auditAction = audit.ActionBusinessProfileReject
+	default:
+		return nil, apperror.NewFieldError("action", "field.one_of", "approve, reject")
+	}
+
+	var moderatedProfile *user.BusinessProfile
+
+	if err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		businessProfile, err := s.repository.SetBusinessProfileStatus(ctx, userID, status, rejectionReason, adminID)
+		if err != nil {
+			if errors.Is(err, db.ErrBusinessProfileNotFound) {
+				return ErrBusinessProfileReviewed
+			}
+
+			logging.FromContext(ctx, s.logger).Error("unexpected error when moderating business profile", zap.Error(err))
+
+			return err
+		}
+
+		moderatedProfile = businessProfile.ToDomain()
+
+		return s.auditRecorder.Record(ctx, audit.Record{
+			ActorID:    adminID,
+			Action:     auditAction,
+			EntityType: audit.EntityBusinessProfile,
+			EntityID:   userID,
+			Before:     existingProfile,
+			After:      moderatedProfile,
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	s.notifyModerationDecision(ctx, *moderatedProfile)
+
+	return moderatedProfile, nil
+}
+
+func (s *service) notifyModerationDecision(ctx context.Context, businessProfile user.BusinessProfile) {
+	owner, err := s.repository.GetByID(ctx, businessProfile.UserID)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("unexpected error when fetching business profile owner", zap.Error(err))
+		return
+	}
+
+	// the request locale is the admin's one, so only the owner preference counts
+	locale := i18n.Resolve(owner.Locale, i18n.DefaultLocale)
+
+	subject, body := i18n.Mail(locale, "email.business_profile_approved", businessProfile.BusinessName)
+
+	if businessProfile.Status == user.BusinessProfileStatusRejected {
+		subject, body = i18n.Mail(
+			locale,
+			"email.business_profile_rejected",
+			businessProfile.BusinessName,
+			*businessProfile.RejectionReason,
+		)
+	}
+
+	go func() {
+		if err := s.mailManager.SendMail(subject, body, []string{owner.Email}); err != nil {
+			logging.FromContext(ctx, s.logger).Error("unexpected error when sending email", zap.Error(err))
+		}
+	}()
 }
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS stores_hours_exceptions;
+
+DROP TABLE IF EXISTS stores_hours;
+
+ALTER TABLE states
+  DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,26 @@
+ALTER TABLE states
+  ADD COLUMN IF NOT EXISTS timezone TEXT DEFAULT 'UTC' NOT NULL;
+
+CREATE TABLE IF NOT EXISTS stores_hours (
+    id SERIAL PRIMARY KEY,
+    store_id INTEGER NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    opens_at TIME NOT NULL,
+    closes_at TIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_stores_hours_store_id
+ON stores_hours (store_id);
+
+CREATE TABLE IF NOT EXISTS stores_hours_exceptions (
+    id SERIAL PRIMARY KEY,
+    store_id INTEGER NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    is_closed BOOLEAN DEFAULT false NOT NULL,
+    opens_at TIME,
+    closes_at TIME,
+    note TEXT DEFAULT '' NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_stores_hours_exceptions_store_id_date
+ON stores_hours_exceptions (store_id, date);
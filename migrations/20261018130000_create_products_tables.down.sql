@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS products_lab_tests;
+
+DROP TABLE IF EXISTS products_variants;
+
+DROP TABLE IF EXISTS products_pictures;
+
+DROP TABLE IF EXISTS products;
+
+DROP TYPE IF EXISTS weight_unit;
+
+DROP TYPE IF EXISTS strain_type;
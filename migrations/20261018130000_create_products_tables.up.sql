@@ -0,0 +1,53 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'strain_type') THEN
+        CREATE TYPE strain_type AS ENUM ('indica', 'sativa', 'hybrid', 'cbd', 'none');
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'weight_unit') THEN
+        CREATE TYPE weight_unit AS ENUM ('mg', 'g', 'oz', 'ml', 'pcs');
+    END IF;
+END
+$$;
+
+CREATE TABLE IF NOT EXISTS products (
+    id SERIAL PRIMARY KEY,
+    brand_id INTEGER NOT NULL REFERENCES brands(id) ON DELETE CASCADE,
+    market_section_id INTEGER NOT NULL REFERENCES market_sections(id),
+    market_sub_section_id INTEGER REFERENCES market_sections(id) ON DELETE SET NULL,
+    name TEXT NOT NULL,
+    description TEXT DEFAULT '' NOT NULL,
+    strain_type strain_type DEFAULT 'none' NOT NULL,
+    thc_percent NUMERIC(5, 2) DEFAULT 0 NOT NULL CHECK (thc_percent BETWEEN 0 AND 100),
+    cbd_percent NUMERIC(5, 2) DEFAULT 0 NOT NULL CHECK (cbd_percent BETWEEN 0 AND 100),
+    is_published BOOLEAN DEFAULT false NOT NULL,
+    created_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_products_brand_id
+ON products (brand_id);
+
+CREATE TABLE IF NOT EXISTS products_pictures (
+    product_id INTEGER REFERENCES products(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    PRIMARY KEY (product_id, url)
+);
+
+CREATE TABLE IF NOT EXISTS products_variants (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    weight NUMERIC(10, 3) NOT NULL CHECK (weight > 0),
+    unit weight_unit NOT NULL,
+    UNIQUE (product_id, weight, unit)
+);
+
+CREATE TABLE IF NOT EXISTS products_lab_tests (
+    product_id INTEGER REFERENCES products(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    PRIMARY KEY (product_id, url)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS stores_menu_items;
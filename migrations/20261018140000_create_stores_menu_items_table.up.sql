@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS stores_menu_items (
+    id SERIAL PRIMARY KEY,
+    store_id INTEGER NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    product_variant_id INTEGER NOT NULL REFERENCES products_variants(id) ON DELETE CASCADE,
+    price INTEGER NOT NULL CHECK (price >= 0),
+    stock_quantity INTEGER DEFAULT 0 NOT NULL CHECK (stock_quantity >= 0),
+    in_stock BOOLEAN DEFAULT true NOT NULL,
+    sale_price INTEGER CHECK (sale_price >= 0),
+    sale_starts_at timestamp(3),
+    sale_ends_at timestamp(3),
+    created_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    UNIQUE (store_id, product_variant_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stores_menu_items_product_variant_id
+ON stores_menu_items (product_variant_id);
@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS orders_status_history;
+
+DROP TABLE IF EXISTS orders_items;
+
+DROP TABLE IF EXISTS orders;
+
+DROP TABLE IF EXISTS carts_items;
+
+DROP TABLE IF EXISTS carts;
+
+DROP TYPE IF EXISTS fulfillment_type;
+
+DROP TYPE IF EXISTS order_status;
+
+ALTER TABLE stores
+  DROP COLUMN IF EXISTS latitude,
+  DROP COLUMN IF EXISTS longitude;
@@ -0,0 +1,93 @@
+ALTER TABLE stores
+  ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION,
+  ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'order_status') THEN
+        CREATE TYPE order_status AS ENUM (
+            'pending',
+            'accepted',
+            'preparing',
+            'out_for_delivery',
+            'ready_for_pickup',
+            'completed',
+            'cancelled'
+        );
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'fulfillment_type') THEN
+        CREATE TYPE fulfillment_type AS ENUM ('delivery', 'pickup');
+    END IF;
+END
+$$;
+
+CREATE TABLE IF NOT EXISTS carts (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    store_id INTEGER NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    created_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    UNIQUE (user_id, store_id)
+);
+
+CREATE TABLE IF NOT EXISTS carts_items (
+    cart_id INTEGER NOT NULL REFERENCES carts(id) ON DELETE CASCADE,
+    menu_item_id INTEGER NOT NULL REFERENCES stores_menu_items(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (cart_id, menu_item_id)
+);
+
+CREATE TABLE IF NOT EXISTS orders (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    store_id INTEGER NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    status order_status DEFAULT 'pending' NOT NULL,
+    fulfillment fulfillment_type NOT NULL,
+    delivery_address TEXT DEFAULT '' NOT NULL,
+    delivery_latitude DOUBLE PRECISION,
+    delivery_longitude DOUBLE PRECISION,
+    items_price INTEGER NOT NULL CHECK (items_price >= 0),
+    delivery_price INTEGER NOT NULL CHECK (delivery_price >= 0),
+    total_price INTEGER NOT NULL CHECK (total_price >= 0),
+    comment TEXT DEFAULT '' NOT NULL,
+    cancel_reason TEXT DEFAULT '' NOT NULL,
+    created_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_user_id
+ON orders (user_id);
+
+CREATE INDEX IF NOT EXISTS idx_orders_store_id_status
+ON orders (store_id, status);
+
+CREATE TABLE IF NOT EXISTS orders_items (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    product_variant_id INTEGER REFERENCES products_variants(id) ON DELETE SET NULL,
+    product_id INTEGER REFERENCES products(id) ON DELETE SET NULL,
+    product_name TEXT NOT NULL,
+    brand_name TEXT NOT NULL,
+    weight NUMERIC(10, 3) NOT NULL,
+    unit weight_unit NOT NULL,
+    price INTEGER NOT NULL CHECK (price >= 0),
+    quantity INTEGER NOT NULL CHECK (quantity > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_items_order_id
+ON orders_items (order_id);
+
+CREATE TABLE IF NOT EXISTS orders_status_history (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    status order_status NOT NULL,
+    created_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_status_history_order_id
+ON orders_status_history (order_id);
@@ -0,0 +1,15 @@
+ALTER TABLE states
+    DROP COLUMN IF EXISTS minimum_age;
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS age INTEGER;
+
+UPDATE users
+SET age = date_part('year', age(date_of_birth))
+WHERE date_of_birth IS NOT NULL;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS age_verification_reference,
+    DROP COLUMN IF EXISTS age_verification_provider,
+    DROP COLUMN IF EXISTS age_verified_at,
+    DROP COLUMN IF EXISTS date_of_birth;
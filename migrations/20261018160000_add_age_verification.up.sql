@@ -0,0 +1,9 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS date_of_birth DATE,
+    ADD COLUMN IF NOT EXISTS age_verified_at timestamp(3),
+    ADD COLUMN IF NOT EXISTS age_verification_provider TEXT,
+    ADD COLUMN IF NOT EXISTS age_verification_reference TEXT,
+    DROP COLUMN IF EXISTS age;
+
+ALTER TABLE states
+    ADD COLUMN IF NOT EXISTS minimum_age INTEGER DEFAULT 21 NOT NULL CHECK (minimum_age > 0);
@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS reviews_reports;
+
+DROP TYPE IF EXISTS review_report_status;
+
+DROP TABLE IF EXISTS reviews_pictures;
+
+DROP TABLE IF EXISTS reviews;
+
+ALTER TABLE brands
+    DROP COLUMN IF EXISTS reviews_count,
+    DROP COLUMN IF EXISTS rating_sum;
+
+ALTER TABLE stores
+    DROP COLUMN IF EXISTS reviews_count,
+    DROP COLUMN IF EXISTS rating_sum;
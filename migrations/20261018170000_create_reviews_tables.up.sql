@@ -0,0 +1,53 @@
+ALTER TABLE stores
+    ADD COLUMN IF NOT EXISTS rating_sum INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS reviews_count INTEGER DEFAULT 0 NOT NULL;
+
+ALTER TABLE brands
+    ADD COLUMN IF NOT EXISTS rating_sum INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS reviews_count INTEGER DEFAULT 0 NOT NULL;
+
+CREATE TABLE IF NOT EXISTS reviews (
+    id SERIAL PRIMARY KEY,
+    store_id INTEGER NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    text TEXT DEFAULT '' NOT NULL,
+    reply TEXT,
+    replied_at timestamp(3),
+    is_hidden BOOLEAN DEFAULT false NOT NULL,
+    created_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    UNIQUE (store_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_user_id
+ON reviews (user_id);
+
+CREATE TABLE IF NOT EXISTS reviews_pictures (
+    review_id INTEGER REFERENCES reviews(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    PRIMARY KEY (review_id, url)
+);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'review_report_status') THEN
+        CREATE TYPE review_report_status AS ENUM ('pending', 'hidden', 'dismissed');
+    END IF;
+END
+$$;
+
+CREATE TABLE IF NOT EXISTS reviews_reports (
+    id SERIAL PRIMARY KEY,
+    review_id INTEGER NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    status review_report_status DEFAULT 'pending' NOT NULL,
+    moderator_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    resolved_at timestamp(3),
+    UNIQUE (review_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_reports_status
+ON reviews_reports (status);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS brands_followers;
+
+DROP TABLE IF EXISTS favorites_stores;
+
+ALTER TABLE brands
+    DROP COLUMN IF EXISTS followers_count;
+
+ALTER TABLE stores
+    DROP COLUMN IF EXISTS followers_count;
@@ -0,0 +1,25 @@
+ALTER TABLE stores
+    ADD COLUMN IF NOT EXISTS followers_count INTEGER DEFAULT 0 NOT NULL;
+
+ALTER TABLE brands
+    ADD COLUMN IF NOT EXISTS followers_count INTEGER DEFAULT 0 NOT NULL;
+
+CREATE TABLE IF NOT EXISTS favorites_stores (
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    store_id INTEGER REFERENCES stores(id) ON DELETE CASCADE,
+    created_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, store_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_favorites_stores_store_id
+ON favorites_stores (store_id);
+
+CREATE TABLE IF NOT EXISTS brands_followers (
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    brand_id INTEGER REFERENCES brands(id) ON DELETE CASCADE,
+    created_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, brand_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_brands_followers_brand_id
+ON brands_followers (brand_id);
@@ -0,0 +1,16 @@
+ALTER TABLE business_profiles
+    ADD COLUMN IF NOT EXISTS is_verified BOOLEAN DEFAULT false NOT NULL;
+
+UPDATE business_profiles
+SET is_verified = (status = 'approved');
+
+DROP INDEX IF EXISTS idx_business_profiles_status;
+
+ALTER TABLE business_profiles
+    DROP COLUMN IF EXISTS reviewed_at,
+    DROP COLUMN IF EXISTS submitted_at,
+    DROP COLUMN IF EXISTS moderator_id,
+    DROP COLUMN IF EXISTS rejection_reason,
+    DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS business_profile_status;
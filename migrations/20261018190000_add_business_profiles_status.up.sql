@@ -0,0 +1,24 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'business_profile_status') THEN
+        CREATE TYPE business_profile_status AS ENUM ('draft', 'pending', 'approved', 'rejected');
+    END IF;
+END
+$$;
+
+ALTER TABLE business_profiles
+    ADD COLUMN IF NOT EXISTS status business_profile_status DEFAULT 'draft' NOT NULL,
+    ADD COLUMN IF NOT EXISTS rejection_reason TEXT,
+    ADD COLUMN IF NOT EXISTS moderator_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS submitted_at timestamp(3),
+    ADD COLUMN IF NOT EXISTS reviewed_at timestamp(3);
+
+UPDATE business_profiles
+SET status = CASE WHEN is_verified THEN 'approved'::business_profile_status ELSE 'pending'::business_profile_status END,
+    submitted_at = CURRENT_TIMESTAMP;
+
+ALTER TABLE business_profiles
+    DROP COLUMN IF EXISTS is_verified;
+
+CREATE INDEX IF NOT EXISTS idx_business_profiles_status
+ON business_profiles (status, submitted_at);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_brands_moderation_status;
+
+ALTER TABLE brands
+    DROP COLUMN IF EXISTS reviewed_at,
+    DROP COLUMN IF EXISTS submitted_at,
+    DROP COLUMN IF EXISTS moderator_id,
+    DROP COLUMN IF EXISTS moderation_comment,
+    DROP COLUMN IF EXISTS moderation_status;
+
+DROP TYPE IF EXISTS brand_moderation_status;
@@ -0,0 +1,22 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'brand_moderation_status') THEN
+        CREATE TYPE brand_moderation_status AS ENUM ('draft', 'pending', 'approved', 'rejected');
+    END IF;
+END
+$$;
+
+ALTER TABLE brands
+    ADD COLUMN IF NOT EXISTS moderation_status brand_moderation_status DEFAULT 'draft' NOT NULL,
+    ADD COLUMN IF NOT EXISTS moderation_comment TEXT,
+    ADD COLUMN IF NOT EXISTS moderator_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS submitted_at timestamp(3),
+    ADD COLUMN IF NOT EXISTS reviewed_at timestamp(3);
+
+-- brands that are already live are treated as approved
+UPDATE brands
+SET moderation_status = 'approved', reviewed_at = CURRENT_TIMESTAMP
+WHERE is_published = true;
+
+CREATE INDEX IF NOT EXISTS idx_brands_moderation_status
+ON brands (moderation_status, submitted_at);
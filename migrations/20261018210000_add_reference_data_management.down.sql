@@ -0,0 +1,77 @@
+-- restores the original cascading foreign keys
+
+ALTER TABLE states
+    DROP CONSTRAINT IF EXISTS states_country_id_fkey,
+    ADD CONSTRAINT states_country_id_fkey FOREIGN KEY (country_id) REFERENCES countries(id) ON DELETE CASCADE;
+
+ALTER TABLE regions
+    DROP CONSTRAINT IF EXISTS regions_state_id_fkey,
+    ADD CONSTRAINT regions_state_id_fkey FOREIGN KEY (state_id) REFERENCES states(id) ON DELETE CASCADE;
+
+ALTER TABLE brands
+    DROP CONSTRAINT IF EXISTS brands_country_id_fkey,
+    ADD CONSTRAINT brands_country_id_fkey FOREIGN KEY (country_id) REFERENCES countries(id) ON DELETE CASCADE;
+
+ALTER TABLE brands
+    DROP CONSTRAINT IF EXISTS brands_market_section_id_fkey,
+    ADD CONSTRAINT brands_market_section_id_fkey FOREIGN KEY (market_section_id) REFERENCES market_sections(id) ON DELETE CASCADE;
+
+ALTER TABLE brands_market_sub_sections
+    DROP CONSTRAINT IF EXISTS brands_market_sub_sections_market_section_id_fkey,
+    ADD CONSTRAINT brands_market_sub_sections_market_section_id_fkey FOREIGN KEY (market_section_id) REFERENCES market_sections(id) ON DELETE CASCADE;
+
+ALTER TABLE brands_states
+    DROP CONSTRAINT IF EXISTS brands_states_state_id_fkey,
+    ADD CONSTRAINT brands_states_state_id_fkey FOREIGN KEY (state_id) REFERENCES states(id) ON DELETE CASCADE;
+
+ALTER TABLE brands_socials
+    DROP CONSTRAINT IF EXISTS brands_socials_social_id_fkey,
+    ADD CONSTRAINT brands_socials_social_id_fkey FOREIGN KEY (social_id) REFERENCES socials(id) ON DELETE CASCADE;
+
+ALTER TABLE stores
+    DROP CONSTRAINT IF EXISTS stores_country_id_fkey,
+    ADD CONSTRAINT stores_country_id_fkey FOREIGN KEY (country_id) REFERENCES countries(id) ON DELETE CASCADE;
+
+ALTER TABLE stores
+    DROP CONSTRAINT IF EXISTS stores_state_id_fkey,
+    ADD CONSTRAINT stores_state_id_fkey FOREIGN KEY (state_id) REFERENCES states(id) ON DELETE CASCADE;
+
+ALTER TABLE stores
+    DROP CONSTRAINT IF EXISTS stores_region_id_fkey,
+    ADD CONSTRAINT stores_region_id_fkey FOREIGN KEY (region_id) REFERENCES regions(id) ON DELETE CASCADE;
+
+ALTER TABLE stores
+    DROP CONSTRAINT IF EXISTS stores_store_type_id_fkey,
+    ADD CONSTRAINT stores_store_type_id_fkey FOREIGN KEY (store_type_id) REFERENCES store_types(id) ON DELETE CASCADE;
+
+ALTER TABLE stores_socials
+    DROP CONSTRAINT IF EXISTS stores_socials_social_id_fkey,
+    ADD CONSTRAINT stores_socials_social_id_fkey FOREIGN KEY (social_id) REFERENCES socials(id) ON DELETE CASCADE;
+
+ALTER TABLE countries
+    DROP COLUMN IF EXISTS is_active,
+    DROP COLUMN IF EXISTS position;
+
+ALTER TABLE states
+    DROP COLUMN IF EXISTS is_active,
+    DROP COLUMN IF EXISTS position;
+
+ALTER TABLE regions
+    DROP COLUMN IF EXISTS is_active,
+    DROP COLUMN IF EXISTS position;
+
+ALTER TABLE business_industries
+    DROP COLUMN IF EXISTS is_active,
+    DROP COLUMN IF EXISTS position;
+
+ALTER TABLE market_sections
+    DROP COLUMN IF EXISTS is_active,
+    DROP COLUMN IF EXISTS position;
+
+ALTER TABLE socials
+    DROP COLUMN IF EXISTS is_active,
+    DROP COLUMN IF EXISTS position;
+
+ALTER TABLE store_types
+    DROP COLUMN IF EXISTS is_active,
+    DROP COLUMN IF EXISTS position;
@@ -0,0 +1,91 @@
+ALTER TABLE countries
+    ADD COLUMN IF NOT EXISTS position INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS is_active BOOLEAN DEFAULT true NOT NULL;
+
+UPDATE countries SET position = id;
+
+ALTER TABLE states
+    ADD COLUMN IF NOT EXISTS position INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS is_active BOOLEAN DEFAULT true NOT NULL;
+
+UPDATE states SET position = id;
+
+ALTER TABLE regions
+    ADD COLUMN IF NOT EXISTS position INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS is_active BOOLEAN DEFAULT true NOT NULL;
+
+UPDATE regions SET position = id;
+
+ALTER TABLE business_industries
+    ADD COLUMN IF NOT EXISTS position INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS is_active BOOLEAN DEFAULT true NOT NULL;
+
+UPDATE business_industries SET position = id;
+
+ALTER TABLE market_sections
+    ADD COLUMN IF NOT EXISTS position INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS is_active BOOLEAN DEFAULT true NOT NULL;
+
+UPDATE market_sections SET position = id;
+
+ALTER TABLE socials
+    ADD COLUMN IF NOT EXISTS position INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS is_active BOOLEAN DEFAULT true NOT NULL;
+
+UPDATE socials SET position = id;
+
+ALTER TABLE store_types
+    ADD COLUMN IF NOT EXISTS position INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS is_active BOOLEAN DEFAULT true NOT NULL;
+
+UPDATE store_types SET position = id;
+
+-- reference rows are deactivated instead of deleted, so deleting one must never cascade into businesses
+
+ALTER TABLE states
+    DROP CONSTRAINT IF EXISTS states_country_id_fkey,
+    ADD CONSTRAINT states_country_id_fkey FOREIGN KEY (country_id) REFERENCES countries(id) ON DELETE RESTRICT;
+
+ALTER TABLE regions
+    DROP CONSTRAINT IF EXISTS regions_state_id_fkey,
+    ADD CONSTRAINT regions_state_id_fkey FOREIGN KEY (state_id) REFERENCES states(id) ON DELETE RESTRICT;
+
+ALTER TABLE brands
+    DROP CONSTRAINT IF EXISTS brands_country_id_fkey,
+    ADD CONSTRAINT brands_country_id_fkey FOREIGN KEY (country_id) REFERENCES countries(id) ON DELETE RESTRICT;
+
+ALTER TABLE brands
+    DROP CONSTRAINT IF EXISTS brands_market_section_id_fkey,
+    ADD CONSTRAINT brands_market_section_id_fkey FOREIGN KEY (market_section_id) REFERENCES market_sections(id) ON DELETE RESTRICT;
+
+ALTER TABLE brands_market_sub_sections
+    DROP CONSTRAINT IF EXISTS brands_market_sub_sections_market_section_id_fkey,
+    ADD CONSTRAINT brands_market_sub_sections_market_section_id_fkey FOREIGN KEY (market_section_id) REFERENCES market_sections(id) ON DELETE RESTRICT;
+
+ALTER TABLE brands_states
+    DROP CONSTRAINT IF EXISTS brands_states_state_id_fkey,
+    ADD CONSTRAINT brands_states_state_id_fkey FOREIGN KEY (state_id) REFERENCES states(id) ON DELETE RESTRICT;
+
+ALTER TABLE brands_socials
+    DROP CONSTRAINT IF EXISTS brands_socials_social_id_fkey,
+    ADD CONSTRAINT brands_socials_social_id_fkey FOREIGN KEY (social_id) REFERENCES socials(id) ON DELETE RESTRICT;
+
+ALTER TABLE stores
+    DROP CONSTRAINT IF EXISTS stores_country_id_fkey,
+    ADD CONSTRAINT stores_country_id_fkey FOREIGN KEY (country_id) REFERENCES countries(id) ON DELETE RESTRICT;
+
+ALTER TABLE stores
+    DROP CONSTRAINT IF EXISTS stores_state_id_fkey,
+    ADD CONSTRAINT stores_state_id_fkey FOREIGN KEY (state_id) REFERENCES states(id) ON DELETE RESTRICT;
+
+ALTER TABLE stores
+    DROP CONSTRAINT IF EXISTS stores_region_id_fkey,
+    ADD CONSTRAINT stores_region_id_fkey FOREIGN KEY (region_id) REFERENCES regions(id) ON DELETE RESTRICT;
+
+ALTER TABLE stores
+    DROP CONSTRAINT IF EXISTS stores_store_type_id_fkey,
+    ADD CONSTRAINT stores_store_type_id_fkey FOREIGN KEY (store_type_id) REFERENCES store_types(id) ON DELETE RESTRICT;
+
+ALTER TABLE stores_socials
+    DROP CONSTRAINT IF EXISTS stores_socials_social_id_fkey,
+    ADD CONSTRAINT stores_socials_social_id_fkey FOREIGN KEY (social_id) REFERENCES socials(id) ON DELETE RESTRICT;
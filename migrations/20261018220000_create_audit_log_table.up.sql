@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(64) NOT NULL,
+    entity_type VARCHAR(64) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    before JSONB,
+    after JSONB,
+    ip VARCHAR(64),
+    request_id VARCHAR(128),
+    created_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity
+ON audit_log (entity_type, entity_id, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor_id
+ON audit_log (actor_id, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at
+ON audit_log (created_at);
@@ -0,0 +1,5 @@
+ALTER TABLE stores
+    DROP COLUMN IF EXISTS version;
+
+ALTER TABLE brands
+    DROP COLUMN IF EXISTS version;
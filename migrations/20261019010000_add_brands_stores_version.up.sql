@@ -0,0 +1,5 @@
+ALTER TABLE brands
+    ADD COLUMN IF NOT EXISTS version INTEGER DEFAULT 1 NOT NULL;
+
+ALTER TABLE stores
+    ADD COLUMN IF NOT EXISTS version INTEGER DEFAULT 1 NOT NULL;
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS team_invites;
+
+DROP TABLE IF EXISTS team_members_stores;
+
+DROP TABLE IF EXISTS team_members;
+
+DROP TYPE IF EXISTS team_invite_status;
+
+DROP TYPE IF EXISTS team_role;
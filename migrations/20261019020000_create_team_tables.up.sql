@@ -0,0 +1,61 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'team_role') THEN
+        CREATE TYPE team_role AS ENUM ('owner', 'manager', 'staff');
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'team_invite_status') THEN
+        CREATE TYPE team_invite_status AS ENUM ('pending', 'accepted', 'declined', 'revoked');
+    END IF;
+END
+$$;
+
+CREATE TABLE IF NOT EXISTS team_members (
+    business_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    role team_role NOT NULL,
+    created_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (business_id, user_id),
+    FOREIGN KEY (business_id) REFERENCES business_profiles(user_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_members_user_id
+ON team_members (user_id);
+
+CREATE TABLE IF NOT EXISTS team_members_stores (
+    business_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    store_id INTEGER NOT NULL,
+
+    PRIMARY KEY (business_id, user_id, store_id),
+    FOREIGN KEY (business_id, user_id) REFERENCES team_members(business_id, user_id) ON DELETE CASCADE,
+    FOREIGN KEY (store_id) REFERENCES stores(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS team_invites (
+    id SERIAL PRIMARY KEY,
+    business_id INTEGER NOT NULL,
+    email TEXT NOT NULL,
+    role team_role NOT NULL,
+    store_ids INTEGER[] DEFAULT '{}' NOT NULL,
+    inviter_id INTEGER,
+    status team_invite_status DEFAULT 'pending' NOT NULL,
+    created_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    responded_at timestamp(3),
+
+    FOREIGN KEY (business_id) REFERENCES business_profiles(user_id) ON DELETE CASCADE,
+    FOREIGN KEY (inviter_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_team_invites_pending
+ON team_invites (business_id, lower(email)) WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_team_invites_email
+ON team_invites (lower(email)) WHERE status = 'pending';
+
+-- every business is owned by the user who created its profile
+INSERT INTO team_members (business_id, user_id, role)
+SELECT user_id, user_id, 'owner'
+FROM business_profiles
+ON CONFLICT DO NOTHING;
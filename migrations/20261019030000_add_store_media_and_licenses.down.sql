@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS stores_licenses;
+
+DROP INDEX IF EXISTS idx_stores_pictures_store_id_position;
+
+ALTER TABLE stores_pictures
+    DROP CONSTRAINT IF EXISTS stores_pictures_store_id_url_key,
+    DROP COLUMN IF EXISTS position,
+    DROP COLUMN IF EXISTS id,
+    ADD PRIMARY KEY (store_id, url);
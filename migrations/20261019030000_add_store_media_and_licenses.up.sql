@@ -0,0 +1,38 @@
+ALTER TABLE stores_pictures
+    DROP CONSTRAINT IF EXISTS stores_pictures_pkey,
+    ADD COLUMN IF NOT EXISTS id SERIAL PRIMARY KEY,
+    ADD COLUMN IF NOT EXISTS position INTEGER DEFAULT 0 NOT NULL;
+
+UPDATE stores_pictures sp
+SET position = n.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY store_id ORDER BY id) - 1 AS position
+    FROM stores_pictures
+) n
+WHERE sp.id = n.id;
+
+ALTER TABLE stores_pictures
+    ADD CONSTRAINT stores_pictures_store_id_url_key UNIQUE (store_id, url);
+
+CREATE INDEX IF NOT EXISTS idx_stores_pictures_store_id_position
+ON stores_pictures (store_id, position);
+
+CREATE TABLE IF NOT EXISTS stores_licenses (
+    id SERIAL PRIMARY KEY,
+    store_id INTEGER NOT NULL,
+    number TEXT NOT NULL,
+    url TEXT NOT NULL,
+    expires_at DATE NOT NULL,
+    reminded_at timestamp(3),
+    created_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    FOREIGN KEY (store_id) REFERENCES stores(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_stores_licenses_store_id
+ON stores_licenses (store_id);
+
+CREATE INDEX IF NOT EXISTS idx_stores_licenses_expires_at
+ON stores_licenses (expires_at)
+WHERE reminded_at IS NULL;
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS orders_promotions;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS coupon_code,
+    DROP COLUMN IF EXISTS discount_price;
+
+DROP TABLE IF EXISTS promotions_brands;
+DROP TABLE IF EXISTS promotions_market_sections;
+DROP TABLE IF EXISTS promotions_windows;
+DROP TABLE IF EXISTS promotions;
@@ -0,0 +1,67 @@
+CREATE TABLE IF NOT EXISTS promotions (
+    id SERIAL PRIMARY KEY,
+    store_id INTEGER NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT DEFAULT '' NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('percentage', 'fixed', 'bogo')),
+    value INTEGER DEFAULT 0 NOT NULL CHECK (value >= 0),
+    buy_quantity INTEGER DEFAULT 0 NOT NULL CHECK (buy_quantity >= 0),
+    get_quantity INTEGER DEFAULT 0 NOT NULL CHECK (get_quantity >= 0),
+    starts_at timestamp(3),
+    ends_at timestamp(3),
+    coupon_code TEXT,
+    usage_limit INTEGER CHECK (usage_limit > 0),
+    per_user_limit INTEGER CHECK (per_user_limit > 0),
+    usage_count INTEGER DEFAULT 0 NOT NULL CHECK (usage_count >= 0),
+    is_active BOOLEAN DEFAULT true NOT NULL,
+    created_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp(3) DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotions_store_id
+ON promotions (store_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_promotions_store_id_coupon_code
+ON promotions (store_id, lower(coupon_code))
+WHERE coupon_code IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS promotions_windows (
+    id SERIAL PRIMARY KEY,
+    promotion_id INTEGER NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    starts_at TIME NOT NULL,
+    ends_at TIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotions_windows_promotion_id
+ON promotions_windows (promotion_id);
+
+CREATE TABLE IF NOT EXISTS promotions_market_sections (
+    promotion_id INTEGER NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    market_section_id INTEGER NOT NULL REFERENCES market_sections(id) ON DELETE CASCADE,
+    PRIMARY KEY (promotion_id, market_section_id)
+);
+
+CREATE TABLE IF NOT EXISTS promotions_brands (
+    promotion_id INTEGER NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    brand_id INTEGER NOT NULL REFERENCES brands(id) ON DELETE CASCADE,
+    PRIMARY KEY (promotion_id, brand_id)
+);
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS discount_price INTEGER DEFAULT 0 NOT NULL CHECK (discount_price >= 0),
+    ADD COLUMN IF NOT EXISTS coupon_code TEXT;
+
+CREATE TABLE IF NOT EXISTS orders_promotions (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    promotion_id INTEGER REFERENCES promotions(id) ON DELETE SET NULL,
+    name TEXT NOT NULL,
+    amount INTEGER NOT NULL CHECK (amount >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_promotions_order_id
+ON orders_promotions (order_id);
+
+CREATE INDEX IF NOT EXISTS idx_orders_promotions_promotion_id
+ON orders_promotions (promotion_id);
@@ -0,0 +1,17 @@
+-- restores the original cascading foreign keys, orders left without a store or a user are dropped
+
+DELETE FROM orders
+WHERE user_id IS NULL OR store_id IS NULL;
+
+ALTER TABLE orders
+    DROP CONSTRAINT IF EXISTS orders_user_id_fkey,
+    ADD CONSTRAINT orders_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    DROP CONSTRAINT IF EXISTS orders_store_id_fkey,
+    ADD CONSTRAINT orders_store_id_fkey FOREIGN KEY (store_id) REFERENCES stores(id) ON DELETE CASCADE,
+    ALTER COLUMN user_id SET NOT NULL,
+    ALTER COLUMN store_id SET NOT NULL;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS store_name,
+    DROP COLUMN IF EXISTS customer_name,
+    DROP COLUMN IF EXISTS customer_email;
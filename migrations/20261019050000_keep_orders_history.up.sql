@@ -0,0 +1,25 @@
+-- orders are the sales history of both sides, so deleting a store or a user
+-- keeps them with a snapshot of who they were made by and at
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS store_name TEXT DEFAULT '' NOT NULL,
+    ADD COLUMN IF NOT EXISTS customer_name TEXT DEFAULT '' NOT NULL,
+    ADD COLUMN IF NOT EXISTS customer_email TEXT DEFAULT '' NOT NULL;
+
+UPDATE orders o
+SET store_name = s.name
+FROM stores s
+WHERE o.store_id = s.id;
+
+UPDATE orders o
+SET customer_name = concat_ws(' ', u.first_name, u.last_name), customer_email = u.email
+FROM users u
+WHERE o.user_id = u.id;
+
+ALTER TABLE orders
+    ALTER COLUMN user_id DROP NOT NULL,
+    ALTER COLUMN store_id DROP NOT NULL,
+    DROP CONSTRAINT IF EXISTS orders_user_id_fkey,
+    ADD CONSTRAINT orders_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL,
+    DROP CONSTRAINT IF EXISTS orders_store_id_fkey,
+    ADD CONSTRAINT orders_store_id_fkey FOREIGN KEY (store_id) REFERENCES stores(id) ON DELETE SET NULL;
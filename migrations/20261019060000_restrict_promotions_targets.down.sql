@@ -0,0 +1,9 @@
+-- restores the original cascading foreign keys
+
+ALTER TABLE promotions_market_sections
+    DROP CONSTRAINT IF EXISTS promotions_market_sections_market_section_id_fkey,
+    ADD CONSTRAINT promotions_market_sections_market_section_id_fkey FOREIGN KEY (market_section_id) REFERENCES market_sections(id) ON DELETE CASCADE;
+
+ALTER TABLE promotions_brands
+    DROP CONSTRAINT IF EXISTS promotions_brands_brand_id_fkey,
+    ADD CONSTRAINT promotions_brands_brand_id_fkey FOREIGN KEY (brand_id) REFERENCES brands(id) ON DELETE CASCADE;
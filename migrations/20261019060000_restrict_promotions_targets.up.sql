@@ -0,0 +1,10 @@
+-- a promotion losing its only target would apply to the whole store, so
+-- targets can not be deleted from under it
+
+ALTER TABLE promotions_market_sections
+    DROP CONSTRAINT IF EXISTS promotions_market_sections_market_section_id_fkey,
+    ADD CONSTRAINT promotions_market_sections_market_section_id_fkey FOREIGN KEY (market_section_id) REFERENCES market_sections(id) ON DELETE RESTRICT;
+
+ALTER TABLE promotions_brands
+    DROP CONSTRAINT IF EXISTS promotions_brands_brand_id_fkey,
+    ADD CONSTRAINT promotions_brands_brand_id_fkey FOREIGN KEY (brand_id) REFERENCES brands(id) ON DELETE RESTRICT;
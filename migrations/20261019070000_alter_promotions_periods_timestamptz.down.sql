@@ -0,0 +1,3 @@
+ALTER TABLE promotions
+    ALTER COLUMN starts_at TYPE timestamp(3) USING starts_at AT TIME ZONE 'UTC',
+    ALTER COLUMN ends_at TYPE timestamp(3) USING ends_at AT TIME ZONE 'UTC';
@@ -0,0 +1,6 @@
+-- timestamp without time zone dropped the offset the period was sent with,
+-- existing values are read as UTC
+
+ALTER TABLE promotions
+    ALTER COLUMN starts_at TYPE timestamptz(3) USING starts_at AT TIME ZONE 'UTC',
+    ALTER COLUMN ends_at TYPE timestamptz(3) USING ends_at AT TIME ZONE 'UTC';
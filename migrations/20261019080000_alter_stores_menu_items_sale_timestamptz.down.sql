@@ -0,0 +1,3 @@
+ALTER TABLE stores_menu_items
+    ALTER COLUMN sale_starts_at TYPE timestamp(3) USING sale_starts_at AT TIME ZONE 'UTC',
+    ALTER COLUMN sale_ends_at TYPE timestamp(3) USING sale_ends_at AT TIME ZONE 'UTC';
@@ -0,0 +1,6 @@
+-- timestamp without time zone dropped the offset the sale period was sent with,
+-- existing values are read as UTC
+
+ALTER TABLE stores_menu_items
+    ALTER COLUMN sale_starts_at TYPE timestamptz(3) USING sale_starts_at AT TIME ZONE 'UTC',
+    ALTER COLUMN sale_ends_at TYPE timestamptz(3) USING sale_ends_at AT TIME ZONE 'UTC';
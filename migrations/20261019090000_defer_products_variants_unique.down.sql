@@ -0,0 +1,3 @@
+ALTER TABLE products_variants
+    DROP CONSTRAINT IF EXISTS products_variants_product_id_weight_unit_key,
+    ADD CONSTRAINT products_variants_product_id_weight_unit_key UNIQUE (product_id, weight, unit);
@@ -0,0 +1,4 @@
+-- checked at commit so that two variants can swap their weight and unit in one save
+ALTER TABLE products_variants
+    DROP CONSTRAINT IF EXISTS products_variants_product_id_weight_unit_key,
+    ADD CONSTRAINT products_variants_product_id_weight_unit_key UNIQUE (product_id, weight, unit) DEFERRABLE INITIALLY IMMEDIATE;
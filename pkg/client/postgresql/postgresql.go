@@ -25,7 +25,14 @@ func New(ctx context.Context, cfg Config) (*pgxpool.Pool, error) {
 		cfg.Database,
 	)
 
-	dbpool, err := pgxpool.New(ctx, dsn)
+	poolConfig, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse connection config: %v", err)
+	}
+
+	poolConfig.ConnConfig.Tracer = queryTracer{database: cfg.Database}
+
+	dbpool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create connection pool: %v", err)
 	}
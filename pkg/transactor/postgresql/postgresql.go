@@ -43,3 +43,13 @@ func GetExecutor(ctx context.Context, db dbExecutor) dbExecutor {
 	}
 	return db
 }
+
+// Begin starts a transaction for a repository method that needs one of its
+// own. Inside WithinTransaction it opens a savepoint on the outer transaction,
+// so the work is committed or rolled back together with it.
+func Begin(ctx context.Context, db *pgxpool.Pool) (pgx.Tx, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Begin(ctx)
+	}
+	return db.Begin(ctx)
+}
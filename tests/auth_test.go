@@ -50,8 +50,8 @@ func (s *APITestSuite) TestRegister() {
 
 	appErr, err := decodeResponseBody[apperror.AppError](response)
 	require.NoError(err)
-	require.Equal(http.StatusBadRequest, response.StatusCode)
-	require.Equal(authservice.ErrEmailAlreadyExists.Error(), appErr.Message)
+	require.Equal(http.StatusConflict, response.StatusCode)
+	require.Equal(authservice.ErrEmailAlreadyExists.Code, appErr.Code)
 
 	// проверка что код появился в бд
 	var createdCodeValue string
@@ -228,8 +228,8 @@ func (s *APITestSuite) TestSaveProfile() {
 
 	appErr, err := decodeResponseBody[apperror.AppError](response)
 	require.NoError(err)
-	require.Equal(http.StatusBadRequest, response.StatusCode)
-	require.Equal(authservice.ErrUsernameAlreadyExists.Error(), appErr.Message)
+	require.Equal(http.StatusConflict, response.StatusCode)
+	require.Equal(authservice.ErrUsernameAlreadyExists.Code, appErr.Code)
 
 	// с токеном + валидным username
 	valid := auth.ProfileRequest{